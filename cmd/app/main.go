@@ -2,8 +2,8 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
-	"os"
 
 	"github.com/I-Van-Radkov/subscription-service/internal/app"
 	"github.com/I-Van-Radkov/subscription-service/internal/config"
@@ -11,19 +11,22 @@ import (
 )
 
 func main() {
-	envPath := os.Getenv("ENV_PATH")
-	if envPath == "" {
-		envPath = "./config/.env"
-	}
+	var configPath string
+	flag.StringVar(&configPath, "config", "", "path to config file (yaml, toml, json or .env)")
+	flag.Parse()
 
-	cfg, err := config.ParseConfigFromEnv()
+	cfg, err := config.Load(config.ResolvePath(configPath))
 	if err != nil {
-		panic(fmt.Errorf("failed to parse config: %w", err))
+		panic(fmt.Errorf("failed to load config: %w", err))
 	}
 
-	lg := logger.NewLogger(cfg.Env)
+	lg, err := logger.NewLogger(cfg.Env, cfg.LoggerConfig)
+	if err != nil {
+		panic(fmt.Errorf("failed to create logger: %w", err))
+	}
+	defer lg.Sync()
 
-	ctx := logger.WithRequestID(context.Background(), "12345678")
+	ctx := context.Background()
 	lg.Info(ctx, "starting server")
 
 	app, err := app.NewApp(cfg, lg)
@@ -1,26 +1,70 @@
 package main
 
 import (
+	"bufio"
 	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/I-Van-Radkov/subscription-service/internal/config"
+	"github.com/I-Van-Radkov/subscription-service/migrations"
+	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
 	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/joho/godotenv"
 )
 
+const usage = `Usage: migrate [flags] <command> [args]
+
+Commands:
+  up              apply all pending migrations
+  down            roll back all applied migrations
+  steps N         apply N migrations (N > 0) or roll back -N migrations (N < 0)
+  goto V          migrate up or down to version V
+  force V         set version V and clear the dirty flag without running migrations
+  version         print the current version
+  status          list applied and pending migrations
+  drop            drop everything in the database (asks for confirmation)
+  create NAME     create up/down files in -path (default ./migrations) numbered after the
+                  latest one (000011 after 000010), not by timestamp, to match existing files
+
+Flags:
+`
+
 func main() {
-	var migrationsPath string
-	var command string
+	var (
+		migrationsPath string
+		command        string
+		dryRun         bool
+		yes            bool
+	)
 
-	flag.StringVar(&migrationsPath, "path", "./migrations", "path to migrations directory")
-	flag.StringVar(&command, "command", "up", "migration command: up, down, force, version")
+	flag.StringVar(&migrationsPath, "path", "", "path to migrations directory (default: migrations embedded in the binary)")
+	flag.StringVar(&command, "command", "up", "migration command; the first positional argument takes precedence")
+	flag.BoolVar(&dryRun, "dry-run", false, "print the SQL that up, down, steps and goto would execute without running it")
+	flag.BoolVar(&yes, "yes", false, "do not ask for confirmation in drop")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
+	args := flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	if command == "create" {
+		if err := createMigration(migrationsPath, args); err != nil {
+			fmt.Printf("failed to create migration: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	envPath := os.Getenv("ENV_PATH")
 	if envPath == "" {
 		envPath = "./config/.env.local"
@@ -36,50 +80,129 @@ func main() {
 		os.Exit(1)
 	}
 
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-		cfg.PostgresConfig.Username,
-		cfg.PostgresConfig.Password,
-		cfg.PostgresConfig.Host,
-		cfg.PostgresConfig.Port,
-		cfg.PostgresConfig.DbName,
-	)
+	var source fs.FS = migrations.FS
+	if migrationsPath != "" {
+		source = os.DirFS(migrationsPath)
+	}
 
-	m, err := migrate.New(
-		fmt.Sprintf("file://%s", migrationsPath),
-		dsn,
-	)
+	m, err := postgres.NewMigrate(cfg.PostgresConfig, source)
 	if err != nil {
 		fmt.Printf("failed to create migrate instance: %v\n", err)
 		os.Exit(1)
 	}
-	defer m.Close()
+
+	err = run(m, source, cfg.PostgresConfig.DbName, command, args, dryRun, yes)
+	m.Close()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func run(m *migrate.Migrate, source fs.FS, dbName, command string, args []string, dryRun, yes bool) error {
+	switch command {
+	case "up", "down", "steps", "goto":
+		if dryRun {
+			return printPlan(m, source, command, args)
+		}
+	}
 
 	switch command {
 	case "up":
 		if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-			fmt.Printf("failed to apply migrations: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("failed to apply migrations: %w", err)
 		}
 		fmt.Println("Migrations applied successfully!")
 
 	case "down":
 		if err := m.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-			fmt.Printf("failed to rollback migrations: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("failed to rollback migrations: %w", err)
 		}
 		fmt.Println("Migrations rolled back successfully!")
 
+	case "steps":
+		n, err := intArg(args, "steps N")
+		if err != nil {
+			return err
+		}
+		if err := m.Steps(n); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("failed to migrate %d steps: %w", n, err)
+		}
+		fmt.Printf("Migrated %d steps successfully!\n", n)
+
+	case "goto":
+		v, err := versionArg(args, "goto V")
+		if err != nil {
+			return err
+		}
+		if err := m.Migrate(v); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			return fmt.Errorf("failed to migrate to version %d: %w", v, err)
+		}
+		fmt.Printf("Migrated to version %d successfully!\n", v)
+
+	case "force":
+		v, err := intArg(args, "force V")
+		if err != nil {
+			return err
+		}
+		if err := m.Force(v); err != nil {
+			return fmt.Errorf("failed to force version %d: %w", v, err)
+		}
+		fmt.Printf("Version forced to %d, dirty flag cleared\n", v)
+
 	case "version":
 		version, dirty, err := m.Version()
 		if err != nil {
-			fmt.Printf("failed to get version: %v\n", err)
-			os.Exit(1)
+			return fmt.Errorf("failed to get version: %w", err)
 		}
 		fmt.Printf("Current version: %d, Dirty: %v\n", version, dirty)
 
+	case "status":
+		return printStatus(m, source)
+
+	case "drop":
+		if !yes && !confirm(fmt.Sprintf("This will drop ALL tables in database %q. Type the database name to confirm: ", dbName), dbName) {
+			return errors.New("drop cancelled")
+		}
+		if err := m.Drop(); err != nil {
+			return fmt.Errorf("failed to drop database: %w", err)
+		}
+		fmt.Println("Database dropped successfully!")
+
 	default:
-		fmt.Printf("unknown command: %s\n", command)
-		fmt.Println("Available commands: up, down, version")
-		os.Exit(1)
+		return fmt.Errorf("unknown command: %s\nAvailable commands: up, down, steps, goto, force, version, status, drop, create", command)
+	}
+
+	return nil
+}
+
+func intArg(args []string, syntax string) (int, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("usage: %s", syntax)
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, fmt.Errorf("usage: %s: %w", syntax, err)
+	}
+	return n, nil
+}
+
+func versionArg(args []string, syntax string) (uint, error) {
+	if len(args) != 1 {
+		return 0, fmt.Errorf("usage: %s", syntax)
+	}
+	v, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("usage: %s: %w", syntax, err)
+	}
+	return uint(v), nil
+}
+
+func confirm(prompt, expected string) bool {
+	fmt.Print(prompt)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
 	}
+	return strings.TrimSpace(answer) == expected
 }
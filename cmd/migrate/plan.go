@@ -0,0 +1,242 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/I-Van-Radkov/subscription-service/migrations"
+	"github.com/golang-migrate/migrate/v4"
+)
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+var migrationNameRe = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+type migrationFile struct {
+	version uint
+	name    string
+	up      string
+	down    string
+}
+
+// readMigrations возвращает миграции из source, отсортированные по версии.
+func readMigrations(source fs.FS) ([]migrationFile, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := map[uint]*migrationFile{}
+	for _, e := range entries {
+		match := migrationFileRe.FindStringSubmatch(e.Name())
+		if match == nil {
+			continue
+		}
+		v, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", e.Name(), err)
+		}
+
+		mf, ok := byVersion[uint(v)]
+		if !ok {
+			mf = &migrationFile{version: uint(v), name: match[2]}
+			byVersion[uint(v)] = mf
+		}
+		if match[3] == "up" {
+			mf.up = e.Name()
+		} else {
+			mf.down = e.Name()
+		}
+	}
+
+	files := make([]migrationFile, 0, len(byVersion))
+	for _, mf := range byVersion {
+		files = append(files, *mf)
+	}
+	slices.SortFunc(files, func(a, b migrationFile) int {
+		return cmp.Compare(a.version, b.version)
+	})
+
+	return files, nil
+}
+
+func currentVersion(m *migrate.Migrate) (uint, bool, error) {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+func printStatus(m *migrate.Migrate, source fs.FS) error {
+	files, err := readMigrations(source)
+	if err != nil {
+		return err
+	}
+	current, dirty, err := currentVersion(m)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Current version: %d, Dirty: %v\n\n", current, dirty)
+	fmt.Printf("%-16s %-10s %s\n", "VERSION", "STATE", "NAME")
+	for _, f := range files {
+		state := "pending"
+		switch {
+		case f.version == current && dirty:
+			state = "dirty"
+		case f.version <= current:
+			state = "applied"
+		}
+		fmt.Printf("%-16d %-10s %s\n", f.version, state, f.name)
+	}
+
+	return nil
+}
+
+// plan возвращает миграции в порядке выполнения и направление для команды.
+func plan(files []migrationFile, current uint, command string, args []string) ([]migrationFile, bool, error) {
+	var applied, pending []migrationFile
+	for _, f := range files {
+		if f.version <= current {
+			applied = append(applied, f)
+		} else {
+			pending = append(pending, f)
+		}
+	}
+	slices.Reverse(applied)
+
+	switch command {
+	case "up":
+		return pending, true, nil
+	case "down":
+		return applied, false, nil
+	case "steps":
+		n, err := intArg(args, "steps N")
+		if err != nil {
+			return nil, false, err
+		}
+		if n >= 0 {
+			if n > len(pending) {
+				return nil, false, fmt.Errorf("only %d pending migrations", len(pending))
+			}
+			return pending[:n], true, nil
+		}
+		if -n > len(applied) {
+			return nil, false, fmt.Errorf("only %d applied migrations", len(applied))
+		}
+		return applied[:-n], false, nil
+	case "goto":
+		v, err := versionArg(args, "goto V")
+		if err != nil {
+			return nil, false, err
+		}
+		if !slices.ContainsFunc(files, func(f migrationFile) bool { return f.version == v }) {
+			return nil, false, fmt.Errorf("no migration with version %d", v)
+		}
+		if v >= current {
+			var steps []migrationFile
+			for _, f := range pending {
+				if f.version <= v {
+					steps = append(steps, f)
+				}
+			}
+			return steps, true, nil
+		}
+		var steps []migrationFile
+		for _, f := range applied {
+			if f.version > v {
+				steps = append(steps, f)
+			}
+		}
+		return steps, false, nil
+	}
+
+	return nil, false, fmt.Errorf("dry-run is not supported for %s", command)
+}
+
+func printPlan(m *migrate.Migrate, source fs.FS, command string, args []string) error {
+	files, err := readMigrations(source)
+	if err != nil {
+		return err
+	}
+	current, dirty, err := currentVersion(m)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("database is dirty at version %d, run force first", current)
+	}
+
+	steps, up, err := plan(files, current, command, args)
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		fmt.Println("-- no change")
+		return nil
+	}
+
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+
+	for _, f := range steps {
+		name := f.down
+		if up {
+			name = f.up
+		}
+		if name == "" {
+			return fmt.Errorf("migration %d has no %s file", f.version, direction)
+		}
+
+		sql, err := fs.ReadFile(source, name)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		fmt.Printf("-- %s\n%s\n", name, sql)
+	}
+
+	return nil
+}
+
+func createMigration(dir string, args []string) error {
+	if len(args) != 1 || !migrationNameRe.MatchString(args[0]) {
+		return errors.New("usage: create NAME (lowercase letters, digits and underscores)")
+	}
+	if dir == "" {
+		dir = "./migrations"
+	}
+
+	// Миграции нумеруются подряд: 000001, 000002, … Метка времени оказалась
+	// бы больше любого следующего номера, и migrations.Latest(), с которым
+	// /readyz сверяет схему, застрял бы на ней.
+	latest, err := migrations.LatestIn(os.DirFS(dir))
+	if err != nil {
+		return fmt.Errorf("failed to read migrations: %w", err)
+	}
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%06d_%s.%s.sql", latest+1, args[0], direction))
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		fmt.Println("Created", path)
+	}
+
+	return nil
+}
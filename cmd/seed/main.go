@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/app"
+	"github.com/I-Van-Radkov/subscription-service/internal/config"
+	"github.com/I-Van-Radkov/subscription-service/internal/seed"
+	"github.com/I-Van-Radkov/subscription-service/pkg/logger"
+)
+
+func main() {
+	var (
+		configPath string
+		opts       seed.Options
+		until      string
+		batchSize  int
+	)
+
+	flag.StringVar(&configPath, "config", "", "path to config file (yaml, toml, json or .env)")
+	flag.IntVar(&opts.Users, "users", 100, "number of users to generate")
+	flag.Uint64Var(&opts.Seed, "seed", 1, "random seed; the same seed and flags produce the same data")
+	flag.IntVar(&opts.Years, "years", 3, "spread start dates over this many years before -until")
+	flag.Float64Var(&opts.CancelRate, "cancel-rate", 0.35, "share of cancelled subscriptions, 0..1")
+	flag.StringVar(&until, "until", "", "last start month, MM-YYYY (default: current month; set it for reproducible runs)")
+	flag.IntVar(&batchSize, "batch", 5000, "subscriptions per CreateBatch call")
+	flag.Parse()
+
+	if err := validate(opts, batchSize); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	opts.Until = time.Now().UTC()
+	if until != "" {
+		t, err := time.Parse("01-2006", until)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid -until (expected MM-YYYY): %v\n", err)
+			os.Exit(2)
+		}
+		opts.Until = t
+	}
+
+	cfg, err := config.Load(config.ResolvePath(configPath))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	lg, err := logger.NewLogger(cfg.Env, cfg.LoggerConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer lg.Sync()
+
+	storage, err := app.OpenStorage(cfg, lg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer storage.Close()
+
+	subs := seed.Generate(opts)
+
+	ctx := context.Background()
+	start := time.Now()
+	inserted := 0
+	for batch := range slices.Chunk(subs, batchSize) {
+		if err := storage.Repo.CreateBatch(ctx, batch); err != nil {
+			fmt.Fprintf(os.Stderr, "failed after %d subscriptions: %v\n", inserted, err)
+			storage.Close()
+			os.Exit(1)
+		}
+		inserted += len(batch)
+	}
+
+	fmt.Printf("Inserted %d subscriptions for %d users in %s (seed %d)\n",
+		inserted, opts.Users, time.Since(start).Round(time.Millisecond), opts.Seed)
+}
+
+func validate(opts seed.Options, batchSize int) error {
+	switch {
+	case opts.Users <= 0:
+		return fmt.Errorf("-users: must be positive, got %d", opts.Users)
+	case opts.Years <= 0:
+		return fmt.Errorf("-years: must be positive, got %d", opts.Years)
+	case opts.CancelRate < 0 || opts.CancelRate > 1:
+		return fmt.Errorf("-cancel-rate: must be between 0 and 1, got %v", opts.CancelRate)
+	case batchSize <= 0:
+		return fmt.Errorf("-batch: must be positive, got %d", batchSize)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+)
+
+type cli struct {
+	usecase  *usecase.SubscriptionUsecase
+	services *usecase.ServiceUsecase
+	printer  *printer
+	format   string
+}
+
+func (c *cli) run(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "list":
+		return c.list(ctx, args)
+	case "show":
+		return c.show(ctx, args)
+	case "create":
+		return c.create(ctx, args)
+	case "update":
+		return c.update(ctx, args)
+	case "delete":
+		return c.delete(ctx, args)
+	case "summary":
+		return c.summary(ctx, args)
+	case "export":
+		return c.export(ctx, args)
+	case "import":
+		return c.importFile(ctx, args)
+	case "services":
+		return c.servicesCommand(ctx, args)
+	}
+
+	return fmt.Errorf("unknown command: %s\nAvailable commands: list, show, create, update, delete, summary, export, import, services", command)
+}
+
+func idArg(fs *flag.FlagSet, args []string) (string, error) {
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+	if fs.NArg() != 1 {
+		return "", fmt.Errorf("usage: subctl %s ID", fs.Name())
+	}
+	return fs.Arg(0), nil
+}
+
+func (c *cli) list(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	userID := fs.String("user", "", "user ID (required)")
+	category := fs.String("category", "", "only subscriptions in this category")
+	tag := fs.String("tag", "", "only subscriptions with this tag")
+	status := fs.String("status", "", "only subscriptions with this status: scheduled, trial, active, ending_soon, paused or ended")
+	asOf := fs.String("as-of", "", "month the status is computed for, MM-YYYY; defaults to the current month")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	out, err := c.usecase.GetSubscriptionsList(ctx, dto.GetSubsListFilter{
+		UserID:   *userID,
+		Category: *category,
+		Tag:      *tag,
+		Status:   *status,
+		AsOf:     *asOf,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.printer.subscriptions(out.List)
+}
+
+func (c *cli) show(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	asOf := fs.String("as-of", "", "month the status is computed for, MM-YYYY; defaults to the current month")
+	id, err := idArg(fs, args)
+	if err != nil {
+		return err
+	}
+
+	out, err := c.usecase.GetSubscription(ctx, id, *asOf)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.subscription(out)
+}
+
+func (c *cli) create(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("create", flag.ContinueOnError)
+	var input dto.CreateSubstractionRequest
+	fs.StringVar(&input.UserID, "user", "", "user ID (required)")
+	fs.StringVar(&input.ServiceName, "service", "", "service name (required)")
+	fs.IntVar(&input.Price, "price", 0, "monthly price in rubles; defaults to the catalog plan price")
+	fs.StringVar(&input.StartDate, "start", "", "start month, MM-YYYY (required)")
+	end := fs.String("end", "", "end month, MM-YYYY")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *end != "" {
+		input.EndDate = end
+	}
+
+	out, err := c.usecase.CreateSubscription(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.ids([]string{out.ID})
+}
+
+func (c *cli) update(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("update", flag.ContinueOnError)
+	var input dto.UpdateSubscriptionRequest
+	fs.StringVar(&input.ServiceName, "service", "", "new service name")
+	fs.IntVar(&input.Price, "price", 0, "new monthly price")
+	fs.StringVar(&input.StartDate, "start", "", "new start month, MM-YYYY")
+	end := fs.String("end", "", `new end month, MM-YYYY; "" removes the end date`)
+
+	// Флаги идут после ID: subctl update ID -price 500.
+	if len(args) == 0 {
+		return fmt.Errorf("usage: subctl update ID [flags]")
+	}
+	id := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "end" {
+			input.EndDate = end
+		}
+	})
+
+	out, err := c.usecase.UpdateSubscription(ctx, id, input)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.subscription(dto.GetSubscriptionResponse(out))
+}
+
+func (c *cli) delete(ctx context.Context, args []string) error {
+	id, err := idArg(flag.NewFlagSet("delete", flag.ContinueOnError), args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.usecase.DeleteSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	return c.printer.ids([]string{id})
+}
+
+func (c *cli) summary(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("summary", flag.ContinueOnError)
+	userID := fs.String("user", "", "user ID; empty means all users")
+	service := fs.String("service", "", "service name")
+	start := fs.String("start", "", "first month, MM-YYYY (required)")
+	end := fs.String("end", "", "last month, MM-YYYY; empty means open-ended")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var endPtr *string
+	if *end != "" {
+		endPtr = end
+	}
+
+	out, err := c.usecase.GetSubscriptionsSum(ctx, *userID, *service, *start, endPtr, "")
+	if err != nil {
+		return err
+	}
+
+	return c.printer.total(out.Total)
+}
+
+func (c *cli) export(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	userID := fs.String("user", "", "user ID (required)")
+	file := fs.String("file", "", "output file; stdout if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	format := c.format
+	if format == formatTable {
+		format = formatJSON
+	}
+	if *file != "" {
+		format = formatFromPath(*file, format)
+	}
+
+	out, err := c.usecase.GetSubscriptionsList(ctx, dto.GetSubsListFilter{UserID: *userID})
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", *file, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	p, err := newPrinter(w, format)
+	if err != nil {
+		return err
+	}
+	if err := p.subscriptions(out.List); err != nil {
+		return err
+	}
+
+	if *file != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d subscriptions to %s\n", len(out.List), *file)
+	}
+	return nil
+}
+
+func (c *cli) importFile(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := fs.String("file", "", "input file, .json or .csv (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("usage: subctl import -file PATH")
+	}
+
+	inputs, err := readImportFile(*file)
+	if err != nil {
+		return err
+	}
+
+	// Каждая строка создаётся отдельно: ошибки в одних строках не мешают остальным.
+	var (
+		ids    []string
+		failed int
+	)
+	for i, input := range inputs {
+		out, err := c.usecase.CreateSubscription(ctx, input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "record %d: %v\n", i+1, err)
+			failed++
+			continue
+		}
+		ids = append(ids, out.ID)
+	}
+
+	if err := c.printer.ids(ids); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("imported %d of %d records, %d failed", len(ids), len(inputs), failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+var subscriptionColumns = []string{"id", "user_id", "service_name", "price", "start_date", "end_date", "status"}
+
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return &printer{w: w, format: format}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q: use table, json or csv", format)
+}
+
+func formatFromPath(path, fallback string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return formatJSON
+	case ".csv":
+		return formatCSV
+	}
+	return fallback
+}
+
+func subscriptionRow(s dto.GetSubscriptionResponse) []string {
+	end := ""
+	if s.EndDate != nil {
+		end = *s.EndDate
+	}
+	return []string{s.ID, s.UserID, s.ServiceName, strconv.Itoa(s.Price), s.StartDate, end, s.Status}
+}
+
+func (p *printer) subscriptions(subs []dto.GetSubscriptionResponse) error {
+	rows := make([][]string, 0, len(subs))
+	for _, s := range subs {
+		rows = append(rows, subscriptionRow(s))
+	}
+	return p.write(subscriptionColumns, rows, subs)
+}
+
+func (p *printer) subscription(sub dto.GetSubscriptionResponse) error {
+	return p.write(subscriptionColumns, [][]string{subscriptionRow(sub)}, sub)
+}
+
+var serviceColumns = []string{"id", "name", "aliases", "category", "default_price"}
+
+func (p *printer) services(services []dto.GetServiceResponse) error {
+	rows := make([][]string, 0, len(services))
+	for _, s := range services {
+		price := ""
+		for i, plan := range s.Plans {
+			if plan.Default || (i == 0 && price == "") {
+				price = strconv.Itoa(plan.Price)
+			}
+		}
+		rows = append(rows, []string{s.ID, s.Name, strings.Join(s.Aliases, ", "), s.Category, price})
+	}
+	return p.write(serviceColumns, rows, services)
+}
+
+func (p *printer) backfill(links []dto.BackfillServiceLink) error {
+	rows := make([][]string, 0, len(links))
+	for _, l := range links {
+		rows = append(rows, []string{l.Name, l.Service, strconv.FormatBool(l.Created), strconv.Itoa(l.Count)})
+	}
+	return p.write([]string{"name", "service", "created", "count"}, rows, links)
+}
+
+func (p *printer) ids(ids []string) error {
+	rows := make([][]string, 0, len(ids))
+	values := make([]map[string]string, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, []string{id})
+		values = append(values, map[string]string{"id": id})
+	}
+	return p.write([]string{"id"}, rows, values)
+}
+
+func (p *printer) total(total int) error {
+	return p.write([]string{"total"}, [][]string{{strconv.Itoa(total)}}, dto.GetSubSumResponse{Total: total})
+}
+
+func (p *printer) write(header []string, rows [][]string, value any) error {
+	switch p.format {
+	case formatJSON:
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(value)
+
+	case formatCSV:
+		w := csv.NewWriter(p.w)
+		if err := w.Write(header); err != nil {
+			return err
+		}
+		if err := w.WriteAll(rows); err != nil {
+			return err
+		}
+		return w.Error()
+
+	default:
+		w := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.ToUpper(strings.Join(header, "\t")))
+		for _, row := range rows {
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+		return w.Flush()
+	}
+}
+
+// readImportFile читает подписки в формате export: JSON-массив или CSV с
+// заголовком. Колонка id игнорируется, новые подписки получают новые ID.
+func readImportFile(path string) ([]dto.CreateSubstractionRequest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	switch formatFromPath(path, "") {
+	case formatJSON:
+		var inputs []dto.CreateSubstractionRequest
+		if err := json.NewDecoder(f).Decode(&inputs); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		return inputs, nil
+
+	case formatCSV:
+		return readImportCSV(f)
+	}
+
+	return nil, fmt.Errorf("unsupported file type %s: use .json or .csv", path)
+}
+
+func readImportCSV(r io.Reader) ([]dto.CreateSubstractionRequest, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse csv: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"user_id", "service_name", "start_date"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv header: missing column %q", required)
+		}
+	}
+
+	get := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	inputs := make([]dto.CreateSubstractionRequest, 0, len(records)-1)
+	for n, record := range records[1:] {
+		// Пустая цена допустима: для сервиса из каталога её подставит usecase.
+		var price int
+		if raw := get(record, "price"); raw != "" {
+			if price, err = strconv.Atoi(raw); err != nil {
+				return nil, fmt.Errorf("csv line %d: invalid price: %w", n+2, err)
+			}
+		}
+
+		input := dto.CreateSubstractionRequest{
+			UserID:      get(record, "user_id"),
+			ServiceName: get(record, "service_name"),
+			Price:       price,
+			StartDate:   get(record, "start_date"),
+		}
+		if end := get(record, "end_date"); end != "" {
+			input.EndDate = &end
+		}
+		inputs = append(inputs, input)
+	}
+
+	return inputs, nil
+}
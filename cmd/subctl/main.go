@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/app"
+	"github.com/I-Van-Radkov/subscription-service/internal/config"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/I-Van-Radkov/subscription-service/pkg/logger"
+)
+
+const usage = `Usage: subctl [flags] <command> [command flags]
+
+Commands:
+  list     -user ID [-category C] [-tag T] [-status S] [-as-of MM-YYYY]
+                                           list subscriptions of a user
+  show     [-as-of MM-YYYY] ID             show one subscription
+  create   -user ID -service NAME [-price N] -start MM-YYYY [-end MM-YYYY]
+  update   ID [-service NAME] [-price N] [-start MM-YYYY] [-end MM-YYYY|""]
+  delete   ID
+  summary  -start MM-YYYY [-end MM-YYYY] [-user ID] [-service NAME]
+  export   -user ID [-file PATH]           write subscriptions as JSON or CSV (-o)
+  import   -file PATH                      create subscriptions from a .json or .csv file
+  services list                            list the service catalog
+  services backfill [-dry-run]             link existing subscriptions to catalog services
+
+Run "subctl <command> -h" for command flags.
+
+Flags:
+`
+
+func main() {
+	var (
+		configPath string
+		output     string
+	)
+
+	flag.StringVar(&configPath, "config", "", "path to config file (yaml, toml, json or .env)")
+	flag.StringVar(&output, "o", formatTable, "output format: table, json or csv")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	printer, err := newPrinter(os.Stdout, output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load(config.ResolvePath(configPath))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Сообщения сервиса не должны смешиваться с выводом команды.
+	logCfg := cfg.LoggerConfig
+	logCfg.Level = "error"
+	logCfg.Encoding = logger.EncodingConsole
+	lg, err := logger.NewLogger(cfg.Env, logCfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer lg.Sync()
+
+	storage, err := app.OpenStorage(cfg, lg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	cli := &cli{
+		usecase:  usecase.NewSubscriptionUsecase(storage.Repo, storage.Services, storage.Plans, storage.Transactor),
+		services: usecase.NewServiceUsecase(storage.Services, storage.Plans, storage.Repo, storage.Transactor),
+		printer:  printer,
+		format:   output,
+	}
+
+	err = cli.run(context.Background(), flag.Arg(0), flag.Args()[1:])
+	storage.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
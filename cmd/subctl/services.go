@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func (c *cli) servicesCommand(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: subctl services list|backfill")
+	}
+
+	switch args[0] {
+	case "list":
+		return c.listServices(ctx, args[1:])
+	case "backfill":
+		return c.backfillServices(ctx, args[1:])
+	}
+
+	return fmt.Errorf("unknown services command: %s\nAvailable commands: list, backfill", args[0])
+}
+
+func (c *cli) listServices(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("services list", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	out, err := c.services.GetServicesList(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.printer.services(out.List)
+}
+
+// backfillServices — разовая миграция подписок, созданных до появления
+// каталога: см. ServiceUsecase.BackfillServices.
+func (c *cli) backfillServices(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("services backfill", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "show what would be linked without writing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	out, err := c.services.BackfillServices(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if err := c.printer.backfill(out.Links); err != nil {
+		return err
+	}
+
+	if *dryRun {
+		fmt.Fprintf(os.Stderr, "Dry run: %d subscriptions would be linked\n", out.Total)
+	} else {
+		fmt.Fprintf(os.Stderr, "Linked %d subscriptions\n", out.Total)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+var budgetColumns = []string{"id", "name", "amount", "scope", "scope_value", "period", "thresholds", "created_at", "updated_at"}
+
+// BudgetRepo хранит бюджеты и отправленные по ним предупреждения.
+type BudgetRepo struct {
+	db      *postgres.Database
+	builder squirrel.StatementBuilderType
+}
+
+func NewBudgetRepo(db *postgres.Database) *BudgetRepo {
+	return &BudgetRepo{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *BudgetRepo) Create(ctx context.Context, b *models.Budget) error {
+	query, args, err := r.builder.
+		Insert("budgets").
+		Columns(budgetColumns...).
+		Values(b.ID, b.Name, b.Amount, b.Scope, b.ScopeValue, b.Period, b.Thresholds, b.CreatedAt, b.UpdatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err := r.db.Writer(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert budget: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BudgetRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Budget, error) {
+	budgets, err := r.list(ctx, squirrel.Eq{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	if len(budgets) == 0 {
+		return nil, nil
+	}
+	return budgets[0], nil
+}
+
+func (r *BudgetRepo) List(ctx context.Context) ([]*models.Budget, error) {
+	return r.list(ctx, nil)
+}
+
+// list возвращает бюджеты в порядке создания; nil в where означает все.
+func (r *BudgetRepo) list(ctx context.Context, where squirrel.Sqlizer) ([]*models.Budget, error) {
+	qb := r.builder.
+		Select(budgetColumns...).
+		From("budgets").
+		OrderBy("created_at", "id")
+	if where != nil {
+		qb = qb.Where(where)
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build budgets query: %w", err)
+	}
+
+	rows, err := r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+	defer rows.Close()
+
+	budgets := make([]*models.Budget, 0)
+	for rows.Next() {
+		var b models.Budget
+		if err := rows.Scan(&b.ID, &b.Name, &b.Amount, &b.Scope, &b.ScopeValue, &b.Period, &b.Thresholds, &b.CreatedAt, &b.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		budgets = append(budgets, &b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	return budgets, nil
+}
+
+// Update меняет название, сумму и пороги бюджета; область и период не меняются.
+func (r *BudgetRepo) Update(ctx context.Context, b *models.Budget) error {
+	query, args, err := r.builder.
+		Update("budgets").
+		Set("name", b.Name).
+		Set("amount", b.Amount).
+		Set("thresholds", b.Thresholds).
+		Set("updated_at", b.UpdatedAt).
+		Where(squirrel.Eq{"id": b.ID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("budget not found")
+	}
+
+	return nil
+}
+
+func (r *BudgetRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("budgets").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("budget not found")
+	}
+
+	return nil
+}
+
+// ClaimAlert отмечает предупреждение о пороге как отправленное и возвращает
+// false, если его уже отметил кто-то раньше.
+func (r *BudgetRepo) ClaimAlert(ctx context.Context, budgetID uuid.UUID, periodStart time.Time, threshold int) (bool, error) {
+	query, args, err := r.builder.
+		Insert("budget_alerts").
+		Columns("budget_id", "period_start", "threshold", "created_at").
+		Values(budgetID, periodStart, threshold, time.Now()).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build claim alert query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim budget alert: %w", err)
+	}
+
+	return cmd.RowsAffected() > 0, nil
+}
+
+// ReleaseAlert снимает отметку, чтобы предупреждение отправилось повторно.
+func (r *BudgetRepo) ReleaseAlert(ctx context.Context, budgetID uuid.UUID, periodStart time.Time, threshold int) error {
+	query, args, err := r.builder.
+		Delete("budget_alerts").
+		Where(squirrel.Eq{"budget_id": budgetID, "period_start": periodStart, "threshold": threshold}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build release alert query: %w", err)
+	}
+
+	if _, err := r.db.Writer(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to release budget alert: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BudgetRepo) AlertedThresholds(ctx context.Context, budgetID uuid.UUID, periodStart time.Time) ([]int, error) {
+	query, args, err := r.builder.
+		Select("threshold").
+		From("budget_alerts").
+		Where(squirrel.Eq{"budget_id": budgetID, "period_start": periodStart}).
+		OrderBy("threshold").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build alerts query: %w", err)
+	}
+
+	rows, err := r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget alerts: %w", err)
+	}
+	defer rows.Close()
+
+	thresholds := make([]int, 0)
+	for rows.Next() {
+		var t int
+		if err := rows.Scan(&t); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		thresholds = append(thresholds, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get budget alerts: %w", err)
+	}
+
+	return thresholds, nil
+}
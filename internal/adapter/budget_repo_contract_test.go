@@ -0,0 +1,146 @@
+package adapter_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// runBudgetRepoContract проверяет бюджеты и отметки об отправленных
+// предупреждениях. newRepo должен возвращать репозиторий поверх пустого
+// хранилища.
+func runBudgetRepoContract(t *testing.T, newRepo func(t *testing.T) usecase.BudgetRepo) {
+	t.Run("CRUD", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+
+		team := mustCreateBudget(t, repo, newBudget("Team", models.BudgetScopeTag, "team", 0))
+		org := mustCreateBudget(t, repo, newBudget("Company", models.BudgetScopeOrganization, "", time.Hour))
+
+		got, err := repo.GetById(ctx, team.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		assertBudget(t, got, team)
+
+		team.Name = "Team tools"
+		team.Amount = 2500
+		team.Thresholds = []int{90, 100}
+		team.UpdatedAt = team.UpdatedAt.Add(time.Hour)
+		if err := repo.Update(ctx, team); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		list, err := repo.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(list) != 2 {
+			t.Fatalf("List returned %d budgets, want 2", len(list))
+		}
+		assertBudget(t, list[0], team)
+		assertBudget(t, list[1], org)
+
+		if err := repo.Update(ctx, newBudget("Missing", models.BudgetScopeOrganization, "", 0)); err == nil {
+			t.Fatal("Update: expected error for missing budget")
+		}
+
+		if err := repo.Delete(ctx, team.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if got, err := repo.GetById(ctx, team.ID); err != nil || got != nil {
+			t.Fatalf("GetById after Delete = %+v, %v; want nil", got, err)
+		}
+		if err := repo.Delete(ctx, team.ID); err == nil {
+			t.Fatal("Delete: expected error for missing budget")
+		}
+	})
+
+	t.Run("Alerts", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+
+		b := mustCreateBudget(t, repo, newBudget("Company", models.BudgetScopeOrganization, "", 0))
+		january, february := month(2025, 1), month(2025, 2)
+
+		for _, threshold := range []int{80, 50} {
+			claimed, err := repo.ClaimAlert(ctx, b.ID, january, threshold)
+			if err != nil || !claimed {
+				t.Fatalf("ClaimAlert(%d) = %v, %v; want true", threshold, claimed, err)
+			}
+		}
+		if claimed, err := repo.ClaimAlert(ctx, b.ID, january, 50); err != nil || claimed {
+			t.Fatalf("ClaimAlert again = %v, %v; want false", claimed, err)
+		}
+		if claimed, err := repo.ClaimAlert(ctx, b.ID, february, 50); err != nil || !claimed {
+			t.Fatalf("ClaimAlert in next period = %v, %v; want true", claimed, err)
+		}
+
+		assertAlerted(t, repo, b.ID, january, []int{50, 80})
+
+		if err := repo.ReleaseAlert(ctx, b.ID, january, 80); err != nil {
+			t.Fatalf("ReleaseAlert: %v", err)
+		}
+		assertAlerted(t, repo, b.ID, january, []int{50})
+		if claimed, err := repo.ClaimAlert(ctx, b.ID, january, 80); err != nil || !claimed {
+			t.Fatalf("ClaimAlert after release = %v, %v; want true", claimed, err)
+		}
+
+		if err := repo.Delete(ctx, b.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		assertAlerted(t, repo, b.ID, january, nil)
+	})
+}
+
+func newBudget(name, scope, scopeValue string, createdOffset time.Duration) *models.Budget {
+	now := time.Date(2025, 1, 15, 10, 30, 0, 123456000, time.UTC).Add(createdOffset)
+	return &models.Budget{
+		ID:         uuid.New(),
+		Name:       name,
+		Amount:     1000,
+		Scope:      scope,
+		ScopeValue: scopeValue,
+		Period:     models.BudgetMonthly,
+		Thresholds: models.DefaultBudgetThresholds,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+func mustCreateBudget(t *testing.T, repo usecase.BudgetRepo, b *models.Budget) *models.Budget {
+	t.Helper()
+	if err := repo.Create(context.Background(), b); err != nil {
+		t.Fatalf("Create budget: %v", err)
+	}
+	return b
+}
+
+func assertBudget(t *testing.T, got, want *models.Budget) {
+	t.Helper()
+	if got == nil {
+		t.Fatalf("budget %s not found", want.ID)
+	}
+	if got.ID != want.ID || got.Name != want.Name || got.Amount != want.Amount ||
+		got.Scope != want.Scope || got.ScopeValue != want.ScopeValue || got.Period != want.Period ||
+		!slices.Equal(got.Thresholds, want.Thresholds) ||
+		!got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func assertAlerted(t *testing.T, repo usecase.BudgetRepo, budgetID uuid.UUID, periodStart time.Time, want []int) {
+	t.Helper()
+	got, err := repo.AlertedThresholds(context.Background(), budgetID, periodStart)
+	if err != nil {
+		t.Fatalf("AlertedThresholds: %v", err)
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("AlertedThresholds = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,235 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var costCenterColumns = []string{"id", "name", "created_at", "updated_at"}
+
+// CostCenterRepo хранит центры затрат и распределение подписок по ним.
+type CostCenterRepo struct {
+	db      *postgres.Database
+	builder squirrel.StatementBuilderType
+}
+
+func NewCostCenterRepo(db *postgres.Database) *CostCenterRepo {
+	return &CostCenterRepo{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *CostCenterRepo) Create(ctx context.Context, cc *models.CostCenter) error {
+	query, args, err := r.builder.
+		Insert("cost_centers").
+		Columns(costCenterColumns...).
+		Values(cc.ID, cc.Name, cc.CreatedAt, cc.UpdatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	_, err = r.db.Writer(ctx).Exec(ctx, query, args...)
+	if isPgUniqueViolation(err) {
+		return models.ErrCostCenterNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert cost center: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CostCenterRepo) GetById(ctx context.Context, id uuid.UUID) (*models.CostCenter, error) {
+	centers, err := r.list(ctx, squirrel.Eq{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	if len(centers) == 0 {
+		return nil, nil
+	}
+	return centers[0], nil
+}
+
+func (r *CostCenterRepo) List(ctx context.Context) ([]*models.CostCenter, error) {
+	return r.list(ctx, nil)
+}
+
+// list возвращает центры затрат по названию; nil в where означает все.
+func (r *CostCenterRepo) list(ctx context.Context, where squirrel.Sqlizer) ([]*models.CostCenter, error) {
+	qb := r.builder.
+		Select(costCenterColumns...).
+		From("cost_centers").
+		OrderBy("name")
+	if where != nil {
+		qb = qb.Where(where)
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cost centers query: %w", err)
+	}
+
+	rows, err := r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost centers: %w", err)
+	}
+	defer rows.Close()
+
+	centers := make([]*models.CostCenter, 0)
+	for rows.Next() {
+		var cc models.CostCenter
+		if err := rows.Scan(&cc.ID, &cc.Name, &cc.CreatedAt, &cc.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		centers = append(centers, &cc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get cost centers: %w", err)
+	}
+
+	return centers, nil
+}
+
+func (r *CostCenterRepo) Update(ctx context.Context, cc *models.CostCenter) error {
+	query, args, err := r.builder.
+		Update("cost_centers").
+		Set("name", cc.Name).
+		Set("updated_at", cc.UpdatedAt).
+		Where(squirrel.Eq{"id": cc.ID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if isPgUniqueViolation(err) {
+		return models.ErrCostCenterNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update cost center: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("cost center not found")
+	}
+
+	return nil
+}
+
+// Delete удаляет центр затрат; если на него распределены подписки,
+// возвращает models.ErrCostCenterInUse.
+func (r *CostCenterRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("cost_centers").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		return models.ErrCostCenterInUse
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete cost center: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("cost center not found")
+	}
+
+	return nil
+}
+
+// SetAllocations заменяет распределение подписки; пустой список его снимает.
+func (r *CostCenterRepo) SetAllocations(ctx context.Context, subscriptionID uuid.UUID, allocations []models.Allocation) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Delete("subscription_allocations").
+			Where(squirrel.Eq{"subscription_id": subscriptionID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build delete allocations query: %w", err)
+		}
+		if _, err := r.db.Writer(ctx).Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete allocations: %w", err)
+		}
+
+		if len(allocations) == 0 {
+			return nil
+		}
+
+		qb := r.builder.
+			Insert("subscription_allocations").
+			Columns("subscription_id", "cost_center_id", "percent")
+		for _, a := range allocations {
+			qb = qb.Values(subscriptionID, a.CostCenterID, a.Percent)
+		}
+		query, args, err = qb.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert allocations query: %w", err)
+		}
+
+		_, err = r.db.Writer(ctx).Exec(ctx, query, args...)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return fmt.Errorf("subscription or cost center not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert allocations: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ListAllocations возвращает распределение подписок subscriptionIDs; доли
+// каждой подписки идут от большей к меньшей. Подписок без распределения в
+// результате нет.
+func (r *CostCenterRepo) ListAllocations(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID][]models.Allocation, error) {
+	allocations := map[uuid.UUID][]models.Allocation{}
+	if len(subscriptionIDs) == 0 {
+		return allocations, nil
+	}
+
+	query, args, err := r.builder.
+		Select("subscription_id", "cost_center_id", "percent").
+		From("subscription_allocations").
+		Where(squirrel.Eq{"subscription_id": subscriptionIDs}).
+		OrderBy("subscription_id", "percent DESC", "cost_center_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build allocations query: %w", err)
+	}
+
+	rows, err := r.db.Reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			subscriptionID uuid.UUID
+			a              models.Allocation
+		)
+		if err := rows.Scan(&subscriptionID, &a.CostCenterID, &a.Percent); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		allocations[subscriptionID] = append(allocations[subscriptionID], a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get allocations: %w", err)
+	}
+
+	return allocations, nil
+}
@@ -0,0 +1,183 @@
+package adapter_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// runCostCenterRepoContract проверяет центры затрат и распределение по ним
+// подписок. newRepos должен возвращать репозитории поверх одного пустого
+// хранилища.
+func runCostCenterRepoContract(t *testing.T, newRepos func(t *testing.T) (usecase.CostCenterRepo, usecase.SubscriptionRepo)) {
+	t.Run("CRUD", func(t *testing.T) {
+		centers, _ := newRepos(t)
+		ctx := context.Background()
+
+		marketing := mustCreateCostCenter(t, centers, "Marketing")
+		engineering := mustCreateCostCenter(t, centers, "Engineering")
+
+		got, err := centers.GetById(ctx, marketing.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if got == nil || got.Name != "Marketing" || !got.CreatedAt.Equal(marketing.CreatedAt) {
+			t.Fatalf("got %+v, want %+v", got, marketing)
+		}
+
+		if err := centers.Create(ctx, newCostCenter("Marketing")); !errors.Is(err, models.ErrCostCenterNameTaken) {
+			t.Fatalf("Create with taken name: got %v, want %v", err, models.ErrCostCenterNameTaken)
+		}
+
+		marketing.Name = "Growth"
+		marketing.UpdatedAt = marketing.UpdatedAt.Add(time.Hour)
+		if err := centers.Update(ctx, marketing); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		engineering.Name = "Growth"
+		if err := centers.Update(ctx, engineering); !errors.Is(err, models.ErrCostCenterNameTaken) {
+			t.Fatalf("Update with taken name: got %v, want %v", err, models.ErrCostCenterNameTaken)
+		}
+
+		list, err := centers.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		var names []string
+		for _, cc := range list {
+			names = append(names, cc.Name)
+		}
+		if want := []string{"Engineering", "Growth"}; !slices.Equal(names, want) {
+			t.Fatalf("List names = %v, want %v", names, want)
+		}
+
+		if err := centers.Delete(ctx, marketing.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if got, err := centers.GetById(ctx, marketing.ID); err != nil || got != nil {
+			t.Fatalf("GetById after Delete = %+v, %v; want nil", got, err)
+		}
+		if err := centers.Delete(ctx, marketing.ID); err == nil {
+			t.Fatal("Delete: expected error for missing cost center")
+		}
+	})
+
+	t.Run("Allocations", func(t *testing.T) {
+		centers, subs := newRepos(t)
+		ctx := context.Background()
+
+		engineering := mustCreateCostCenter(t, centers, "Engineering")
+		marketing := mustCreateCostCenter(t, centers, "Marketing")
+		netflix := mustCreate(t, subs, newSubscription(uuid.New(), "Netflix", 100, month(2025, 1), nil))
+		spotify := mustCreate(t, subs, newSubscription(uuid.New(), "Spotify", 200, month(2025, 1), nil))
+
+		want := []models.Allocation{
+			{CostCenterID: engineering.ID, Percent: 60},
+			{CostCenterID: marketing.ID, Percent: 40},
+		}
+		if err := centers.SetAllocations(ctx, netflix.ID, []models.Allocation{want[1], want[0]}); err != nil {
+			t.Fatalf("SetAllocations: %v", err)
+		}
+		if err := centers.SetAllocations(ctx, spotify.ID, []models.Allocation{{CostCenterID: marketing.ID, Percent: 100}}); err != nil {
+			t.Fatalf("SetAllocations: %v", err)
+		}
+
+		got, err := centers.ListAllocations(ctx, []uuid.UUID{netflix.ID, uuid.New()})
+		if err != nil {
+			t.Fatalf("ListAllocations: %v", err)
+		}
+		if len(got) != 1 || !slices.Equal(got[netflix.ID], want) {
+			t.Fatalf("ListAllocations = %+v, want %+v for %s only", got, want, netflix.ID)
+		}
+
+		if err := centers.SetAllocations(ctx, netflix.ID, []models.Allocation{{CostCenterID: uuid.New(), Percent: 100}}); err == nil {
+			t.Fatal("SetAllocations: expected error for missing cost center")
+		}
+
+		if err := centers.Delete(ctx, marketing.ID); !errors.Is(err, models.ErrCostCenterInUse) {
+			t.Fatalf("Delete used cost center: got %v, want %v", err, models.ErrCostCenterInUse)
+		}
+
+		if err := centers.SetAllocations(ctx, netflix.ID, nil); err != nil {
+			t.Fatalf("SetAllocations with no allocations: %v", err)
+		}
+		if err := subs.Delete(ctx, spotify.ID); err != nil {
+			t.Fatalf("Delete subscription: %v", err)
+		}
+		got, err = centers.ListAllocations(ctx, []uuid.UUID{netflix.ID, spotify.ID})
+		if err != nil {
+			t.Fatalf("ListAllocations: %v", err)
+		}
+		if len(got) != 0 {
+			t.Fatalf("ListAllocations after clearing = %+v, want none", got)
+		}
+		if err := centers.Delete(ctx, marketing.ID); err != nil {
+			t.Fatalf("Delete unused cost center: %v", err)
+		}
+	})
+	t.Run("YearlyPlanReport", func(t *testing.T) {
+		centers, subs := newRepos(t)
+		ctx := context.Background()
+
+		engineering := mustCreateCostCenter(t, centers, "Engineering")
+		marketing := mustCreateCostCenter(t, centers, "Marketing")
+		yearly := newSubscription(uuid.New(), "Netflix", 6000, month(2025, 1), nil)
+		yearly.BillingPeriod = models.BillingYearly
+		mustCreate(t, subs, yearly)
+		mustCreate(t, subs, newSubscription(uuid.New(), "Spotify", 100, month(2025, 1), nil))
+		if err := centers.SetAllocations(ctx, yearly.ID, []models.Allocation{
+			{CostCenterID: engineering.ID, Percent: 60},
+			{CostCenterID: marketing.ID, Percent: 40},
+		}); err != nil {
+			t.Fatalf("SetAllocations: %v", err)
+		}
+
+		report, err := usecase.NewCostCenterUsecase(centers, subs, nil).GetCostCenterReport(ctx, "", "01-2025", "12-2025")
+		if err != nil {
+			t.Fatalf("GetCostCenterReport: %v", err)
+		}
+		// Годовая цена распределяется по месяцам, а не входит в каждый.
+		if report.Total != 6000+12*100 {
+			t.Fatalf("report total = %d, want %d", report.Total, 6000+12*100)
+		}
+		for _, m := range report.Months {
+			if m.Total != 500+100 {
+				t.Fatalf("month %s total = %d, want %d", m.Month, m.Total, 500+100)
+			}
+		}
+		want := map[string]int{"Engineering": 3600, "Marketing": 2400, "": 1200}
+		if len(report.CostCenters) != len(want) {
+			t.Fatalf("cost centers = %+v, want %v", report.CostCenters, want)
+		}
+		for _, cc := range report.CostCenters {
+			if cc.Amount != want[cc.CostCenterName] {
+				t.Fatalf("cost centers = %+v, want %v", report.CostCenters, want)
+			}
+		}
+	})
+}
+
+func newCostCenter(name string) *models.CostCenter {
+	now := time.Date(2025, 1, 15, 10, 30, 0, 123456000, time.UTC)
+	return &models.CostCenter{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func mustCreateCostCenter(t *testing.T, repo usecase.CostCenterRepo, name string) *models.CostCenter {
+	t.Helper()
+	cc := newCostCenter(name)
+	if err := repo.Create(context.Background(), cc); err != nil {
+		t.Fatalf("Create cost center: %v", err)
+	}
+	return cc
+}
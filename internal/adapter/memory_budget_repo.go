@@ -0,0 +1,157 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryBudgetAlert — аналог первичного ключа budget_alerts.
+type memoryBudgetAlert struct {
+	budgetID    uuid.UUID
+	periodStart time.Time
+	threshold   int
+}
+
+type MemoryBudgetRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryBudgetRepo(store *MemoryStore) *MemoryBudgetRepo {
+	return &MemoryBudgetRepo{
+		store: store,
+	}
+}
+
+func copyMemoryBudget(b models.Budget) *models.Budget {
+	b.Thresholds = slices.Clone(b.Thresholds)
+	return &b
+}
+
+func (r *MemoryBudgetRepo) Create(ctx context.Context, b *models.Budget) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.budgets[b.ID]; ok {
+		return fmt.Errorf("failed to insert budget: duplicate id %s", b.ID)
+	}
+
+	stored := *copyMemoryBudget(*b)
+	stored.CreatedAt = memoryTimestamp(stored.CreatedAt)
+	stored.UpdatedAt = memoryTimestamp(stored.UpdatedAt)
+	r.store.budgets[b.ID] = stored
+
+	return nil
+}
+
+func (r *MemoryBudgetRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Budget, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	b, ok := r.store.budgets[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return copyMemoryBudget(b), nil
+}
+
+func (r *MemoryBudgetRepo) List(ctx context.Context) ([]*models.Budget, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	budgets := make([]*models.Budget, 0, len(r.store.budgets))
+	for _, b := range r.store.budgets {
+		budgets = append(budgets, copyMemoryBudget(b))
+	}
+	sort.Slice(budgets, func(i, j int) bool {
+		if !budgets[i].CreatedAt.Equal(budgets[j].CreatedAt) {
+			return budgets[i].CreatedAt.Before(budgets[j].CreatedAt)
+		}
+		return budgets[i].ID.String() < budgets[j].ID.String()
+	})
+
+	return budgets, nil
+}
+
+func (r *MemoryBudgetRepo) Update(ctx context.Context, b *models.Budget) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.budgets[b.ID]
+	if !ok {
+		return fmt.Errorf("budget not found")
+	}
+
+	existing.Name = b.Name
+	existing.Amount = b.Amount
+	existing.Thresholds = slices.Clone(b.Thresholds)
+	existing.UpdatedAt = memoryTimestamp(b.UpdatedAt)
+	r.store.budgets[b.ID] = existing
+
+	return nil
+}
+
+func (r *MemoryBudgetRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.budgets[id]; !ok {
+		return fmt.Errorf("budget not found")
+	}
+	delete(r.store.budgets, id)
+	for key := range r.store.budgetAlerts {
+		if key.budgetID == id {
+			delete(r.store.budgetAlerts, key)
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryBudgetRepo) ClaimAlert(ctx context.Context, budgetID uuid.UUID, periodStart time.Time, threshold int) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.budgets[budgetID]; !ok {
+		return false, fmt.Errorf("failed to claim budget alert: budget %s not found", budgetID)
+	}
+
+	key := memoryBudgetAlert{budgetID: budgetID, periodStart: memoryDate(periodStart), threshold: threshold}
+	if r.store.budgetAlerts[key] {
+		return false, nil
+	}
+	r.store.budgetAlerts[key] = true
+
+	return true, nil
+}
+
+func (r *MemoryBudgetRepo) ReleaseAlert(ctx context.Context, budgetID uuid.UUID, periodStart time.Time, threshold int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.budgetAlerts, memoryBudgetAlert{budgetID: budgetID, periodStart: memoryDate(periodStart), threshold: threshold})
+
+	return nil
+}
+
+func (r *MemoryBudgetRepo) AlertedThresholds(ctx context.Context, budgetID uuid.UUID, periodStart time.Time) ([]int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	periodStart = memoryDate(periodStart)
+	thresholds := make([]int, 0)
+	for key := range r.store.budgetAlerts {
+		if key.budgetID == budgetID && key.periodStart.Equal(periodStart) {
+			thresholds = append(thresholds, key.threshold)
+		}
+	}
+	slices.Sort(thresholds)
+
+	return thresholds, nil
+}
@@ -0,0 +1,157 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+type MemoryCostCenterRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryCostCenterRepo(store *MemoryStore) *MemoryCostCenterRepo {
+	return &MemoryCostCenterRepo{
+		store: store,
+	}
+}
+
+// memoryCostCenterNameTaken проверяет уникальность названия. Вызывать под store.mu.
+func memoryCostCenterNameTaken(store *MemoryStore, cc *models.CostCenter) bool {
+	for _, existing := range store.costCenters {
+		if existing.ID != cc.ID && existing.Name == cc.Name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryCostCenterRepo) Create(ctx context.Context, cc *models.CostCenter) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.costCenters[cc.ID]; ok {
+		return fmt.Errorf("failed to insert cost center: duplicate id %s", cc.ID)
+	}
+	if memoryCostCenterNameTaken(r.store, cc) {
+		return models.ErrCostCenterNameTaken
+	}
+
+	stored := *cc
+	stored.CreatedAt = memoryTimestamp(stored.CreatedAt)
+	stored.UpdatedAt = memoryTimestamp(stored.UpdatedAt)
+	r.store.costCenters[cc.ID] = stored
+
+	return nil
+}
+
+func (r *MemoryCostCenterRepo) GetById(ctx context.Context, id uuid.UUID) (*models.CostCenter, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	cc, ok := r.store.costCenters[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &cc, nil
+}
+
+func (r *MemoryCostCenterRepo) List(ctx context.Context) ([]*models.CostCenter, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	centers := make([]*models.CostCenter, 0, len(r.store.costCenters))
+	for _, cc := range r.store.costCenters {
+		centers = append(centers, &cc)
+	}
+	sort.Slice(centers, func(i, j int) bool {
+		return centers[i].Name < centers[j].Name
+	})
+
+	return centers, nil
+}
+
+func (r *MemoryCostCenterRepo) Update(ctx context.Context, cc *models.CostCenter) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.costCenters[cc.ID]
+	if !ok {
+		return fmt.Errorf("cost center not found")
+	}
+	if memoryCostCenterNameTaken(r.store, cc) {
+		return models.ErrCostCenterNameTaken
+	}
+
+	existing.Name = cc.Name
+	existing.UpdatedAt = memoryTimestamp(cc.UpdatedAt)
+	r.store.costCenters[cc.ID] = existing
+
+	return nil
+}
+
+func (r *MemoryCostCenterRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.costCenters[id]; !ok {
+		return fmt.Errorf("cost center not found")
+	}
+	for _, allocations := range r.store.allocations {
+		if slices.ContainsFunc(allocations, func(a models.Allocation) bool { return a.CostCenterID == id }) {
+			return models.ErrCostCenterInUse
+		}
+	}
+	delete(r.store.costCenters, id)
+
+	return nil
+}
+
+func (r *MemoryCostCenterRepo) SetAllocations(ctx context.Context, subscriptionID uuid.UUID, allocations []models.Allocation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if len(allocations) == 0 {
+		delete(r.store.allocations, subscriptionID)
+		return nil
+	}
+
+	if _, ok := r.store.subscriptions[subscriptionID]; !ok {
+		return fmt.Errorf("subscription or cost center not found")
+	}
+	for _, a := range allocations {
+		if _, ok := r.store.costCenters[a.CostCenterID]; !ok {
+			return fmt.Errorf("subscription or cost center not found")
+		}
+	}
+
+	stored := slices.Clone(allocations)
+	sort.Slice(stored, func(i, j int) bool {
+		if stored[i].Percent != stored[j].Percent {
+			return stored[i].Percent > stored[j].Percent
+		}
+		return stored[i].CostCenterID.String() < stored[j].CostCenterID.String()
+	})
+	r.store.allocations[subscriptionID] = stored
+
+	return nil
+}
+
+func (r *MemoryCostCenterRepo) ListAllocations(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID][]models.Allocation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	allocations := map[uuid.UUID][]models.Allocation{}
+	for _, id := range subscriptionIDs {
+		if stored, ok := r.store.allocations[id]; ok {
+			allocations[id] = slices.Clone(stored)
+		}
+	}
+
+	return allocations, nil
+}
@@ -0,0 +1,157 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+type MemoryPlanRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryPlanRepo(store *MemoryStore) *MemoryPlanRepo {
+	return &MemoryPlanRepo{
+		store: store,
+	}
+}
+
+func copyMemoryPlan(plan models.ServicePlan) models.ServicePlan {
+	plan.PriceChanges = slices.Clone(plan.PriceChanges)
+	return plan
+}
+
+// memoryPlansOf возвращает тарифы сервиса в порядке создания, как и list в
+// SQL-реализациях. Вызывать под store.mu.
+func memoryPlansOf(store *MemoryStore, serviceID uuid.UUID) []models.ServicePlan {
+	plans := make([]models.ServicePlan, 0)
+	for _, p := range store.plans {
+		if p.ServiceID == serviceID {
+			plans = append(plans, copyMemoryPlan(p))
+		}
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		if !plans[i].CreatedAt.Equal(plans[j].CreatedAt) {
+			return plans[i].CreatedAt.Before(plans[j].CreatedAt)
+		}
+		return plans[i].Name < plans[j].Name
+	})
+	return plans
+}
+
+// insertMemoryPlan проверяет уникальность названия в пределах сервиса и
+// сохраняет тариф. Вызывать под store.mu.
+func insertMemoryPlan(store *MemoryStore, plan *models.ServicePlan) error {
+	if _, ok := store.plans[plan.ID]; ok {
+		return fmt.Errorf("failed to insert plan: duplicate id %s", plan.ID)
+	}
+	if _, ok := store.services[plan.ServiceID]; !ok {
+		return fmt.Errorf("failed to insert plan: service %s not found", plan.ServiceID)
+	}
+	for _, p := range store.plans {
+		if p.ServiceID == plan.ServiceID && p.Name == plan.Name {
+			return models.ErrPlanNameTaken
+		}
+	}
+
+	stored := copyMemoryPlan(*plan)
+	stored.BillingPeriod = billingPeriodOrDefault(stored.BillingPeriod)
+	stored.CreatedAt = memoryTimestamp(stored.CreatedAt)
+	stored.UpdatedAt = memoryTimestamp(stored.UpdatedAt)
+	store.plans[plan.ID] = stored
+
+	return nil
+}
+
+// unlinkMemoryPlan повторяет ON DELETE SET NULL для subscriptions.plan_id.
+// Вызывать под store.mu.
+func unlinkMemoryPlan(store *MemoryStore, planID uuid.UUID) {
+	delete(store.plans, planID)
+	for id, sub := range store.subscriptions {
+		if sub.PlanID != nil && *sub.PlanID == planID {
+			sub.PlanID = nil
+			store.subscriptions[id] = sub
+		}
+	}
+}
+
+func (r *MemoryPlanRepo) Create(ctx context.Context, plan *models.ServicePlan) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return insertMemoryPlan(r.store, plan)
+}
+
+func (r *MemoryPlanRepo) GetById(ctx context.Context, id uuid.UUID) (*models.ServicePlan, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	plan, ok := r.store.plans[id]
+	if !ok {
+		return nil, nil
+	}
+	plan = copyMemoryPlan(plan)
+
+	return &plan, nil
+}
+
+func (r *MemoryPlanRepo) Update(ctx context.Context, plan *models.ServicePlan) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.plans[plan.ID]
+	if !ok {
+		return fmt.Errorf("plan not found")
+	}
+	for _, p := range r.store.plans {
+		if p.ID != plan.ID && p.ServiceID == existing.ServiceID && p.Name == plan.Name {
+			return models.ErrPlanNameTaken
+		}
+	}
+
+	existing.Name = plan.Name
+	existing.Default = plan.Default
+	existing.UpdatedAt = memoryTimestamp(plan.UpdatedAt)
+	r.store.plans[plan.ID] = existing
+
+	return nil
+}
+
+func (r *MemoryPlanRepo) SetPriceChange(ctx context.Context, planID uuid.UUID, change models.PlanPriceChange) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	plan, ok := r.store.plans[planID]
+	if !ok {
+		return fmt.Errorf("plan not found")
+	}
+
+	change.EffectiveFrom = memoryDate(change.EffectiveFrom)
+	changes := slices.DeleteFunc(slices.Clone(plan.PriceChanges), func(c models.PlanPriceChange) bool {
+		return c.EffectiveFrom.Equal(change.EffectiveFrom)
+	})
+	changes = append(changes, change)
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].EffectiveFrom.Before(changes[j].EffectiveFrom)
+	})
+	plan.PriceChanges = changes
+	r.store.plans[planID] = plan
+
+	return nil
+}
+
+func (r *MemoryPlanRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.plans[id]; !ok {
+		return fmt.Errorf("plan not found")
+	}
+	unlinkMemoryPlan(r.store, id)
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// MemoryServiceRepo — каталог сервисов в памяти с той же семантикой, что и
+// ServiceRepo поверх Postgres.
+type MemoryServiceRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryServiceRepo(store *MemoryStore) *MemoryServiceRepo {
+	return &MemoryServiceRepo{
+		store: store,
+	}
+}
+
+// copyMemoryService возвращает копию сервиса вместе с его тарифами.
+// Вызывать под store.mu.
+func (r *MemoryServiceRepo) copyMemoryService(svc models.Service) *models.Service {
+	svc.Aliases = slices.Clone(svc.Aliases)
+	svc.Plans = memoryPlansOf(r.store, svc.ID)
+	return &svc
+}
+
+// claimAliases проверяет, что ни название, ни синонимы svc не заняты другим
+// сервисом, и возвращает ключи для записи в store.aliases.
+func (r *MemoryServiceRepo) claimAliases(svc *models.Service) ([]string, error) {
+	names := serviceNames(svc)
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		key := models.NormalizeServiceName(name)
+		if owner, ok := r.store.aliases[key]; ok && owner != svc.ID {
+			return nil, models.ErrServiceNameTaken
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (r *MemoryServiceRepo) dropAliases(id uuid.UUID) {
+	for key, owner := range r.store.aliases {
+		if owner == id {
+			delete(r.store.aliases, key)
+		}
+	}
+}
+
+func (r *MemoryServiceRepo) put(svc *models.Service, keys []string) {
+	stored := *svc
+	stored.Plans = nil
+	stored.Aliases = aliasesOf(svc, serviceNames(svc))
+	slices.Sort(stored.Aliases)
+	stored.CreatedAt = memoryTimestamp(stored.CreatedAt)
+	stored.UpdatedAt = memoryTimestamp(stored.UpdatedAt)
+	r.store.services[svc.ID] = stored
+
+	for _, key := range keys {
+		r.store.aliases[key] = svc.ID
+	}
+}
+
+func (r *MemoryServiceRepo) Create(ctx context.Context, svc *models.Service) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.services[svc.ID]; ok {
+		return fmt.Errorf("failed to insert service: duplicate id %s", svc.ID)
+	}
+	keys, err := r.claimAliases(svc)
+	if err != nil {
+		return err
+	}
+	// Тарифы проверяются до записи, чтобы при ошибке не сохранить ничего.
+	names := map[string]bool{}
+	for _, p := range svc.Plans {
+		if names[p.Name] {
+			return models.ErrPlanNameTaken
+		}
+		names[p.Name] = true
+	}
+
+	r.put(svc, keys)
+	for i := range svc.Plans {
+		svc.Plans[i].ServiceID = svc.ID
+		if err := insertMemoryPlan(r.store, &svc.Plans[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryServiceRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Service, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	svc, ok := r.store.services[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return r.copyMemoryService(svc), nil
+}
+
+func (r *MemoryServiceRepo) FindByName(ctx context.Context, name string) (*models.Service, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	id, ok := r.store.aliases[models.NormalizeServiceName(name)]
+	if !ok {
+		return nil, nil
+	}
+
+	return r.copyMemoryService(r.store.services[id]), nil
+}
+
+func (r *MemoryServiceRepo) List(ctx context.Context) ([]*models.Service, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	services := make([]*models.Service, 0, len(r.store.services))
+	for _, svc := range r.store.services {
+		services = append(services, r.copyMemoryService(svc))
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+
+	return services, nil
+}
+
+func (r *MemoryServiceRepo) Update(ctx context.Context, svc *models.Service) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.services[svc.ID]
+	if !ok {
+		return fmt.Errorf("service not found")
+	}
+	keys, err := r.claimAliases(svc)
+	if err != nil {
+		return err
+	}
+
+	updated := *svc
+	updated.CreatedAt = existing.CreatedAt
+
+	r.dropAliases(svc.ID)
+	r.put(&updated, keys)
+
+	return nil
+}
+
+// Delete удаляет сервис с его тарифами и, как ON DELETE SET NULL, отвязывает
+// от них подписки.
+func (r *MemoryServiceRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.services[id]; !ok {
+		return fmt.Errorf("service not found")
+	}
+	delete(r.store.services, id)
+	r.dropAliases(id)
+	for planID, p := range r.store.plans {
+		if p.ServiceID == id {
+			unlinkMemoryPlan(r.store, planID)
+		}
+	}
+
+	for subID, sub := range r.store.subscriptions {
+		if sub.ServiceID != nil && *sub.ServiceID == id {
+			sub.ServiceID = nil
+			r.store.subscriptions[subID] = sub
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package adapter
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// MemoryStore хранит данные в памяти процесса. Используется в режиме
+// STORAGE=memory и в тестах; после перезапуска данные теряются.
+type MemoryStore struct {
+	mu sync.RWMutex
+	memoryTables
+
+	txMu sync.Mutex
+}
+
+// memoryTables — таблицы хранилища. Репозитории заменяют значения в картах
+// целиком и не меняют их на месте, поэтому для отката транзакции достаточно
+// копий карт.
+type memoryTables struct {
+	subscriptions map[uuid.UUID]models.Subscription
+	services      map[uuid.UUID]models.Service
+	// aliases — аналог service_aliases: ключ нормализации → сервис.
+	aliases map[string]uuid.UUID
+	plans   map[uuid.UUID]models.ServicePlan
+	// members — участники разделённых подписок в порядке user_id.
+	members map[uuid.UUID][]models.SubscriptionMember
+	// pauses — паузы подписок в порядке start_date.
+	pauses map[uuid.UUID][]models.SubscriptionPause
+	// cancellations — отмены подписок, ключ — подписка.
+	cancellations map[uuid.UUID]models.Cancellation
+
+	costCenters map[uuid.UUID]models.CostCenter
+	// allocations — распределение по центрам затрат, ключ — подписка.
+	allocations map[uuid.UUID][]models.Allocation
+
+	budgets      map[uuid.UUID]models.Budget
+	budgetAlerts map[memoryBudgetAlert]bool
+
+	trialReminders map[memoryTrialReminder]bool
+}
+
+func (t memoryTables) clone() memoryTables {
+	return memoryTables{
+		subscriptions: maps.Clone(t.subscriptions),
+		services:      maps.Clone(t.services),
+		aliases:       maps.Clone(t.aliases),
+		plans:         maps.Clone(t.plans),
+		members:       maps.Clone(t.members),
+		pauses:        maps.Clone(t.pauses),
+		cancellations: maps.Clone(t.cancellations),
+		costCenters:   maps.Clone(t.costCenters),
+		allocations:   maps.Clone(t.allocations),
+		budgets:       maps.Clone(t.budgets),
+		budgetAlerts:  maps.Clone(t.budgetAlerts),
+
+		trialReminders: maps.Clone(t.trialReminders),
+	}
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{memoryTables: memoryTables{
+		subscriptions: make(map[uuid.UUID]models.Subscription),
+		services:      make(map[uuid.UUID]models.Service),
+		aliases:       make(map[string]uuid.UUID),
+		plans:         make(map[uuid.UUID]models.ServicePlan),
+		members:       make(map[uuid.UUID][]models.SubscriptionMember),
+		pauses:        make(map[uuid.UUID][]models.SubscriptionPause),
+		cancellations: make(map[uuid.UUID]models.Cancellation),
+		costCenters:   make(map[uuid.UUID]models.CostCenter),
+		allocations:   make(map[uuid.UUID][]models.Allocation),
+		budgets:       make(map[uuid.UUID]models.Budget),
+		budgetAlerts:  make(map[memoryBudgetAlert]bool),
+
+		trialReminders: make(map[memoryTrialReminder]bool),
+	}}
+}
+
+type memoryTxKey struct{}
+
+// WithinTx сериализует транзакции: пока выполняется fn, другие транзакции ждут.
+// Если fn вернула ошибку или запаниковала, хранилище возвращается к состоянию
+// на начало транзакции; записи вне транзакций, сделанные за это время, тоже
+// теряются.
+func (s *MemoryStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(memoryTxKey{}) != nil {
+		return fn(ctx)
+	}
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.RLock()
+	snapshot := s.memoryTables.clone()
+	s.mu.RUnlock()
+
+	committed := false
+	defer func() {
+		if !committed {
+			s.mu.Lock()
+			s.memoryTables = snapshot
+			s.mu.Unlock()
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, struct{}{})); err != nil {
+		return err
+	}
+	committed = true
+
+	return nil
+}
+
+// memoryDate повторяет поведение колонки DATE: остаётся только дата, время отбрасывается.
+func memoryDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// memoryTimestamp повторяет поведение колонки TIMESTAMP: локальное время без
+// часового пояса с точностью до микросекунд.
+func memoryTimestamp(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC).
+		Truncate(time.Microsecond)
+}
@@ -0,0 +1,99 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (r *MemorySubscriptionRepo) CreateCancellation(ctx context.Context, c models.Cancellation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.subscriptions[c.SubscriptionID]; !ok {
+		return fmt.Errorf("subscription not found")
+	}
+	if _, ok := r.store.cancellations[c.SubscriptionID]; ok {
+		return models.ErrAlreadyCancelled
+	}
+
+	c.EffectiveDate = memoryDate(c.EffectiveDate)
+	if c.PreviousEndDate != nil {
+		end := memoryDate(*c.PreviousEndDate)
+		c.PreviousEndDate = &end
+	}
+	c.CancelledAt = memoryTimestamp(c.CancelledAt)
+	r.store.cancellations[c.SubscriptionID] = c
+
+	return nil
+}
+
+func (r *MemorySubscriptionRepo) DeleteCancellation(ctx context.Context, subscriptionID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.cancellations[subscriptionID]; !ok {
+		return models.ErrNotCancelled
+	}
+	delete(r.store.cancellations, subscriptionID)
+
+	return nil
+}
+
+func (r *MemorySubscriptionRepo) ListCancellations(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID]models.Cancellation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	cancellations := map[uuid.UUID]models.Cancellation{}
+	for _, id := range subscriptionIDs {
+		c, ok := r.store.cancellations[id]
+		if !ok {
+			continue
+		}
+		if c.PreviousEndDate != nil {
+			end := *c.PreviousEndDate
+			c.PreviousEndDate = &end
+		}
+		cancellations[id] = c
+	}
+
+	return cancellations, nil
+}
+
+func (r *MemorySubscriptionRepo) ChurnReport(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]models.ChurnTotal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	type churnKey struct{ reason, serviceName string }
+	counts := map[churnKey]int{}
+	for id, c := range r.store.cancellations {
+		if c.EffectiveDate.Before(start) || c.EffectiveDate.After(end) {
+			continue
+		}
+		sub := r.store.subscriptions[id]
+		if userID != uuid.Nil && sub.UserID != userID {
+			continue
+		}
+		counts[churnKey{c.Reason, sub.ServiceName}]++
+	}
+
+	totals := make([]models.ChurnTotal, 0, len(counts))
+	for key, count := range counts {
+		totals = append(totals, models.ChurnTotal{Reason: key.reason, ServiceName: key.serviceName, Count: count})
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Count != totals[j].Count {
+			return totals[i].Count > totals[j].Count
+		}
+		if totals[i].Reason != totals[j].Reason {
+			return totals[i].Reason < totals[j].Reason
+		}
+		return totals[i].ServiceName < totals[j].ServiceName
+	})
+
+	return totals, nil
+}
@@ -0,0 +1,63 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (r *MemorySubscriptionRepo) SetPauses(ctx context.Context, subscriptionID uuid.UUID, pauses []models.SubscriptionPause) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if len(pauses) == 0 {
+		delete(r.store.pauses, subscriptionID)
+		return nil
+	}
+	if _, ok := r.store.subscriptions[subscriptionID]; !ok {
+		return fmt.Errorf("subscription not found")
+	}
+
+	stored := make([]models.SubscriptionPause, 0, len(pauses))
+	for _, p := range pauses {
+		p.StartDate = memoryDate(p.StartDate)
+		if p.EndDate != nil {
+			end := memoryDate(*p.EndDate)
+			p.EndDate = &end
+		}
+		stored = append(stored, p)
+	}
+	slices.SortFunc(stored, func(a, b models.SubscriptionPause) int {
+		return a.StartDate.Compare(b.StartDate)
+	})
+	r.store.pauses[subscriptionID] = stored
+
+	return nil
+}
+
+func (r *MemorySubscriptionRepo) ListPauses(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID][]models.SubscriptionPause, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	pauses := map[uuid.UUID][]models.SubscriptionPause{}
+	for _, id := range subscriptionIDs {
+		stored, ok := r.store.pauses[id]
+		if !ok {
+			continue
+		}
+		copied := make([]models.SubscriptionPause, 0, len(stored))
+		for _, p := range stored {
+			if p.EndDate != nil {
+				end := *p.EndDate
+				p.EndDate = &end
+			}
+			copied = append(copied, p)
+		}
+		pauses[id] = copied
+	}
+
+	return pauses, nil
+}
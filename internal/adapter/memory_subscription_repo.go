@@ -0,0 +1,498 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// MemorySubscriptionRepo — реализация usecase.SubscriptionRepo в памяти
+// с той же семантикой, что и SubscriptionRepo поверх Postgres.
+type MemorySubscriptionRepo struct {
+	store *MemoryStore
+}
+
+func NewMemorySubscriptionRepo(store *MemoryStore) *MemorySubscriptionRepo {
+	return &MemorySubscriptionRepo{
+		store: store,
+	}
+}
+
+func normalizeMemorySubscription(sub models.Subscription) models.Subscription {
+	sub.StartDate = memoryDate(sub.StartDate)
+	if sub.EndDate != nil {
+		end := memoryDate(*sub.EndDate)
+		sub.EndDate = &end
+	}
+	if sub.TrialEndDate != nil {
+		trial := memoryDate(*sub.TrialEndDate)
+		sub.TrialEndDate = &trial
+	}
+	sub.BillingPeriod = billingPeriodOrDefault(sub.BillingPeriod)
+	sub.CreatedAt = memoryTimestamp(sub.CreatedAt)
+	sub.UpdatedAt = memoryTimestamp(sub.UpdatedAt)
+	return sub
+}
+
+func validateMemorySubscription(sub *models.Subscription) error {
+	if sub.Price <= 0 {
+		return fmt.Errorf("price must be greater than 0")
+	}
+	if sub.EndDate != nil && memoryDate(*sub.EndDate).Before(memoryDate(sub.StartDate)) {
+		return fmt.Errorf("end_date is before start_date")
+	}
+	return nil
+}
+
+func copyMemorySubscription(sub models.Subscription) *models.Subscription {
+	if sub.EndDate != nil {
+		end := *sub.EndDate
+		sub.EndDate = &end
+	}
+	if sub.TrialEndDate != nil {
+		trial := *sub.TrialEndDate
+		sub.TrialEndDate = &trial
+	}
+	if sub.IntroPrice != nil {
+		price := *sub.IntroPrice
+		sub.IntroPrice = &price
+	}
+	if sub.ServiceID != nil {
+		id := *sub.ServiceID
+		sub.ServiceID = &id
+	}
+	if sub.PlanID != nil {
+		id := *sub.PlanID
+		sub.PlanID = &id
+	}
+	sub.Tags = slices.Clone(sub.Tags)
+	return &sub
+}
+
+func (r *MemorySubscriptionRepo) Create(ctx context.Context, sub *models.Subscription) (uuid.UUID, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.subscriptions[sub.ID]; ok {
+		return uuid.Nil, fmt.Errorf("failed to insert subscription: duplicate id %s", sub.ID)
+	}
+	if err := validateMemorySubscription(sub); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to insert subscription: %w", err)
+	}
+
+	r.store.subscriptions[sub.ID] = normalizeMemorySubscription(*copyMemorySubscription(*sub))
+
+	return sub.ID, nil
+}
+
+func (r *MemorySubscriptionRepo) CreateBatch(ctx context.Context, subs []*models.Subscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Сначала проверяем все подписки, чтобы при ошибке ничего не записать.
+	seen := make(map[uuid.UUID]struct{}, len(subs))
+	for _, sub := range subs {
+		if _, ok := r.store.subscriptions[sub.ID]; ok {
+			return fmt.Errorf("failed to insert subscriptions: duplicate id %s", sub.ID)
+		}
+		if _, ok := seen[sub.ID]; ok {
+			return fmt.Errorf("failed to insert subscriptions: duplicate id %s", sub.ID)
+		}
+		seen[sub.ID] = struct{}{}
+		if err := validateMemorySubscription(sub); err != nil {
+			return fmt.Errorf("failed to insert subscriptions: %w", err)
+		}
+	}
+
+	for _, sub := range subs {
+		r.store.subscriptions[sub.ID] = normalizeMemorySubscription(*copyMemorySubscription(*sub))
+	}
+
+	return nil
+}
+
+func (r *MemorySubscriptionRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	sub, ok := r.store.subscriptions[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return copyMemorySubscription(sub), nil
+}
+
+func (r *MemorySubscriptionRepo) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
+	return r.GetById(ctx, id)
+}
+
+func (r *MemorySubscriptionRepo) Update(ctx context.Context, sub *models.Subscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.subscriptions[sub.ID]
+	if !ok {
+		return fmt.Errorf("subscription not found")
+	}
+	if err := validateMemorySubscription(sub); err != nil {
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	existing.ServiceName = sub.ServiceName
+	existing.ServiceID = copyMemorySubscription(*sub).ServiceID
+	existing.PlanID = copyMemorySubscription(*sub).PlanID
+	existing.Price = sub.Price
+	existing.BillingPeriod = sub.BillingPeriod
+	existing.Category = sub.Category
+	existing.Tags = slices.Clone(sub.Tags)
+	existing.StartDate = sub.StartDate
+	existing.EndDate = copyMemorySubscription(*sub).EndDate
+	existing.TrialEndDate = copyMemorySubscription(*sub).TrialEndDate
+	existing.IntroPrice = copyMemorySubscription(*sub).IntroPrice
+	existing.IntroMonths = sub.IntroMonths
+	existing.UpdatedAt = sub.UpdatedAt
+
+	r.store.subscriptions[sub.ID] = normalizeMemorySubscription(existing)
+
+	return nil
+}
+
+func (r *MemorySubscriptionRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.subscriptions[id]; !ok {
+		return fmt.Errorf("subscription not found")
+	}
+	delete(r.store.subscriptions, id)
+	delete(r.store.members, id)
+	delete(r.store.pauses, id)
+	delete(r.store.cancellations, id)
+	for key := range r.store.trialReminders {
+		if key.subscriptionID == id {
+			delete(r.store.trialReminders, key)
+		}
+	}
+	delete(r.store.allocations, id)
+
+	return nil
+}
+
+func (r *MemorySubscriptionRepo) List(ctx context.Context, filter models.SubscriptionFilter) ([]*models.Subscription, error) {
+	if filter.Status != "" && !models.IsStatus(filter.Status) {
+		return nil, fmt.Errorf("unknown subscription status %q", filter.Status)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	subs := make([]*models.Subscription, 0)
+	for _, sub := range r.store.subscriptions {
+		if filter.UserID != uuid.Nil && sub.UserID != filter.UserID && !r.isMember(sub.ID, filter.UserID) {
+			continue
+		}
+		if filter.Category != "" && sub.Category != filter.Category {
+			continue
+		}
+		if filter.Tag != "" && !slices.Contains(sub.Tags, filter.Tag) {
+			continue
+		}
+		if filter.Status != "" && sub.Status(r.store.pauses[sub.ID], filter.AsOf) != filter.Status {
+			continue
+		}
+		subs = append(subs, copyMemorySubscription(sub))
+	}
+
+	sort.SliceStable(subs, func(i, j int) bool {
+		return subs[i].CreatedAt.After(subs[j].CreatedAt)
+	})
+
+	return subs, nil
+}
+
+func (r *MemorySubscriptionRepo) SumForPeriod(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	total := 0
+	r.forEachSum(userID, serviceName, start, end, func(sub models.Subscription, sum int) {
+		total += sum
+	})
+
+	return total, nil
+}
+
+func (r *MemorySubscriptionRepo) SumForPeriodByTag(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) ([]models.TagTotal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	byTag := map[string]int{}
+	r.forEachSum(userID, serviceName, start, end, func(sub models.Subscription, sum int) {
+		if len(sub.Tags) == 0 {
+			byTag[""] += sum
+		}
+		for _, tag := range sub.Tags {
+			byTag[tag] += sum
+		}
+	})
+
+	totals := make([]models.TagTotal, 0, len(byTag))
+	for tag, total := range byTag {
+		totals = append(totals, models.TagTotal{Tag: tag, Total: total})
+	}
+	sort.Slice(totals, func(i, j int) bool {
+		return totals[i].Tag < totals[j].Tag
+	})
+
+	return totals, nil
+}
+
+func (r *MemorySubscriptionRepo) SumForPeriodBySubscription(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) ([]models.SubscriptionTotal, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	totals := make([]models.SubscriptionTotal, 0)
+	r.forEachSum(userID, serviceName, start, end, func(sub models.Subscription, sum int) {
+		totals = append(totals, models.SubscriptionTotal{SubscriptionID: sub.ID, Total: sum})
+	})
+
+	return totals, nil
+}
+
+// forEachSum вызывает fn для каждой подписки, пересекающейся с периодом, с
+// её суммой за период. Вызывать под store.mu.
+func (r *MemorySubscriptionRepo) forEachSum(userID uuid.UUID, serviceName string, start time.Time, end *time.Time, fn func(sub models.Subscription, sum int)) {
+	start = memoryDate(start)
+	var endDate *time.Time
+	if end != nil {
+		e := memoryDate(*end)
+		endDate = &e
+	}
+
+	for _, sub := range r.store.subscriptions {
+		members := r.store.members[sub.ID]
+		if userID != uuid.Nil {
+			if len(members) == 0 && sub.UserID != userID {
+				continue
+			}
+			if len(members) > 0 && !r.isMember(sub.ID, userID) {
+				continue
+			}
+		}
+		if serviceName != "" && sub.ServiceName != serviceName {
+			continue
+		}
+		if sub.EndDate != nil && sub.EndDate.Before(start) {
+			continue
+		}
+		if endDate != nil && sub.StartDate.After(*endDate) {
+			continue
+		}
+		sum := 0
+		pauses := r.store.pauses[sub.ID]
+		// Пробный период бесплатен, вводная цена — отдельный отрезок.
+		paidStart, regularStart := sub.PaidStart(), sub.RegularStart()
+		if sub.IntroPrice != nil && memoryBeforeOrAt(paidStart, sub.EndDate) && memoryBeforeOrAt(paidStart, endDate) && regularStart.After(start) {
+			introEnd := regularStart.AddDate(0, -1, 0)
+			if sub.EndDate != nil && sub.EndDate.Before(introEnd) {
+				introEnd = *sub.EndDate
+			}
+			if !memoryPausedThrough(pauses, paidStart, &introEnd, start, endDate) {
+				sum += *sub.IntroPrice
+			}
+		}
+		if memoryBeforeOrAt(regularStart, sub.EndDate) && memoryBeforeOrAt(regularStart, endDate) {
+			plan, ok := models.ServicePlan{}, false
+			if sub.PlanID != nil {
+				plan, ok = r.store.plans[*sub.PlanID]
+			}
+			regular := sub
+			regular.StartDate = regularStart
+			if ok {
+				sum += memoryPlanSegmentsSum(&plan, regular, pauses, start, endDate)
+			} else if !memoryPausedThrough(pauses, regularStart, sub.EndDate, start, endDate) {
+				sum += models.MonthlyPrice(sub.Price, sub.BillingPeriod)
+			}
+		}
+		if sum == 0 {
+			continue
+		}
+		if userID != uuid.Nil && len(members) > 0 {
+			sum = models.MemberShare(sum, members, userID)
+		}
+		fn(sub, sum)
+	}
+}
+
+// memoryBeforeOrAt сообщает, что t не позже limit; nil означает открытую границу.
+func memoryBeforeOrAt(t time.Time, limit *time.Time) bool {
+	return limit == nil || !t.After(*limit)
+}
+
+// memoryPausedThrough сообщает, что все месяцы отрезка [from, to] внутри
+// периода [start, end] приостановлены; nil — открытая граница.
+func memoryPausedThrough(pauses []models.SubscriptionPause, from time.Time, to *time.Time, start time.Time, end *time.Time) bool {
+	if start.After(from) {
+		from = start
+	}
+	if to == nil || (end != nil && end.Before(*to)) {
+		to = end
+	}
+	return slices.ContainsFunc(pauses, func(p models.SubscriptionPause) bool {
+		return !p.StartDate.After(from) && (p.EndDate == nil || (to != nil && !p.EndDate.Before(*to)))
+	})
+}
+
+// isMember сообщает, разделена ли подписка с userID. Вызывать под store.mu.
+func (r *MemorySubscriptionRepo) isMember(subscriptionID, userID uuid.UUID) bool {
+	return slices.ContainsFunc(r.store.members[subscriptionID], func(m models.SubscriptionMember) bool {
+		return m.UserID == userID
+	})
+}
+
+// SetMembers заменяет участников подписки; пустой список снимает разделение.
+func (r *MemorySubscriptionRepo) SetMembers(ctx context.Context, subscriptionID uuid.UUID, members []models.SubscriptionMember) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if len(members) == 0 {
+		delete(r.store.members, subscriptionID)
+		return nil
+	}
+	if _, ok := r.store.subscriptions[subscriptionID]; !ok {
+		return fmt.Errorf("subscription not found")
+	}
+
+	stored := slices.Clone(members)
+	models.SortMembers(stored)
+	r.store.members[subscriptionID] = stored
+
+	return nil
+}
+
+func (r *MemorySubscriptionRepo) ListMembers(ctx context.Context, subscriptionID uuid.UUID) ([]models.SubscriptionMember, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	members := slices.Clone(r.store.members[subscriptionID])
+	if members == nil {
+		members = make([]models.SubscriptionMember, 0)
+	}
+	return members, nil
+}
+
+// memoryPlanSegmentsSum делит подписку на отрезки между изменениями цены
+// тарифа и суммирует цены отрезков, пересекающихся с периодом, как это
+// делает SumForPeriod в SQL-реализациях. Отрезки, приостановленные в
+// периоде целиком, не учитываются. Как и в SQL, цена отрезка берётся
+// месячная (models.MonthlyPrice).
+func memoryPlanSegmentsSum(plan *models.ServicePlan, sub models.Subscription, pauses []models.SubscriptionPause, start time.Time, end *time.Time) int {
+	type segment struct {
+		from  *time.Time
+		price int
+	}
+	segments := []segment{{price: plan.Price}}
+	for _, c := range plan.PriceChanges {
+		from := memoryDate(c.EffectiveFrom)
+		segments = append(segments, segment{from: &from, price: c.Price})
+	}
+
+	total := 0
+	for i, seg := range segments {
+		var next *time.Time
+		if i+1 < len(segments) {
+			next = segments[i+1].from
+		}
+		if next != nil && (!next.After(sub.StartDate) || !next.After(start)) {
+			continue
+		}
+		if seg.from != nil && sub.EndDate != nil && seg.from.After(*sub.EndDate) {
+			continue
+		}
+		if seg.from != nil && end != nil && seg.from.After(*end) {
+			continue
+		}
+		from, to := sub.StartDate, sub.EndDate
+		if seg.from != nil && seg.from.After(from) {
+			from = *seg.from
+		}
+		if next != nil {
+			last := next.AddDate(0, -1, 0)
+			if to == nil || last.Before(*to) {
+				to = &last
+			}
+		}
+		if memoryPausedThrough(pauses, from, to, start, end) {
+			continue
+		}
+		total += models.MonthlyPrice(seg.price, sub.BillingPeriod)
+	}
+	return total
+}
+
+func (r *MemorySubscriptionRepo) UnlinkedServiceNames(ctx context.Context) ([]models.ServiceNameCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := map[string]int{}
+	for _, sub := range r.store.subscriptions {
+		if sub.ServiceID == nil {
+			counts[sub.ServiceName]++
+		}
+	}
+
+	names := make([]models.ServiceNameCount, 0, len(counts))
+	for name, count := range counts {
+		names = append(names, models.ServiceNameCount{Name: name, Count: count})
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].Count != names[j].Count {
+			return names[i].Count > names[j].Count
+		}
+		return names[i].Name < names[j].Name
+	})
+
+	return names, nil
+}
+
+func (r *MemorySubscriptionRepo) LinkService(ctx context.Context, name string, svc *models.Service) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	linked := 0
+	for id, sub := range r.store.subscriptions {
+		if sub.ServiceID != nil || sub.ServiceName != name {
+			continue
+		}
+		serviceID := svc.ID
+		sub.ServiceID = &serviceID
+		sub.ServiceName = svc.Name
+		r.store.subscriptions[id] = sub
+		linked++
+	}
+
+	return linked, nil
+}
+
+func (r *MemorySubscriptionRepo) RenameService(ctx context.Context, svc *models.Service) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	renamed := 0
+	for id, sub := range r.store.subscriptions {
+		if sub.ServiceID == nil || *sub.ServiceID != svc.ID || sub.ServiceName == svc.Name {
+			continue
+		}
+		sub.ServiceName = svc.Name
+		r.store.subscriptions[id] = sub
+		renamed++
+	}
+
+	return renamed, nil
+}
@@ -0,0 +1,81 @@
+package adapter_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/adapter"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+func TestMemorySubscriptionRepo(t *testing.T) {
+	runSubscriptionRepoContract(t, func(t *testing.T) usecase.SubscriptionRepo {
+		return adapter.NewMemorySubscriptionRepo(adapter.NewMemoryStore())
+	})
+}
+
+func TestMemoryTransactor(t *testing.T) {
+	runTransactorContract(t, func(t *testing.T) (usecase.Transactor, usecase.SubscriptionRepo) {
+		store := adapter.NewMemoryStore()
+		return store, adapter.NewMemorySubscriptionRepo(store)
+	})
+}
+
+func TestMemoryStoreWithinTxSerializesUpdates(t *testing.T) {
+	store := adapter.NewMemoryStore()
+	repo := adapter.NewMemorySubscriptionRepo(store)
+	sub := mustCreate(t, repo, newSubscription(uuid.New(), "Spotify", 1, month(2025, 1), nil))
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := store.WithinTx(context.Background(), func(ctx context.Context) error {
+				got, err := repo.GetByIdForUpdate(ctx, sub.ID)
+				if err != nil {
+					return err
+				}
+				time.Sleep(time.Millisecond)
+				got.Price++
+				return repo.Update(ctx, got)
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := repo.GetById(context.Background(), sub.ID)
+	if err != nil {
+		t.Fatalf("GetById: %v", err)
+	}
+	if got.Price != workers+1 {
+		t.Fatalf("price = %d, want %d: concurrent transactions lost updates", got.Price, workers+1)
+	}
+}
+
+func TestMemoryServiceRepo(t *testing.T) {
+	runServiceRepoContract(t, func(t *testing.T) (usecase.ServiceRepo, usecase.PlanRepo, usecase.SubscriptionRepo) {
+		store := adapter.NewMemoryStore()
+		return adapter.NewMemoryServiceRepo(store), adapter.NewMemoryPlanRepo(store), adapter.NewMemorySubscriptionRepo(store)
+	})
+}
+
+func TestMemoryCostCenterRepo(t *testing.T) {
+	runCostCenterRepoContract(t, func(t *testing.T) (usecase.CostCenterRepo, usecase.SubscriptionRepo) {
+		store := adapter.NewMemoryStore()
+		return adapter.NewMemoryCostCenterRepo(store), adapter.NewMemorySubscriptionRepo(store)
+	})
+}
+
+func TestMemoryBudgetRepo(t *testing.T) {
+	runBudgetRepoContract(t, func(t *testing.T) usecase.BudgetRepo {
+		return adapter.NewMemoryBudgetRepo(adapter.NewMemoryStore())
+	})
+}
@@ -0,0 +1,100 @@
+package adapter
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+)
+
+func (r *MemorySubscriptionRepo) ListTags(ctx context.Context) ([]models.LabelCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := map[string]int{}
+	for _, sub := range r.store.subscriptions {
+		for _, tag := range sub.Tags {
+			counts[tag]++
+		}
+	}
+
+	return memoryLabelCounts(counts), nil
+}
+
+func (r *MemorySubscriptionRepo) RenameTag(ctx context.Context, from, to string) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	renamed := 0
+	for id, sub := range r.store.subscriptions {
+		if !slices.Contains(sub.Tags, from) {
+			continue
+		}
+		tags := slices.DeleteFunc(slices.Clone(sub.Tags), func(tag string) bool { return tag == from })
+		sub.Tags = models.NormalizeTags(append(tags, to))
+		r.store.subscriptions[id] = sub
+		renamed++
+	}
+
+	return renamed, nil
+}
+
+func (r *MemorySubscriptionRepo) DeleteTag(ctx context.Context, name string) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	deleted := 0
+	for id, sub := range r.store.subscriptions {
+		if !slices.Contains(sub.Tags, name) {
+			continue
+		}
+		sub.Tags = slices.DeleteFunc(slices.Clone(sub.Tags), func(tag string) bool { return tag == name })
+		r.store.subscriptions[id] = sub
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+func (r *MemorySubscriptionRepo) ListCategories(ctx context.Context) ([]models.LabelCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := map[string]int{}
+	for _, sub := range r.store.subscriptions {
+		if sub.Category != "" {
+			counts[sub.Category]++
+		}
+	}
+
+	return memoryLabelCounts(counts), nil
+}
+
+func (r *MemorySubscriptionRepo) RenameCategory(ctx context.Context, from, to string) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	renamed := 0
+	for id, sub := range r.store.subscriptions {
+		if sub.Category != from {
+			continue
+		}
+		sub.Category = to
+		r.store.subscriptions[id] = sub
+		renamed++
+	}
+
+	return renamed, nil
+}
+
+func memoryLabelCounts(counts map[string]int) []models.LabelCount {
+	labels := make([]models.LabelCount, 0, len(counts))
+	for name, count := range counts {
+		labels = append(labels, models.LabelCount{Name: name, Count: count})
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Name < labels[j].Name
+	})
+	return labels
+}
@@ -0,0 +1,70 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// memoryTrialReminder — аналог первичного ключа trial_reminders.
+type memoryTrialReminder struct {
+	subscriptionID uuid.UUID
+	paidStart      time.Time
+}
+
+func (r *MemorySubscriptionRepo) ListTrialsEnding(ctx context.Context, month time.Time) ([]*models.Subscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	subs := make([]*models.Subscription, 0)
+	for _, sub := range r.store.subscriptions {
+		if sub.TrialEndDate == nil || sub.TrialEndDate.Before(from) || !sub.TrialEndDate.Before(to) {
+			continue
+		}
+		if sub.EndDate != nil && !sub.EndDate.After(*sub.TrialEndDate) {
+			continue
+		}
+		subs = append(subs, copyMemorySubscription(sub))
+	}
+	sort.Slice(subs, func(i, j int) bool {
+		if !subs[i].TrialEndDate.Equal(*subs[j].TrialEndDate) {
+			return subs[i].TrialEndDate.Before(*subs[j].TrialEndDate)
+		}
+		return subs[i].ID.String() < subs[j].ID.String()
+	})
+
+	return subs, nil
+}
+
+func (r *MemorySubscriptionRepo) ClaimTrialReminder(ctx context.Context, subscriptionID uuid.UUID, paidStart time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.subscriptions[subscriptionID]; !ok {
+		return false, fmt.Errorf("failed to claim trial reminder: subscription %s not found", subscriptionID)
+	}
+
+	key := memoryTrialReminder{subscriptionID: subscriptionID, paidStart: memoryDate(paidStart)}
+	if r.store.trialReminders[key] {
+		return false, nil
+	}
+	r.store.trialReminders[key] = true
+
+	return true, nil
+}
+
+func (r *MemorySubscriptionRepo) ReleaseTrialReminder(ctx context.Context, subscriptionID uuid.UUID, paidStart time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.trialReminders, memoryTrialReminder{subscriptionID: subscriptionID, paidStart: memoryDate(paidStart)})
+
+	return nil
+}
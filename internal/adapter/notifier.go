@@ -0,0 +1,152 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// LogNotifier пишет предупреждения о бюджетах и напоминания о пробных
+// периодах в лог сервиса.
+type LogNotifier struct {
+	logger logger.Logger
+}
+
+func NewLogNotifier(lg logger.Logger) *LogNotifier {
+	return &LogNotifier{logger: lg}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, alert models.BudgetAlert) error {
+	n.logger.Info(ctx, "budget threshold reached",
+		zap.String("budget_id", alert.Budget.ID.String()),
+		zap.String("budget_name", alert.Budget.Name),
+		zap.String("scope", alert.Budget.Scope),
+		zap.String("scope_value", alert.Budget.ScopeValue),
+		zap.String("period_start", alert.PeriodStart.Format("01-2006")),
+		zap.Int("threshold", alert.Threshold),
+		zap.Int("percent", alert.Percent),
+		zap.Int("amount", alert.Budget.Amount),
+		zap.Int("actual", alert.Actual),
+		zap.Int("committed", alert.Committed),
+	)
+	return nil
+}
+
+func (n *LogNotifier) NotifyTrialEnding(ctx context.Context, reminder models.TrialReminder) error {
+	n.logger.Info(ctx, "trial ending",
+		zap.String("subscription_id", reminder.Subscription.ID.String()),
+		zap.String("service_name", reminder.Subscription.ServiceName),
+		zap.String("user_id", reminder.Subscription.UserID.String()),
+		zap.String("charge_date", reminder.ChargeDate.Format("01-2006")),
+		zap.Int("price", reminder.Price),
+	)
+	return nil
+}
+
+// budgetAlertPayload — тело запроса WebhookNotifier.
+type budgetAlertPayload struct {
+	BudgetID    string `json:"budget_id"`
+	BudgetName  string `json:"budget_name"`
+	Scope       string `json:"scope"`
+	ScopeValue  string `json:"scope_value,omitempty"`
+	Period      string `json:"period"`
+	PeriodStart string `json:"period_start"`
+	Threshold   int    `json:"threshold"`
+	Percent     int    `json:"percent"`
+	Amount      int    `json:"amount"`
+	Actual      int    `json:"actual"`
+	Committed   int    `json:"committed"`
+}
+
+// trialReminderPayload — тело напоминания о пробном периоде в WebhookNotifier.
+type trialReminderPayload struct {
+	SubscriptionID string `json:"subscription_id"`
+	ServiceName    string `json:"service_name"`
+	UserID         string `json:"user_id"`
+	TrialEndDate   string `json:"trial_end_date"`
+	ChargeDate     string `json:"charge_date"`
+	Price          int    `json:"price"`
+}
+
+// WebhookNotifier отправляет уведомления POST-запросом с JSON на
+// заданный адрес; любой ответ кроме 2xx считается ошибкой доставки.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, alert models.BudgetAlert) error {
+	body, err := json.Marshal(budgetAlertPayload{
+		BudgetID:    alert.Budget.ID.String(),
+		BudgetName:  alert.Budget.Name,
+		Scope:       alert.Budget.Scope,
+		ScopeValue:  alert.Budget.ScopeValue,
+		Period:      alert.Budget.Period,
+		PeriodStart: alert.PeriodStart.Format("01-2006"),
+		Threshold:   alert.Threshold,
+		Percent:     alert.Percent,
+		Amount:      alert.Budget.Amount,
+		Actual:      alert.Actual,
+		Committed:   alert.Committed,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode budget alert: %w", err)
+	}
+
+	return n.post(ctx, body)
+}
+
+func (n *WebhookNotifier) NotifyTrialEnding(ctx context.Context, reminder models.TrialReminder) error {
+	sub := reminder.Subscription
+	payload := trialReminderPayload{
+		SubscriptionID: sub.ID.String(),
+		ServiceName:    sub.ServiceName,
+		UserID:         sub.UserID.String(),
+		ChargeDate:     reminder.ChargeDate.Format("01-2006"),
+		Price:          reminder.Price,
+	}
+	if sub.TrialEndDate != nil {
+		payload.TrialEndDate = sub.TrialEndDate.Format("01-2006")
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode trial reminder: %w", err)
+	}
+
+	return n.post(ctx, body)
+}
+
+func (n *WebhookNotifier) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var planColumns = []string{"id", "service_id", "name", "price", "billing_period", "is_default", "created_at", "updated_at"}
+
+// PlanRepo хранит тарифы сервисов и историю изменения их цен.
+type PlanRepo struct {
+	db      *postgres.Database
+	builder squirrel.StatementBuilderType
+}
+
+func NewPlanRepo(db *postgres.Database) *PlanRepo {
+	return &PlanRepo{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *PlanRepo) Create(ctx context.Context, plan *models.ServicePlan) error {
+	query, args, err := r.builder.
+		Insert("plans").
+		Columns(planColumns...).
+		Values(plan.ID, plan.ServiceID, plan.Name, plan.Price, billingPeriodOrDefault(plan.BillingPeriod), plan.Default, plan.CreatedAt, plan.UpdatedAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	_, err = r.db.Writer(ctx).Exec(ctx, query, args...)
+	if isPgUniqueViolation(err) {
+		return models.ErrPlanNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert plan: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PlanRepo) GetById(ctx context.Context, id uuid.UUID) (*models.ServicePlan, error) {
+	plans, err := r.list(ctx, squirrel.Eq{"id": id})
+	if err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		return nil, nil
+	}
+	return &plans[0], nil
+}
+
+// list возвращает тарифы вместе с изменениями цен в порядке создания;
+// nil в where означает все тарифы.
+func (r *PlanRepo) list(ctx context.Context, where squirrel.Sqlizer) ([]models.ServicePlan, error) {
+	qb := r.builder.
+		Select(planColumns...).
+		From("plans").
+		OrderBy("created_at", "name")
+	changes := r.builder.
+		Select("plan_id", "effective_from", "price").
+		From("plan_price_changes").
+		OrderBy("effective_from")
+	if where != nil {
+		qb = qb.Where(where)
+		changes = changes.Where(squirrel.Expr("plan_id IN (?)", squirrel.Select("id").From("plans").Where(where)))
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build plans query: %w", err)
+	}
+
+	rows, err := r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := make([]models.ServicePlan, 0)
+	index := map[uuid.UUID]int{}
+	for rows.Next() {
+		var p models.ServicePlan
+		if err := rows.Scan(&p.ID, &p.ServiceID, &p.Name, &p.Price, &p.BillingPeriod, &p.Default, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		index[p.ID] = len(plans)
+		plans = append(plans, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get plans: %w", err)
+	}
+	if len(plans) == 0 {
+		return plans, nil
+	}
+
+	query, args, err = changes.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build price changes query: %w", err)
+	}
+
+	rows, err = r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan price changes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			planID uuid.UUID
+			change models.PlanPriceChange
+		)
+		if err := rows.Scan(&planID, &change.EffectiveFrom, &change.Price); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		if i, ok := index[planID]; ok {
+			plans[i].PriceChanges = append(plans[i].PriceChanges, change)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get plan price changes: %w", err)
+	}
+
+	return plans, nil
+}
+
+// Update меняет название и признак тарифа по умолчанию. Цена меняется только
+// через SetPriceChange, период оплаты — не меняется.
+func (r *PlanRepo) Update(ctx context.Context, plan *models.ServicePlan) error {
+	query, args, err := r.builder.
+		Update("plans").
+		Set("name", plan.Name).
+		Set("is_default", plan.Default).
+		Set("updated_at", plan.UpdatedAt).
+		Where(squirrel.Eq{"id": plan.ID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if isPgUniqueViolation(err) {
+		return models.ErrPlanNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update plan: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("plan not found")
+	}
+
+	return nil
+}
+
+// SetPriceChange добавляет изменение цены; изменение с тем же месяцем
+// перезаписывается.
+func (r *PlanRepo) SetPriceChange(ctx context.Context, planID uuid.UUID, change models.PlanPriceChange) error {
+	query, args, err := r.builder.
+		Insert("plan_price_changes").
+		Columns("plan_id", "effective_from", "price").
+		Values(planID, change.EffectiveFrom, change.Price).
+		Suffix("ON CONFLICT (plan_id, effective_from) DO UPDATE SET price = EXCLUDED.price").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build price change query: %w", err)
+	}
+
+	_, err = r.db.Writer(ctx).Exec(ctx, query, args...)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		return fmt.Errorf("plan not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set plan price: %w", err)
+	}
+
+	return nil
+}
+
+// Delete удаляет тариф; подписки на него сохраняют свою цену, но перестают
+// следовать изменениям цены тарифа.
+func (r *PlanRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("plans").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete plan: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("plan not found")
+	}
+
+	return nil
+}
+
+func isPgUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation
+}
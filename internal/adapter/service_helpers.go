@@ -0,0 +1,51 @@
+package adapter
+
+import (
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+)
+
+// serviceNames возвращает название и синонимы, по одному на каждый ключ
+// нормализации. Их строки попадают в service_aliases.
+func serviceNames(svc *models.Service) []string {
+	seen := map[string]bool{}
+	names := make([]string, 0, len(svc.Aliases)+1)
+	for _, name := range append([]string{svc.Name}, svc.Aliases...) {
+		key := models.NormalizeServiceName(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// aliasesOf убирает из строк service_aliases само название сервиса.
+func aliasesOf(svc *models.Service, stored []string) []string {
+	nameKey := models.NormalizeServiceName(svc.Name)
+	aliases := make([]string, 0, len(stored))
+	for _, alias := range stored {
+		if models.NormalizeServiceName(alias) != nameKey {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// plansOf выбирает из тарифов всех сервисов тарифы svc.
+func plansOf(svc *models.Service, plans []models.ServicePlan) []models.ServicePlan {
+	own := make([]models.ServicePlan, 0)
+	for _, p := range plans {
+		if p.ServiceID == svc.ID {
+			own = append(own, p)
+		}
+	}
+	return own
+}
+
+func billingPeriodOrDefault(period string) string {
+	if period == "" {
+		return models.BillingMonthly
+	}
+	return period
+}
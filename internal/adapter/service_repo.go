@@ -0,0 +1,280 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+var serviceColumns = []string{"id", "name", "logo_url", "category", "created_at", "updated_at"}
+
+// ServiceRepo хранит каталог сервисов. Каталог маленький и меняется редко,
+// поэтому все запросы идут в primary.
+type ServiceRepo struct {
+	db      *postgres.Database
+	plans   *PlanRepo
+	builder squirrel.StatementBuilderType
+}
+
+func NewServiceRepo(db *postgres.Database) *ServiceRepo {
+	return &ServiceRepo{
+		db:      db,
+		plans:   NewPlanRepo(db),
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *ServiceRepo) Create(ctx context.Context, svc *models.Service) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Insert("services").
+			Columns(serviceColumns...).
+			Values(svc.ID, svc.Name, svc.LogoURL, svc.Category, svc.CreatedAt, svc.UpdatedAt).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert query: %w", err)
+		}
+
+		if _, err := r.db.Writer(ctx).Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert service: %w", err)
+		}
+
+		if err := r.insertAliases(ctx, svc); err != nil {
+			return err
+		}
+
+		for i := range svc.Plans {
+			svc.Plans[i].ServiceID = svc.ID
+			if err := r.plans.Create(ctx, &svc.Plans[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *ServiceRepo) insertAliases(ctx context.Context, svc *models.Service) error {
+	qb := r.builder.
+		Insert("service_aliases").
+		Columns("alias_key", "alias", "service_id")
+	for _, name := range serviceNames(svc) {
+		qb = qb.Values(models.NormalizeServiceName(name), name, svc.ID)
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build alias insert query: %w", err)
+	}
+
+	_, err = r.db.Writer(ctx).Exec(ctx, query, args...)
+	if isPgUniqueViolation(err) {
+		return models.ErrServiceNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert service aliases: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ServiceRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Service, error) {
+	return r.getOne(ctx, squirrel.Eq{"id": id})
+}
+
+func (r *ServiceRepo) FindByName(ctx context.Context, name string) (*models.Service, error) {
+	key := models.NormalizeServiceName(name)
+	if key == "" {
+		return nil, nil
+	}
+
+	return r.getOne(ctx, squirrel.Expr("id = (SELECT service_id FROM service_aliases WHERE alias_key = ?)", key))
+}
+
+func (r *ServiceRepo) getOne(ctx context.Context, where squirrel.Sqlizer) (*models.Service, error) {
+	query, args, err := r.builder.
+		Select(serviceColumns...).
+		From("services").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var svc models.Service
+	err = r.db.Primary(ctx).QueryRow(ctx, query, args...).Scan(
+		&svc.ID, &svc.Name, &svc.LogoURL, &svc.Category, &svc.CreatedAt, &svc.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan service: %w", err)
+	}
+
+	aliases, err := r.aliases(ctx, squirrel.Eq{"service_id": svc.ID})
+	if err != nil {
+		return nil, err
+	}
+	svc.Aliases = aliasesOf(&svc, aliases[svc.ID])
+
+	if svc.Plans, err = r.plans.list(ctx, squirrel.Eq{"service_id": svc.ID}); err != nil {
+		return nil, err
+	}
+
+	return &svc, nil
+}
+
+func (r *ServiceRepo) aliases(ctx context.Context, where squirrel.Sqlizer) (map[uuid.UUID][]string, error) {
+	qb := r.builder.
+		Select("service_id", "alias").
+		From("service_aliases").
+		OrderBy("alias")
+	if where != nil {
+		qb = qb.Where(where)
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build aliases query: %w", err)
+	}
+
+	rows, err := r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := map[uuid.UUID][]string{}
+	for rows.Next() {
+		var (
+			id    uuid.UUID
+			alias string
+		)
+		if err := rows.Scan(&id, &alias); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		aliases[id] = append(aliases[id], alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get service aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+func (r *ServiceRepo) List(ctx context.Context) ([]*models.Service, error) {
+	query, args, err := r.builder.
+		Select(serviceColumns...).
+		From("services").
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list query: %w", err)
+	}
+
+	rows, err := r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+	defer rows.Close()
+
+	services := make([]*models.Service, 0)
+	for rows.Next() {
+		var svc models.Service
+		if err := rows.Scan(
+			&svc.ID, &svc.Name, &svc.LogoURL, &svc.Category, &svc.CreatedAt, &svc.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		services = append(services, &svc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+
+	aliases, err := r.aliases(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := r.plans.list(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range services {
+		svc.Aliases = aliasesOf(svc, aliases[svc.ID])
+		svc.Plans = plansOf(svc, plans)
+	}
+
+	return services, nil
+}
+
+// Update заменяет запись каталога и весь набор её синонимов. Тарифы
+// меняются через PlanRepo.
+func (r *ServiceRepo) Update(ctx context.Context, svc *models.Service) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Update("services").
+			Set("name", svc.Name).
+			Set("logo_url", svc.LogoURL).
+			Set("category", svc.Category).
+			Set("updated_at", svc.UpdatedAt).
+			Where(squirrel.Eq{"id": svc.ID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build update query: %w", err)
+		}
+
+		cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to update service: %w", err)
+		}
+		if cmd.RowsAffected() == 0 {
+			return fmt.Errorf("service not found")
+		}
+
+		query, args, err = r.builder.
+			Delete("service_aliases").
+			Where(squirrel.Eq{"service_id": svc.ID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build alias delete query: %w", err)
+		}
+		if _, err := r.db.Writer(ctx).Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete service aliases: %w", err)
+		}
+
+		return r.insertAliases(ctx, svc)
+	})
+}
+
+// Delete удаляет сервис; подписки остаются, но теряют ссылку на каталог.
+func (r *ServiceRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("services").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return fmt.Errorf("service not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,561 @@
+package adapter_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// runServiceRepoContract проверяет каталог сервисов, тарифы и привязку к ним
+// подписок. newRepos должен возвращать репозитории поверх одного пустого
+// хранилища.
+func runServiceRepoContract(t *testing.T, newRepos func(t *testing.T) (usecase.ServiceRepo, usecase.PlanRepo, usecase.SubscriptionRepo)) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		services, _, _ := newRepos(t)
+		ctx := context.Background()
+
+		svc := newService("Yandex Plus", "yandex plus", "Яндекс Плюс")
+		svc.LogoURL = "https://example.com/plus.png"
+		svc.Category = "music"
+		svc.Plans = []models.ServicePlan{
+			*newPlan(svc.ID, "Basic", 299),
+			*newPlan(svc.ID, "Family", 499),
+		}
+		svc.Plans[1].Default = true
+		svc.Plans[1].BillingPeriod = models.BillingYearly
+		mustCreateService(t, services, svc)
+
+		got, err := services.GetById(ctx, svc.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if got == nil || got.Name != svc.Name || got.LogoURL != svc.LogoURL || got.Category != svc.Category {
+			t.Fatalf("got %+v, want %+v", got, svc)
+		}
+		// «yandex plus» совпадает с названием и в синонимы не попадает.
+		if !slices.Equal(got.Aliases, []string{"Яндекс Плюс"}) {
+			t.Fatalf("aliases = %q, want [Яндекс Плюс]", got.Aliases)
+		}
+		assertPlans(t, got.Plans, svc.Plans)
+		if plan, _ := got.DefaultPlan(); plan.Price != 499 {
+			t.Fatalf("default plan price = %d, want 499", plan.Price)
+		}
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		services, _, _ := newRepos(t)
+
+		got, err := services.GetById(context.Background(), uuid.New())
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if got != nil {
+			t.Fatalf("got %+v, want nil", got)
+		}
+	})
+
+	t.Run("FindByName", func(t *testing.T) {
+		services, _, _ := newRepos(t)
+		ctx := context.Background()
+		svc := mustCreateService(t, services, newService("Yandex Plus", "Яндекс Плюс"))
+
+		for _, name := range []string{"Yandex Plus", "  yandex   PLUS ", "яндекс плюс"} {
+			got, err := services.FindByName(ctx, name)
+			if err != nil {
+				t.Fatalf("FindByName(%q): %v", name, err)
+			}
+			if got == nil || got.ID != svc.ID {
+				t.Fatalf("FindByName(%q) = %+v, want %s", name, got, svc.ID)
+			}
+		}
+
+		for _, name := range []string{"Netflix", ""} {
+			got, err := services.FindByName(ctx, name)
+			if err != nil {
+				t.Fatalf("FindByName(%q): %v", name, err)
+			}
+			if got != nil {
+				t.Fatalf("FindByName(%q) = %+v, want nil", name, got)
+			}
+		}
+	})
+
+	t.Run("NameTaken", func(t *testing.T) {
+		services, _, _ := newRepos(t)
+		ctx := context.Background()
+		mustCreateService(t, services, newService("Yandex Plus", "Яндекс Плюс"))
+
+		for _, svc := range []*models.Service{
+			newService("YANDEX PLUS"),
+			newService("Kinopoisk", "яндекс плюс"),
+		} {
+			if err := services.Create(ctx, svc); !errors.Is(err, models.ErrServiceNameTaken) {
+				t.Fatalf("Create(%s): err = %v, want ErrServiceNameTaken", svc.Name, err)
+			}
+			if got, err := services.GetById(ctx, svc.ID); err != nil || got != nil {
+				t.Fatalf("GetById after failed Create = %+v, %v; want nil", got, err)
+			}
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		services, _, _ := newRepos(t)
+		ctx := context.Background()
+
+		got, err := services.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if got == nil || len(got) != 0 {
+			t.Fatalf("List on empty catalog = %v, want empty slice", got)
+		}
+
+		mustCreateService(t, services, newService("Spotify"))
+		mustCreateService(t, services, newService("Netflix", "Нетфликс"))
+
+		got, err = services.List(ctx)
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(got) != 2 || got[0].Name != "Netflix" || got[1].Name != "Spotify" {
+			t.Fatalf("List = %+v, want Netflix, Spotify", got)
+		}
+		if !slices.Equal(got[0].Aliases, []string{"Нетфликс"}) {
+			t.Fatalf("aliases = %q, want [Нетфликс]", got[0].Aliases)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		services, _, _ := newRepos(t)
+		ctx := context.Background()
+		svc := mustCreateService(t, services, newService("Yandex Plus", "Яндекс Плюс"))
+		other := mustCreateService(t, services, newService("Okko"))
+
+		svc.Name = "Yandex Plus Multi"
+		svc.Aliases = []string{"Plus Multi"}
+		svc.UpdatedAt = svc.UpdatedAt.Add(time.Hour)
+		if err := services.Update(ctx, svc); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		got, err := services.FindByName(ctx, "plus multi")
+		if err != nil || got == nil || got.Name != "Yandex Plus Multi" {
+			t.Fatalf("FindByName after Update = %+v, %v", got, err)
+		}
+		if !got.UpdatedAt.Equal(svc.UpdatedAt) {
+			t.Fatalf("updated_at = %s, want %s", got.UpdatedAt, svc.UpdatedAt)
+		}
+		// Старые название и синоним освобождаются.
+		if got, err := services.FindByName(ctx, "Яндекс Плюс"); err != nil || got != nil {
+			t.Fatalf("FindByName(old alias) = %+v, %v; want nil", got, err)
+		}
+
+		other.Aliases = []string{"plus multi"}
+		if err := services.Update(ctx, other); !errors.Is(err, models.ErrServiceNameTaken) {
+			t.Fatalf("Update with taken alias: err = %v, want ErrServiceNameTaken", err)
+		}
+		if got, err := services.FindByName(ctx, "okko"); err != nil || got == nil || got.ID != other.ID {
+			t.Fatalf("failed Update changed the service: %+v, %v", got, err)
+		}
+	})
+
+	t.Run("UpdateMissing", func(t *testing.T) {
+		services, _, _ := newRepos(t)
+
+		if err := services.Update(context.Background(), newService("Netflix")); err == nil {
+			t.Fatal("Update: expected error for missing service")
+		}
+	})
+
+	t.Run("DeleteUnlinksSubscriptions", func(t *testing.T) {
+		services, _, subs := newRepos(t)
+		ctx := context.Background()
+		svc := mustCreateService(t, services, newService("Netflix"))
+
+		sub := newSubscription(uuid.New(), "Netflix", 100, month(2025, 1), nil)
+		sub.ServiceID = &svc.ID
+		mustCreate(t, subs, sub)
+
+		if err := services.Delete(ctx, svc.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if err := services.Delete(ctx, svc.ID); err == nil {
+			t.Fatal("second Delete: expected error")
+		}
+		if got, err := services.FindByName(ctx, "Netflix"); err != nil || got != nil {
+			t.Fatalf("FindByName after Delete = %+v, %v; want nil", got, err)
+		}
+
+		got, err := subs.GetById(ctx, sub.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if got.ServiceID != nil || got.ServiceName != "Netflix" {
+			t.Fatalf("subscription after service Delete = %+v, want unlinked Netflix", got)
+		}
+	})
+
+	t.Run("LinkService", func(t *testing.T) {
+		services, _, subs := newRepos(t)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		for _, name := range []string{"yandex plus", "yandex plus", "Yandex Plus", "Netflix"} {
+			mustCreate(t, subs, newSubscription(userID, name, 100, month(2025, 1), nil))
+		}
+
+		names, err := subs.UnlinkedServiceNames(ctx)
+		if err != nil {
+			t.Fatalf("UnlinkedServiceNames: %v", err)
+		}
+		want := []models.ServiceNameCount{{Name: "yandex plus", Count: 2}, {Name: "Netflix", Count: 1}, {Name: "Yandex Plus", Count: 1}}
+		if !slices.Equal(names, want) {
+			t.Fatalf("UnlinkedServiceNames = %+v, want %+v", names, want)
+		}
+
+		svc := mustCreateService(t, services, newService("Yandex Plus"))
+		n, err := subs.LinkService(ctx, "yandex plus", svc)
+		if err != nil {
+			t.Fatalf("LinkService: %v", err)
+		}
+		if n != 2 {
+			t.Fatalf("LinkService linked %d subscriptions, want 2", n)
+		}
+
+		list, err := subs.List(ctx, models.SubscriptionFilter{UserID: userID})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		linked := 0
+		for _, sub := range list {
+			if sub.ServiceID != nil {
+				if *sub.ServiceID != svc.ID || sub.ServiceName != "Yandex Plus" {
+					t.Fatalf("linked subscription = %+v", sub)
+				}
+				linked++
+			}
+		}
+		if linked != 2 {
+			t.Fatalf("%d subscriptions linked, want 2", linked)
+		}
+
+		svc.Name = "Yandex Plus Multi"
+		if err := services.Update(ctx, svc); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		n, err = subs.RenameService(ctx, svc)
+		if err != nil {
+			t.Fatalf("RenameService: %v", err)
+		}
+		if n != 2 {
+			t.Fatalf("RenameService renamed %d subscriptions, want 2", n)
+		}
+		total, err := subs.SumForPeriod(ctx, userID, "Yandex Plus Multi", month(2025, 1), nil)
+		if err != nil || total != 200 {
+			t.Fatalf("SumForPeriod(renamed) = %d, %v; want 200", total, err)
+		}
+	})
+	t.Run("Plans", func(t *testing.T) {
+		services, plans, subs := newRepos(t)
+		ctx := context.Background()
+		svc := mustCreateService(t, services, newService("Netflix"))
+
+		basic := newPlan(svc.ID, "Basic", 500)
+		if err := plans.Create(ctx, basic); err != nil {
+			t.Fatalf("Create plan: %v", err)
+		}
+		if err := plans.Create(ctx, newPlan(svc.ID, "Basic", 700)); !errors.Is(err, models.ErrPlanNameTaken) {
+			t.Fatalf("Create duplicate plan: err = %v, want ErrPlanNameTaken", err)
+		}
+		premium := newPlan(svc.ID, "Premium", 900)
+		if err := plans.Create(ctx, premium); err != nil {
+			t.Fatalf("Create plan: %v", err)
+		}
+
+		premium.Name = "Basic"
+		if err := plans.Update(ctx, premium); !errors.Is(err, models.ErrPlanNameTaken) {
+			t.Fatalf("Update with taken name: err = %v, want ErrPlanNameTaken", err)
+		}
+		premium.Name = "Premium HD"
+		premium.Default = true
+		if err := plans.Update(ctx, premium); err != nil {
+			t.Fatalf("Update plan: %v", err)
+		}
+
+		for _, change := range []models.PlanPriceChange{
+			{EffectiveFrom: month(2025, 6), Price: 600},
+			{EffectiveFrom: month(2025, 3), Price: 550},
+			{EffectiveFrom: month(2025, 6), Price: 650},
+		} {
+			if err := plans.SetPriceChange(ctx, basic.ID, change); err != nil {
+				t.Fatalf("SetPriceChange: %v", err)
+			}
+		}
+		if err := plans.SetPriceChange(ctx, uuid.New(), models.PlanPriceChange{EffectiveFrom: month(2025, 1), Price: 1}); err == nil {
+			t.Fatal("SetPriceChange: expected error for missing plan")
+		}
+
+		got, err := plans.GetById(ctx, basic.ID)
+		if err != nil || got == nil {
+			t.Fatalf("GetById = %+v, %v", got, err)
+		}
+		wantChanges := []models.PlanPriceChange{{EffectiveFrom: month(2025, 3), Price: 550}, {EffectiveFrom: month(2025, 6), Price: 650}}
+		if len(got.PriceChanges) != len(wantChanges) {
+			t.Fatalf("price changes = %+v, want %+v", got.PriceChanges, wantChanges)
+		}
+		for i, c := range got.PriceChanges {
+			if !c.EffectiveFrom.Equal(wantChanges[i].EffectiveFrom) || c.Price != wantChanges[i].Price {
+				t.Fatalf("price changes = %+v, want %+v", got.PriceChanges, wantChanges)
+			}
+		}
+		if price := got.PriceAt(month(2025, 4)); price != 550 {
+			t.Fatalf("PriceAt(04-2025) = %d, want 550", price)
+		}
+
+		withPlans, err := services.GetById(ctx, svc.ID)
+		if err != nil {
+			t.Fatalf("GetById service: %v", err)
+		}
+		if plan, ok := withPlans.DefaultPlan(); !ok || plan.ID != premium.ID || plan.Name != "Premium HD" {
+			t.Fatalf("default plan = %+v, want Premium HD", plan)
+		}
+
+		sub := newSubscription(uuid.New(), "Netflix", 500, month(2025, 1), nil)
+		sub.ServiceID = &svc.ID
+		sub.PlanID = &basic.ID
+		mustCreate(t, subs, sub)
+
+		if err := plans.Delete(ctx, basic.ID); err != nil {
+			t.Fatalf("Delete plan: %v", err)
+		}
+		if err := plans.Delete(ctx, basic.ID); err == nil {
+			t.Fatal("second Delete: expected error")
+		}
+		if got, err := plans.GetById(ctx, basic.ID); err != nil || got != nil {
+			t.Fatalf("GetById after Delete = %+v, %v; want nil", got, err)
+		}
+		if got, err := subs.GetById(ctx, sub.ID); err != nil || got.PlanID != nil || got.Price != 500 {
+			t.Fatalf("subscription after plan Delete = %+v, %v; want unlinked with price 500", got, err)
+		}
+	})
+
+	t.Run("PlanPriceChangeSum", func(t *testing.T) {
+		services, plans, subs := newRepos(t)
+		ctx := context.Background()
+		userID := uuid.New()
+		svc := mustCreateService(t, services, newService("Netflix"))
+		plan := newPlan(svc.ID, "Basic", 100)
+		if err := plans.Create(ctx, plan); err != nil {
+			t.Fatalf("Create plan: %v", err)
+		}
+		if err := plans.SetPriceChange(ctx, plan.ID, models.PlanPriceChange{EffectiveFrom: month(2025, 3), Price: 150}); err != nil {
+			t.Fatalf("SetPriceChange: %v", err)
+		}
+
+		onPlan := func(start time.Time, end *time.Time) {
+			sub := newSubscription(userID, "Netflix", 100, start, end)
+			sub.ServiceID = &svc.ID
+			sub.PlanID = &plan.ID
+			sub.BillingPeriod = plan.BillingPeriod
+			mustCreate(t, subs, sub)
+		}
+		// Подписка через изменение цены, закончившаяся до него и начавшаяся после.
+		onPlan(month(2025, 1), nil)
+		onPlan(month(2025, 1), ptr(month(2025, 2)))
+		onPlan(month(2025, 5), nil)
+		// Подписка без тарифа цену тарифа не получает.
+		mustCreate(t, subs, newSubscription(userID, "Netflix", 70, month(2025, 1), nil))
+
+		cases := []struct {
+			name  string
+			start time.Time
+			end   *time.Time
+			want  int
+		}{
+			{"before change", month(2025, 1), ptr(month(2025, 2)), 100 + 100 + 70},
+			{"after change", month(2025, 3), ptr(month(2025, 4)), 150 + 70},
+			{"across change", month(2025, 1), ptr(month(2025, 4)), 100 + 150 + 100 + 70},
+			{"open-ended", month(2025, 1), nil, 100 + 150 + 100 + 150 + 70},
+			{"late start only", month(2025, 5), nil, 150 + 150 + 70},
+		}
+		for _, tc := range cases {
+			total, err := subs.SumForPeriod(ctx, userID, "Netflix", tc.start, tc.end)
+			if err != nil {
+				t.Fatalf("%s: SumForPeriod: %v", tc.name, err)
+			}
+			if total != tc.want {
+				t.Fatalf("%s: SumForPeriod = %d, want %d", tc.name, total, tc.want)
+			}
+		}
+	})
+
+	t.Run("PlanPriceChangeSumWithPauses", func(t *testing.T) {
+		services, plans, subs := newRepos(t)
+		ctx := context.Background()
+		userID := uuid.New()
+		svc := mustCreateService(t, services, newService("Netflix"))
+		plan := newPlan(svc.ID, "Basic", 100)
+		if err := plans.Create(ctx, plan); err != nil {
+			t.Fatalf("Create plan: %v", err)
+		}
+		if err := plans.SetPriceChange(ctx, plan.ID, models.PlanPriceChange{EffectiveFrom: month(2025, 3), Price: 150}); err != nil {
+			t.Fatalf("SetPriceChange: %v", err)
+		}
+
+		onPlan := func(pause models.SubscriptionPause) {
+			sub := newSubscription(userID, "Netflix", 100, month(2025, 1), nil)
+			sub.ServiceID = &svc.ID
+			sub.PlanID = &plan.ID
+			sub.BillingPeriod = plan.BillingPeriod
+			mustCreate(t, subs, sub)
+			if err := subs.SetPauses(ctx, sub.ID, []models.SubscriptionPause{pause}); err != nil {
+				t.Fatalf("SetPauses: %v", err)
+			}
+		}
+		// Пауза на всю старую цену и бессрочная пауза с новой.
+		onPlan(models.SubscriptionPause{StartDate: month(2025, 1), EndDate: ptr(month(2025, 2))})
+		onPlan(models.SubscriptionPause{StartDate: month(2025, 3)})
+
+		cases := []struct {
+			name  string
+			start time.Time
+			end   *time.Time
+			want  int
+		}{
+			{"before change", month(2025, 1), ptr(month(2025, 2)), 100},
+			{"after change", month(2025, 3), ptr(month(2025, 4)), 150},
+			{"across change", month(2025, 1), ptr(month(2025, 4)), 150 + 100},
+			{"open-ended", month(2025, 1), nil, 150 + 100},
+		}
+		for _, tc := range cases {
+			total, err := subs.SumForPeriod(ctx, userID, "Netflix", tc.start, tc.end)
+			if err != nil {
+				t.Fatalf("%s: SumForPeriod: %v", tc.name, err)
+			}
+			if total != tc.want {
+				t.Fatalf("%s: SumForPeriod = %d, want %d", tc.name, total, tc.want)
+			}
+		}
+	})
+
+	t.Run("YearlyPlanSum", func(t *testing.T) {
+		services, plans, subs := newRepos(t)
+		ctx := context.Background()
+		userID := uuid.New()
+		svc := mustCreateService(t, services, newService("Netflix"))
+		monthly := newPlan(svc.ID, "Basic", 300)
+		yearly := newPlan(svc.ID, "Annual", 6000)
+		yearly.BillingPeriod = models.BillingYearly
+		for _, plan := range []*models.ServicePlan{monthly, yearly} {
+			if err := plans.Create(ctx, plan); err != nil {
+				t.Fatalf("Create plan: %v", err)
+			}
+		}
+		if err := plans.SetPriceChange(ctx, yearly.ID, models.PlanPriceChange{EffectiveFrom: month(2025, 7), Price: 7200}); err != nil {
+			t.Fatalf("SetPriceChange: %v", err)
+		}
+
+		ids := map[string]uuid.UUID{}
+		for _, plan := range []*models.ServicePlan{monthly, yearly} {
+			sub := newSubscription(userID, "Netflix", plan.Price, month(2025, 1), nil)
+			sub.ServiceID = &svc.ID
+			sub.PlanID = &plan.ID
+			sub.BillingPeriod = plan.BillingPeriod
+			ids[plan.Name] = mustCreate(t, subs, sub).ID
+		}
+		// Годовая подписка, отвязанная от тарифа, сохраняет период оплаты.
+		unlinked := newSubscription(userID, "Netflix", 1205, month(2025, 1), nil)
+		unlinked.BillingPeriod = models.BillingYearly
+		ids["unlinked"] = mustCreate(t, subs, unlinked).ID
+
+		cases := []struct {
+			name  string
+			start time.Time
+			end   *time.Time
+			want  int
+		}{
+			{"single month", month(2025, 3), ptr(month(2025, 3)), 300 + 500 + 100},
+			{"after change", month(2025, 8), ptr(month(2025, 8)), 300 + 600 + 100},
+			{"across change", month(2025, 1), ptr(month(2025, 12)), 300 + 500 + 600 + 100},
+		}
+		for _, tc := range cases {
+			total, err := subs.SumForPeriod(ctx, userID, "Netflix", tc.start, tc.end)
+			if err != nil {
+				t.Fatalf("%s: SumForPeriod: %v", tc.name, err)
+			}
+			if total != tc.want {
+				t.Fatalf("%s: SumForPeriod = %d, want %d", tc.name, total, tc.want)
+			}
+		}
+
+		totals, err := subs.SumForPeriodBySubscription(ctx, uuid.Nil, "", month(2025, 3), ptr(month(2025, 3)))
+		if err != nil {
+			t.Fatalf("SumForPeriodBySubscription: %v", err)
+		}
+		got := map[uuid.UUID]int{}
+		for _, tot := range totals {
+			got[tot.SubscriptionID] = tot.Total
+		}
+		want := map[uuid.UUID]int{ids["Basic"]: 300, ids["Annual"]: 500, ids["unlinked"]: 100}
+		if len(got) != len(want) {
+			t.Fatalf("SumForPeriodBySubscription = %v, want %v", got, want)
+		}
+		for id, total := range want {
+			if got[id] != total {
+				t.Fatalf("SumForPeriodBySubscription = %v, want %v", got, want)
+			}
+		}
+	})
+}
+
+func newService(name string, aliases ...string) *models.Service {
+	now := time.Date(2025, 1, 15, 10, 30, 0, 123456000, time.UTC)
+	return &models.Service{
+		ID:        uuid.New(),
+		Name:      name,
+		Aliases:   aliases,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func mustCreateService(t *testing.T, repo usecase.ServiceRepo, svc *models.Service) *models.Service {
+	t.Helper()
+	if err := repo.Create(context.Background(), svc); err != nil {
+		t.Fatalf("Create service: %v", err)
+	}
+	return svc
+}
+
+func newPlan(serviceID uuid.UUID, name string, price int) *models.ServicePlan {
+	now := time.Date(2025, 1, 15, 10, 30, 0, 123456000, time.UTC)
+	return &models.ServicePlan{
+		ID:            uuid.New(),
+		ServiceID:     serviceID,
+		Name:          name,
+		Price:         price,
+		BillingPeriod: models.BillingMonthly,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+func assertPlans(t *testing.T, got, want []models.ServicePlan) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("plans = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		g, w := got[i], want[i]
+		if g.ID != w.ID || g.ServiceID != w.ServiceID || g.Name != w.Name || g.Price != w.Price ||
+			g.BillingPeriod != w.BillingPeriod || g.Default != w.Default || !g.CreatedAt.Equal(w.CreatedAt) {
+			t.Fatalf("plans[%d] = %+v, want %+v", i, g, w)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// SQLiteBudgetRepo хранит пороги бюджета JSON-массивом.
+type SQLiteBudgetRepo struct {
+	db      *sqlite.Database
+	builder squirrel.StatementBuilderType
+}
+
+func NewSQLiteBudgetRepo(db *sqlite.Database) *SQLiteBudgetRepo {
+	return &SQLiteBudgetRepo{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func (r *SQLiteBudgetRepo) Create(ctx context.Context, b *models.Budget) error {
+	thresholds, err := json.Marshal(b.Thresholds)
+	if err != nil {
+		return fmt.Errorf("failed to encode thresholds: %w", err)
+	}
+
+	query, args, err := r.builder.
+		Insert("budgets").
+		Columns(budgetColumns...).
+		Values(
+			b.ID.String(), b.Name, b.Amount, b.Scope, b.ScopeValue, b.Period, string(thresholds),
+			sqliteTimestamp(b.CreatedAt), sqliteTimestamp(b.UpdatedAt),
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert budget: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteBudgetRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Budget, error) {
+	budgets, err := r.list(ctx, squirrel.Eq{"id": id.String()})
+	if err != nil {
+		return nil, err
+	}
+	if len(budgets) == 0 {
+		return nil, nil
+	}
+	return budgets[0], nil
+}
+
+func (r *SQLiteBudgetRepo) List(ctx context.Context) ([]*models.Budget, error) {
+	return r.list(ctx, nil)
+}
+
+func (r *SQLiteBudgetRepo) list(ctx context.Context, where squirrel.Sqlizer) ([]*models.Budget, error) {
+	qb := r.builder.
+		Select(budgetColumns...).
+		From("budgets").
+		OrderBy("created_at", "id")
+	if where != nil {
+		qb = qb.Where(where)
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build budgets query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+	defer rows.Close()
+
+	budgets := make([]*models.Budget, 0)
+	for rows.Next() {
+		var (
+			b                    models.Budget
+			id, thresholds       string
+			createdAt, updatedAt string
+		)
+		if err := rows.Scan(&id, &b.Name, &b.Amount, &b.Scope, &b.ScopeValue, &b.Period, &thresholds, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		if b.ID, err = uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("invalid id %q: %w", id, err)
+		}
+		if err := json.Unmarshal([]byte(thresholds), &b.Thresholds); err != nil {
+			return nil, fmt.Errorf("invalid thresholds %q: %w", thresholds, err)
+		}
+		if b.CreatedAt, err = time.Parse(sqliteTimestampLayout, createdAt); err != nil {
+			return nil, fmt.Errorf("invalid created_at %q: %w", createdAt, err)
+		}
+		if b.UpdatedAt, err = time.Parse(sqliteTimestampLayout, updatedAt); err != nil {
+			return nil, fmt.Errorf("invalid updated_at %q: %w", updatedAt, err)
+		}
+		budgets = append(budgets, &b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	return budgets, nil
+}
+
+func (r *SQLiteBudgetRepo) Update(ctx context.Context, b *models.Budget) error {
+	thresholds, err := json.Marshal(b.Thresholds)
+	if err != nil {
+		return fmt.Errorf("failed to encode thresholds: %w", err)
+	}
+
+	query, args, err := r.builder.
+		Update("budgets").
+		Set("name", b.Name).
+		Set("amount", b.Amount).
+		Set("thresholds", string(thresholds)).
+		Set("updated_at", sqliteTimestamp(b.UpdatedAt)).
+		Where(squirrel.Eq{"id": b.ID.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("budget not found")
+	}
+
+	return nil
+}
+
+func (r *SQLiteBudgetRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("budgets").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("budget not found")
+	}
+
+	return nil
+}
+
+func (r *SQLiteBudgetRepo) ClaimAlert(ctx context.Context, budgetID uuid.UUID, periodStart time.Time, threshold int) (bool, error) {
+	query, args, err := r.builder.
+		Insert("budget_alerts").
+		Columns("budget_id", "period_start", "threshold", "created_at").
+		Values(budgetID.String(), sqliteDate(periodStart), threshold, sqliteTimestamp(time.Now())).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build claim alert query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim budget alert: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim budget alert: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+func (r *SQLiteBudgetRepo) ReleaseAlert(ctx context.Context, budgetID uuid.UUID, periodStart time.Time, threshold int) error {
+	query, args, err := r.builder.
+		Delete("budget_alerts").
+		Where(squirrel.Eq{"budget_id": budgetID.String(), "period_start": sqliteDate(periodStart), "threshold": threshold}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build release alert query: %w", err)
+	}
+
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to release budget alert: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteBudgetRepo) AlertedThresholds(ctx context.Context, budgetID uuid.UUID, periodStart time.Time) ([]int, error) {
+	query, args, err := r.builder.
+		Select("threshold").
+		From("budget_alerts").
+		Where(squirrel.Eq{"budget_id": budgetID.String(), "period_start": sqliteDate(periodStart)}).
+		OrderBy("threshold").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build alerts query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget alerts: %w", err)
+	}
+	defer rows.Close()
+
+	thresholds := make([]int, 0)
+	for rows.Next() {
+		var t int
+		if err := rows.Scan(&t); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		thresholds = append(thresholds, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get budget alerts: %w", err)
+	}
+
+	return thresholds, nil
+}
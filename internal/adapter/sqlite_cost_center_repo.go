@@ -0,0 +1,251 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type SQLiteCostCenterRepo struct {
+	db      *sqlite.Database
+	builder squirrel.StatementBuilderType
+}
+
+func NewSQLiteCostCenterRepo(db *sqlite.Database) *SQLiteCostCenterRepo {
+	return &SQLiteCostCenterRepo{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func (r *SQLiteCostCenterRepo) Create(ctx context.Context, cc *models.CostCenter) error {
+	query, args, err := r.builder.
+		Insert("cost_centers").
+		Columns(costCenterColumns...).
+		Values(cc.ID.String(), cc.Name, sqliteTimestamp(cc.CreatedAt), sqliteTimestamp(cc.UpdatedAt)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	_, err = r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if sqlite.IsUniqueViolation(err) {
+		return models.ErrCostCenterNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert cost center: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteCostCenterRepo) GetById(ctx context.Context, id uuid.UUID) (*models.CostCenter, error) {
+	centers, err := r.list(ctx, squirrel.Eq{"id": id.String()})
+	if err != nil {
+		return nil, err
+	}
+	if len(centers) == 0 {
+		return nil, nil
+	}
+	return centers[0], nil
+}
+
+func (r *SQLiteCostCenterRepo) List(ctx context.Context) ([]*models.CostCenter, error) {
+	return r.list(ctx, nil)
+}
+
+func (r *SQLiteCostCenterRepo) list(ctx context.Context, where squirrel.Sqlizer) ([]*models.CostCenter, error) {
+	qb := r.builder.
+		Select(costCenterColumns...).
+		From("cost_centers").
+		OrderBy("name")
+	if where != nil {
+		qb = qb.Where(where)
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cost centers query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost centers: %w", err)
+	}
+	defer rows.Close()
+
+	centers := make([]*models.CostCenter, 0)
+	for rows.Next() {
+		var (
+			cc                   models.CostCenter
+			id                   string
+			createdAt, updatedAt string
+		)
+		if err := rows.Scan(&id, &cc.Name, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		if cc.ID, err = uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("invalid id %q: %w", id, err)
+		}
+		if cc.CreatedAt, err = time.Parse(sqliteTimestampLayout, createdAt); err != nil {
+			return nil, fmt.Errorf("invalid created_at %q: %w", createdAt, err)
+		}
+		if cc.UpdatedAt, err = time.Parse(sqliteTimestampLayout, updatedAt); err != nil {
+			return nil, fmt.Errorf("invalid updated_at %q: %w", updatedAt, err)
+		}
+		centers = append(centers, &cc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get cost centers: %w", err)
+	}
+
+	return centers, nil
+}
+
+func (r *SQLiteCostCenterRepo) Update(ctx context.Context, cc *models.CostCenter) error {
+	query, args, err := r.builder.
+		Update("cost_centers").
+		Set("name", cc.Name).
+		Set("updated_at", sqliteTimestamp(cc.UpdatedAt)).
+		Where(squirrel.Eq{"id": cc.ID.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if sqlite.IsUniqueViolation(err) {
+		return models.ErrCostCenterNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update cost center: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to update cost center: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("cost center not found")
+	}
+
+	return nil
+}
+
+func (r *SQLiteCostCenterRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("cost_centers").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if sqlite.IsForeignKeyViolation(err) {
+		return models.ErrCostCenterInUse
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete cost center: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to delete cost center: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("cost center not found")
+	}
+
+	return nil
+}
+
+func (r *SQLiteCostCenterRepo) SetAllocations(ctx context.Context, subscriptionID uuid.UUID, allocations []models.Allocation) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Delete("subscription_allocations").
+			Where(squirrel.Eq{"subscription_id": subscriptionID.String()}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build delete allocations query: %w", err)
+		}
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete allocations: %w", err)
+		}
+
+		if len(allocations) == 0 {
+			return nil
+		}
+
+		qb := r.builder.
+			Insert("subscription_allocations").
+			Columns("subscription_id", "cost_center_id", "percent")
+		for _, a := range allocations {
+			qb = qb.Values(subscriptionID.String(), a.CostCenterID.String(), a.Percent)
+		}
+		query, args, err = qb.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert allocations query: %w", err)
+		}
+
+		_, err = r.db.Conn(ctx).ExecContext(ctx, query, args...)
+		if sqlite.IsForeignKeyViolation(err) {
+			return fmt.Errorf("subscription or cost center not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert allocations: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *SQLiteCostCenterRepo) ListAllocations(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID][]models.Allocation, error) {
+	allocations := map[uuid.UUID][]models.Allocation{}
+	if len(subscriptionIDs) == 0 {
+		return allocations, nil
+	}
+
+	ids := make([]string, 0, len(subscriptionIDs))
+	for _, id := range subscriptionIDs {
+		ids = append(ids, id.String())
+	}
+
+	query, args, err := r.builder.
+		Select("subscription_id", "cost_center_id", "percent").
+		From("subscription_allocations").
+		Where(squirrel.Eq{"subscription_id": ids}).
+		OrderBy("subscription_id", "percent DESC", "cost_center_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build allocations query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			subscriptionID, costCenterID string
+			a                            models.Allocation
+		)
+		if err := rows.Scan(&subscriptionID, &costCenterID, &a.Percent); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		id, err := uuid.Parse(subscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subscription_id %q: %w", subscriptionID, err)
+		}
+		if a.CostCenterID, err = uuid.Parse(costCenterID); err != nil {
+			return nil, fmt.Errorf("invalid cost_center_id %q: %w", costCenterID, err)
+		}
+		allocations[id] = append(allocations[id], a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get allocations: %w", err)
+	}
+
+	return allocations, nil
+}
@@ -0,0 +1,229 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type SQLitePlanRepo struct {
+	db      *sqlite.Database
+	builder squirrel.StatementBuilderType
+}
+
+func NewSQLitePlanRepo(db *sqlite.Database) *SQLitePlanRepo {
+	return &SQLitePlanRepo{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func (r *SQLitePlanRepo) Create(ctx context.Context, plan *models.ServicePlan) error {
+	query, args, err := r.builder.
+		Insert("plans").
+		Columns(planColumns...).
+		Values(
+			plan.ID.String(), plan.ServiceID.String(), plan.Name, plan.Price, billingPeriodOrDefault(plan.BillingPeriod),
+			plan.Default, sqliteTimestamp(plan.CreatedAt), sqliteTimestamp(plan.UpdatedAt),
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	_, err = r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if sqlite.IsUniqueViolation(err) {
+		return models.ErrPlanNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert plan: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLitePlanRepo) GetById(ctx context.Context, id uuid.UUID) (*models.ServicePlan, error) {
+	plans, err := r.list(ctx, squirrel.Eq{"id": id.String()})
+	if err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		return nil, nil
+	}
+	return &plans[0], nil
+}
+
+// list возвращает тарифы вместе с изменениями цен в порядке создания;
+// nil в where означает все тарифы.
+func (r *SQLitePlanRepo) list(ctx context.Context, where squirrel.Sqlizer) ([]models.ServicePlan, error) {
+	qb := r.builder.
+		Select(planColumns...).
+		From("plans").
+		OrderBy("created_at", "name")
+	changes := r.builder.
+		Select("plan_id", "effective_from", "price").
+		From("plan_price_changes").
+		OrderBy("effective_from")
+	if where != nil {
+		qb = qb.Where(where)
+		changes = changes.Where(squirrel.Expr("plan_id IN (?)", squirrel.Select("id").From("plans").Where(where)))
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build plans query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := make([]models.ServicePlan, 0)
+	index := map[uuid.UUID]int{}
+	for rows.Next() {
+		var (
+			p                    models.ServicePlan
+			id, serviceID        string
+			createdAt, updatedAt string
+		)
+		if err := rows.Scan(&id, &serviceID, &p.Name, &p.Price, &p.BillingPeriod, &p.Default, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		if p.ID, err = uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("invalid id %q: %w", id, err)
+		}
+		if p.ServiceID, err = uuid.Parse(serviceID); err != nil {
+			return nil, fmt.Errorf("invalid service_id %q: %w", serviceID, err)
+		}
+		if p.CreatedAt, err = time.Parse(sqliteTimestampLayout, createdAt); err != nil {
+			return nil, fmt.Errorf("invalid created_at %q: %w", createdAt, err)
+		}
+		if p.UpdatedAt, err = time.Parse(sqliteTimestampLayout, updatedAt); err != nil {
+			return nil, fmt.Errorf("invalid updated_at %q: %w", updatedAt, err)
+		}
+		index[p.ID] = len(plans)
+		plans = append(plans, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get plans: %w", err)
+	}
+	rows.Close()
+	if len(plans) == 0 {
+		return plans, nil
+	}
+
+	query, args, err = changes.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build price changes query: %w", err)
+	}
+
+	rows, err = r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plan price changes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			planID, effectiveFrom string
+			change                models.PlanPriceChange
+		)
+		if err := rows.Scan(&planID, &effectiveFrom, &change.Price); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		id, err := uuid.Parse(planID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid plan_id %q: %w", planID, err)
+		}
+		if change.EffectiveFrom, err = time.Parse(sqliteDateLayout, effectiveFrom); err != nil {
+			return nil, fmt.Errorf("invalid effective_from %q: %w", effectiveFrom, err)
+		}
+		if i, ok := index[id]; ok {
+			plans[i].PriceChanges = append(plans[i].PriceChanges, change)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get plan price changes: %w", err)
+	}
+
+	return plans, nil
+}
+
+func (r *SQLitePlanRepo) Update(ctx context.Context, plan *models.ServicePlan) error {
+	query, args, err := r.builder.
+		Update("plans").
+		Set("name", plan.Name).
+		Set("is_default", plan.Default).
+		Set("updated_at", sqliteTimestamp(plan.UpdatedAt)).
+		Where(squirrel.Eq{"id": plan.ID.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if sqlite.IsUniqueViolation(err) {
+		return models.ErrPlanNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update plan: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to update plan: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("plan not found")
+	}
+
+	return nil
+}
+
+func (r *SQLitePlanRepo) SetPriceChange(ctx context.Context, planID uuid.UUID, change models.PlanPriceChange) error {
+	query, args, err := r.builder.
+		Insert("plan_price_changes").
+		Columns("plan_id", "effective_from", "price").
+		Values(planID.String(), sqliteDate(change.EffectiveFrom), change.Price).
+		Suffix("ON CONFLICT (plan_id, effective_from) DO UPDATE SET price = excluded.price").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build price change query: %w", err)
+	}
+
+	_, err = r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if sqlite.IsForeignKeyViolation(err) {
+		return fmt.Errorf("plan not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set plan price: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLitePlanRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("plans").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete plan: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to delete plan: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("plan not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,300 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type SQLiteServiceRepo struct {
+	db      *sqlite.Database
+	plans   *SQLitePlanRepo
+	builder squirrel.StatementBuilderType
+}
+
+func NewSQLiteServiceRepo(db *sqlite.Database) *SQLiteServiceRepo {
+	return &SQLiteServiceRepo{
+		db:      db,
+		plans:   NewSQLitePlanRepo(db),
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func scanSQLiteService(row sqliteScanner) (*models.Service, error) {
+	var (
+		svc                  models.Service
+		id                   string
+		createdAt, updatedAt string
+	)
+	if err := row.Scan(&id, &svc.Name, &svc.LogoURL, &svc.Category, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if svc.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid id %q: %w", id, err)
+	}
+	if svc.CreatedAt, err = time.Parse(sqliteTimestampLayout, createdAt); err != nil {
+		return nil, fmt.Errorf("invalid created_at %q: %w", createdAt, err)
+	}
+	if svc.UpdatedAt, err = time.Parse(sqliteTimestampLayout, updatedAt); err != nil {
+		return nil, fmt.Errorf("invalid updated_at %q: %w", updatedAt, err)
+	}
+
+	return &svc, nil
+}
+
+func (r *SQLiteServiceRepo) Create(ctx context.Context, svc *models.Service) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Insert("services").
+			Columns(serviceColumns...).
+			Values(
+				svc.ID.String(), svc.Name, svc.LogoURL, svc.Category,
+				sqliteTimestamp(svc.CreatedAt), sqliteTimestamp(svc.UpdatedAt),
+			).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert query: %w", err)
+		}
+
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert service: %w", err)
+		}
+
+		if err := r.insertAliases(ctx, svc); err != nil {
+			return err
+		}
+
+		for i := range svc.Plans {
+			svc.Plans[i].ServiceID = svc.ID
+			if err := r.plans.Create(ctx, &svc.Plans[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *SQLiteServiceRepo) insertAliases(ctx context.Context, svc *models.Service) error {
+	qb := r.builder.
+		Insert("service_aliases").
+		Columns("alias_key", "alias", "service_id")
+	for _, name := range serviceNames(svc) {
+		qb = qb.Values(models.NormalizeServiceName(name), name, svc.ID.String())
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build alias insert query: %w", err)
+	}
+
+	_, err = r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if sqlite.IsUniqueViolation(err) {
+		return models.ErrServiceNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert service aliases: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteServiceRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Service, error) {
+	return r.getOne(ctx, squirrel.Eq{"id": id.String()})
+}
+
+func (r *SQLiteServiceRepo) FindByName(ctx context.Context, name string) (*models.Service, error) {
+	key := models.NormalizeServiceName(name)
+	if key == "" {
+		return nil, nil
+	}
+
+	return r.getOne(ctx, squirrel.Expr("id = (SELECT service_id FROM service_aliases WHERE alias_key = ?)", key))
+}
+
+func (r *SQLiteServiceRepo) getOne(ctx context.Context, where squirrel.Sqlizer) (*models.Service, error) {
+	query, args, err := r.builder.
+		Select(serviceColumns...).
+		From("services").
+		Where(where).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	svc, err := scanSQLiteService(r.db.Conn(ctx).QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan service: %w", err)
+	}
+
+	aliases, err := r.aliases(ctx, squirrel.Eq{"service_id": svc.ID.String()})
+	if err != nil {
+		return nil, err
+	}
+	svc.Aliases = aliasesOf(svc, aliases[svc.ID])
+
+	if svc.Plans, err = r.plans.list(ctx, squirrel.Eq{"service_id": svc.ID.String()}); err != nil {
+		return nil, err
+	}
+
+	return svc, nil
+}
+
+func (r *SQLiteServiceRepo) aliases(ctx context.Context, where squirrel.Sqlizer) (map[uuid.UUID][]string, error) {
+	qb := r.builder.
+		Select("service_id", "alias").
+		From("service_aliases").
+		OrderBy("alias")
+	if where != nil {
+		qb = qb.Where(where)
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build aliases query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service aliases: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := map[uuid.UUID][]string{}
+	for rows.Next() {
+		var idStr, alias string
+		if err := rows.Scan(&idStr, &alias); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service_id %q: %w", idStr, err)
+		}
+		aliases[id] = append(aliases[id], alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get service aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+func (r *SQLiteServiceRepo) List(ctx context.Context) ([]*models.Service, error) {
+	query, args, err := r.builder.
+		Select(serviceColumns...).
+		From("services").
+		OrderBy("name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+	defer rows.Close()
+
+	services := make([]*models.Service, 0)
+	for rows.Next() {
+		svc, err := scanSQLiteService(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		services = append(services, svc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get services: %w", err)
+	}
+	// Единственное соединение SQLite освобождается только после закрытия rows.
+	rows.Close()
+
+	aliases, err := r.aliases(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := r.plans.list(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range services {
+		svc.Aliases = aliasesOf(svc, aliases[svc.ID])
+		svc.Plans = plansOf(svc, plans)
+	}
+
+	return services, nil
+}
+
+// Update заменяет запись каталога и весь набор её синонимов.
+func (r *SQLiteServiceRepo) Update(ctx context.Context, svc *models.Service) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Update("services").
+			Set("name", svc.Name).
+			Set("logo_url", svc.LogoURL).
+			Set("category", svc.Category).
+			Set("updated_at", sqliteTimestamp(svc.UpdatedAt)).
+			Where(squirrel.Eq{"id": svc.ID.String()}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build update query: %w", err)
+		}
+
+		res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to update service: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("failed to update service: %w", err)
+		} else if n == 0 {
+			return fmt.Errorf("service not found")
+		}
+
+		query, args, err = r.builder.
+			Delete("service_aliases").
+			Where(squirrel.Eq{"service_id": svc.ID.String()}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build alias delete query: %w", err)
+		}
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete service aliases: %w", err)
+		}
+
+		return r.insertAliases(ctx, svc)
+	})
+}
+
+// Delete удаляет сервис; подписки остаются, но теряют ссылку на каталог.
+func (r *SQLiteServiceRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("services").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("service not found")
+	}
+
+	return nil
+}
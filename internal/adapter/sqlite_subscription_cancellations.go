@@ -0,0 +1,161 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+func (r *SQLiteSubscriptionRepo) CreateCancellation(ctx context.Context, c models.Cancellation) error {
+	query, args, err := r.builder.
+		Insert("subscription_cancellations").
+		Columns(cancellationColumns...).
+		Values(
+			c.SubscriptionID.String(), sqliteDate(c.EffectiveDate), sqliteNullDate(c.PreviousEndDate),
+			c.Reason, c.Comment, sqliteTimestamp(c.CancelledAt),
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert cancellation query: %w", err)
+	}
+
+	_, err = r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if sqlite.IsForeignKeyViolation(err) {
+		return fmt.Errorf("subscription not found")
+	}
+	if sqlite.IsUniqueViolation(err) {
+		return models.ErrAlreadyCancelled
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert subscription cancellation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteSubscriptionRepo) DeleteCancellation(ctx context.Context, subscriptionID uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("subscription_cancellations").
+		Where(squirrel.Eq{"subscription_id": subscriptionID.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete cancellation query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription cancellation: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to delete subscription cancellation: %w", err)
+	} else if n == 0 {
+		return models.ErrNotCancelled
+	}
+
+	return nil
+}
+
+func (r *SQLiteSubscriptionRepo) ListCancellations(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID]models.Cancellation, error) {
+	cancellations := map[uuid.UUID]models.Cancellation{}
+	if len(subscriptionIDs) == 0 {
+		return cancellations, nil
+	}
+
+	ids := make([]string, 0, len(subscriptionIDs))
+	for _, id := range subscriptionIDs {
+		ids = append(ids, id.String())
+	}
+
+	query, args, err := r.builder.
+		Select(cancellationColumns...).
+		From("subscription_cancellations").
+		Where(squirrel.Eq{"subscription_id": ids}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cancellations query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription cancellations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			subscriptionID, effectiveDate, cancelledAt string
+			previousEndDate                            sql.NullString
+			c                                          models.Cancellation
+		)
+		if err := rows.Scan(&subscriptionID, &effectiveDate, &previousEndDate, &c.Reason, &c.Comment, &cancelledAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		if c.SubscriptionID, err = uuid.Parse(subscriptionID); err != nil {
+			return nil, fmt.Errorf("invalid subscription_id %q: %w", subscriptionID, err)
+		}
+		if c.EffectiveDate, err = time.Parse(sqliteDateLayout, effectiveDate); err != nil {
+			return nil, fmt.Errorf("invalid effective_date %q: %w", effectiveDate, err)
+		}
+		if previousEndDate.Valid {
+			end, err := time.Parse(sqliteDateLayout, previousEndDate.String)
+			if err != nil {
+				return nil, fmt.Errorf("invalid previous_end_date %q: %w", previousEndDate.String, err)
+			}
+			c.PreviousEndDate = &end
+		}
+		if c.CancelledAt, err = time.Parse(sqliteTimestampLayout, cancelledAt); err != nil {
+			return nil, fmt.Errorf("invalid cancelled_at %q: %w", cancelledAt, err)
+		}
+		cancellations[c.SubscriptionID] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get subscription cancellations: %w", err)
+	}
+
+	return cancellations, nil
+}
+
+func (r *SQLiteSubscriptionRepo) ChurnReport(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]models.ChurnTotal, error) {
+	qb := r.builder.
+		Select("c.reason", "s.service_name", "COUNT(*)").
+		From("subscription_cancellations c").
+		Join("subscriptions s ON s.id = c.subscription_id").
+		Where(squirrel.GtOrEq{"c.effective_date": sqliteDate(start)}).
+		Where(squirrel.LtOrEq{"c.effective_date": sqliteDate(end)}).
+		GroupBy("c.reason", "s.service_name").
+		OrderBy("COUNT(*) DESC", "c.reason", "s.service_name")
+	if userID != uuid.Nil {
+		qb = qb.Where(squirrel.Eq{"s.user_id": userID.String()})
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build churn query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get churn: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make([]models.ChurnTotal, 0)
+	for rows.Next() {
+		var t models.ChurnTotal
+		if err := rows.Scan(&t.Reason, &t.ServiceName, &t.Count); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get churn: %w", err)
+	}
+
+	return totals, nil
+}
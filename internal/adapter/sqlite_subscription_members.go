@@ -0,0 +1,89 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+func (r *SQLiteSubscriptionRepo) SetMembers(ctx context.Context, subscriptionID uuid.UUID, members []models.SubscriptionMember) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Delete("subscription_members").
+			Where(squirrel.Eq{"subscription_id": subscriptionID.String()}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build delete members query: %w", err)
+		}
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete subscription members: %w", err)
+		}
+
+		if len(members) == 0 {
+			return nil
+		}
+
+		qb := r.builder.
+			Insert("subscription_members").
+			Columns("subscription_id", "user_id", "weight")
+		for _, m := range members {
+			qb = qb.Values(subscriptionID.String(), m.UserID.String(), m.Weight)
+		}
+		query, args, err = qb.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert members query: %w", err)
+		}
+
+		_, err = r.db.Conn(ctx).ExecContext(ctx, query, args...)
+		if sqlite.IsForeignKeyViolation(err) {
+			return fmt.Errorf("subscription not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert subscription members: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *SQLiteSubscriptionRepo) ListMembers(ctx context.Context, subscriptionID uuid.UUID) ([]models.SubscriptionMember, error) {
+	query, args, err := r.builder.
+		Select("user_id", "weight").
+		From("subscription_members").
+		Where(squirrel.Eq{"subscription_id": subscriptionID.String()}).
+		OrderBy("user_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build members query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]models.SubscriptionMember, 0)
+	for rows.Next() {
+		var (
+			userID string
+			m      models.SubscriptionMember
+		)
+		if err := rows.Scan(&userID, &m.Weight); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		if m.UserID, err = uuid.Parse(userID); err != nil {
+			return nil, fmt.Errorf("invalid user_id %q: %w", userID, err)
+		}
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get subscription members: %w", err)
+	}
+
+	return members, nil
+}
@@ -0,0 +1,112 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+func (r *SQLiteSubscriptionRepo) SetPauses(ctx context.Context, subscriptionID uuid.UUID, pauses []models.SubscriptionPause) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Delete("subscription_pauses").
+			Where(squirrel.Eq{"subscription_id": subscriptionID.String()}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build delete pauses query: %w", err)
+		}
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete subscription pauses: %w", err)
+		}
+
+		if len(pauses) == 0 {
+			return nil
+		}
+
+		qb := r.builder.
+			Insert("subscription_pauses").
+			Columns("subscription_id", "start_date", "end_date")
+		for _, p := range pauses {
+			qb = qb.Values(subscriptionID.String(), sqliteDate(p.StartDate), sqliteNullDate(p.EndDate))
+		}
+		query, args, err = qb.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert pauses query: %w", err)
+		}
+
+		_, err = r.db.Conn(ctx).ExecContext(ctx, query, args...)
+		if sqlite.IsForeignKeyViolation(err) {
+			return fmt.Errorf("subscription not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert subscription pauses: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *SQLiteSubscriptionRepo) ListPauses(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID][]models.SubscriptionPause, error) {
+	pauses := map[uuid.UUID][]models.SubscriptionPause{}
+	if len(subscriptionIDs) == 0 {
+		return pauses, nil
+	}
+
+	ids := make([]string, 0, len(subscriptionIDs))
+	for _, id := range subscriptionIDs {
+		ids = append(ids, id.String())
+	}
+
+	query, args, err := r.builder.
+		Select("subscription_id", "start_date", "end_date").
+		From("subscription_pauses").
+		Where(squirrel.Eq{"subscription_id": ids}).
+		OrderBy("subscription_id", "start_date").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pauses query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription pauses: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			subscriptionID, startDate string
+			endDate                   sql.NullString
+			p                         models.SubscriptionPause
+		)
+		if err := rows.Scan(&subscriptionID, &startDate, &endDate); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		id, err := uuid.Parse(subscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subscription_id %q: %w", subscriptionID, err)
+		}
+		if p.StartDate, err = time.Parse(sqliteDateLayout, startDate); err != nil {
+			return nil, fmt.Errorf("invalid start_date %q: %w", startDate, err)
+		}
+		if endDate.Valid {
+			end, err := time.Parse(sqliteDateLayout, endDate.String)
+			if err != nil {
+				return nil, fmt.Errorf("invalid end_date %q: %w", endDate.String, err)
+			}
+			p.EndDate = &end
+		}
+		pauses[id] = append(pauses[id], p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get subscription pauses: %w", err)
+	}
+
+	return pauses, nil
+}
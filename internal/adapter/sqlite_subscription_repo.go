@@ -0,0 +1,658 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+const (
+	sqliteDateLayout      = "2006-01-02"
+	sqliteTimestampLayout = "2006-01-02 15:04:05.000000"
+)
+
+// SQLiteSubscriptionRepo хранит подписки в SQLite. Даты записываются строками
+// YYYY-MM-DD, поэтому сравнения в SumForPeriod работают как с DATE в Postgres.
+type SQLiteSubscriptionRepo struct {
+	db      *sqlite.Database
+	builder squirrel.StatementBuilderType
+}
+
+func NewSQLiteSubscriptionRepo(db *sqlite.Database) *SQLiteSubscriptionRepo {
+	return &SQLiteSubscriptionRepo{
+		db:      db,
+		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question),
+	}
+}
+
+func sqliteDate(t time.Time) string {
+	return t.Format(sqliteDateLayout)
+}
+
+func sqliteNullDate(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: sqliteDate(*t), Valid: true}
+}
+
+// sqliteTimestamp, как и TIMESTAMP без часового пояса, сохраняет показания
+// часов и отбрасывает зону.
+func sqliteTimestamp(t time.Time) string {
+	return t.Format(sqliteTimestampLayout)
+}
+
+func sqliteNullUUID(id *uuid.UUID) sql.NullString {
+	if id == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: id.String(), Valid: true}
+}
+
+// sqliteSubscriptionSelect — колонки подписки и её теги JSON-массивом по алфавиту.
+var sqliteSubscriptionSelect = append(slices.Clone(subscriptionColumns),
+	`(SELECT json_group_array(name) FROM (SELECT t.name FROM subscription_tags st
+		JOIN tags t ON t.id = st.tag_id WHERE st.subscription_id = subscriptions.id ORDER BY t.name)) AS tags`,
+)
+
+type sqliteScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSQLiteSubscription(row sqliteScanner) (*models.Subscription, error) {
+	var (
+		sub                  models.Subscription
+		id, userID           string
+		serviceID, planID    sql.NullString
+		startDate            string
+		endDate, trialEnd    sql.NullString
+		introPrice           sql.NullInt64
+		createdAt, updatedAt string
+		tags                 string
+	)
+	if err := row.Scan(
+		&id, &sub.ServiceName, &serviceID, &planID, &sub.Price, &sub.BillingPeriod, &sub.Category,
+		&userID, &startDate, &endDate, &trialEnd, &introPrice, &sub.IntroMonths, &createdAt, &updatedAt, &tags,
+	); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if sub.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("invalid id %q: %w", id, err)
+	}
+	if sub.UserID, err = uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("invalid user_id %q: %w", userID, err)
+	}
+	if serviceID.Valid {
+		id, err := uuid.Parse(serviceID.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid service_id %q: %w", serviceID.String, err)
+		}
+		sub.ServiceID = &id
+	}
+	if planID.Valid {
+		id, err := uuid.Parse(planID.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid plan_id %q: %w", planID.String, err)
+		}
+		sub.PlanID = &id
+	}
+	if sub.StartDate, err = time.Parse(sqliteDateLayout, startDate); err != nil {
+		return nil, fmt.Errorf("invalid start_date %q: %w", startDate, err)
+	}
+	if endDate.Valid {
+		end, err := time.Parse(sqliteDateLayout, endDate.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_date %q: %w", endDate.String, err)
+		}
+		sub.EndDate = &end
+	}
+	if trialEnd.Valid {
+		trial, err := time.Parse(sqliteDateLayout, trialEnd.String)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trial_end_date %q: %w", trialEnd.String, err)
+		}
+		sub.TrialEndDate = &trial
+	}
+	if introPrice.Valid {
+		price := int(introPrice.Int64)
+		sub.IntroPrice = &price
+	}
+	if sub.CreatedAt, err = time.Parse(sqliteTimestampLayout, createdAt); err != nil {
+		return nil, fmt.Errorf("invalid created_at %q: %w", createdAt, err)
+	}
+	if sub.UpdatedAt, err = time.Parse(sqliteTimestampLayout, updatedAt); err != nil {
+		return nil, fmt.Errorf("invalid updated_at %q: %w", updatedAt, err)
+	}
+	if err := json.Unmarshal([]byte(tags), &sub.Tags); err != nil {
+		return nil, fmt.Errorf("invalid tags %q: %w", tags, err)
+	}
+
+	return &sub, nil
+}
+
+func (r *SQLiteSubscriptionRepo) Create(ctx context.Context, sub *models.Subscription) (uuid.UUID, error) {
+	query, args, err := r.builder.
+		Insert("subscriptions").
+		Columns(subscriptionColumns...).
+		Values(
+			sub.ID.String(), sub.ServiceName, sqliteNullUUID(sub.ServiceID), sqliteNullUUID(sub.PlanID),
+			sub.Price, billingPeriodOrDefault(sub.BillingPeriod), sub.Category, sub.UserID.String(),
+			sqliteDate(sub.StartDate), sqliteNullDate(sub.EndDate),
+			sqliteNullDate(sub.TrialEndDate), sub.IntroPrice, sub.IntroMonths,
+			sqliteTimestamp(sub.CreatedAt), sqliteTimestamp(sub.UpdatedAt),
+		).
+		ToSql()
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	err = r.db.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert subscription: %w", err)
+		}
+		return r.setTags(ctx, sub.ID, sub.Tags)
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return sub.ID, nil
+}
+
+func (r *SQLiteSubscriptionRepo) CreateBatch(ctx context.Context, subs []*models.Subscription) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		for chunk := range slices.Chunk(subs, createBatchSize) {
+			qb := r.builder.
+				Insert("subscriptions").
+				Columns(subscriptionColumns...)
+			for _, sub := range chunk {
+				qb = qb.Values(
+					sub.ID.String(), sub.ServiceName, sqliteNullUUID(sub.ServiceID), sqliteNullUUID(sub.PlanID),
+					sub.Price, billingPeriodOrDefault(sub.BillingPeriod), sub.Category, sub.UserID.String(),
+					sqliteDate(sub.StartDate), sqliteNullDate(sub.EndDate),
+					sqliteNullDate(sub.TrialEndDate), sub.IntroPrice, sub.IntroMonths,
+					sqliteTimestamp(sub.CreatedAt), sqliteTimestamp(sub.UpdatedAt),
+				)
+			}
+
+			query, args, err := qb.ToSql()
+			if err != nil {
+				return fmt.Errorf("failed to build insert query: %w", err)
+			}
+
+			if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+				return fmt.Errorf("failed to insert subscriptions: %w", err)
+			}
+
+			for _, sub := range chunk {
+				if err := r.setTags(ctx, sub.ID, sub.Tags); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (r *SQLiteSubscriptionRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
+	query, args, err := r.builder.
+		Select(sqliteSubscriptionSelect...).
+		From("subscriptions").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	sub, err := scanSQLiteSubscription(r.db.Conn(ctx).QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+// GetByIdForUpdate не нуждается в блокировке строки: транзакции SQLite
+// начинаются с BEGIN IMMEDIATE и сразу захватывают базу на запись.
+func (r *SQLiteSubscriptionRepo) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
+	return r.GetById(ctx, id)
+}
+
+func (r *SQLiteSubscriptionRepo) Update(ctx context.Context, sub *models.Subscription) error {
+	query, args, err := r.builder.
+		Update("subscriptions").
+		Set("service_name", sub.ServiceName).
+		Set("service_id", sqliteNullUUID(sub.ServiceID)).
+		Set("plan_id", sqliteNullUUID(sub.PlanID)).
+		Set("price", sub.Price).
+		Set("billing_period", billingPeriodOrDefault(sub.BillingPeriod)).
+		Set("category", sub.Category).
+		Set("start_date", sqliteDate(sub.StartDate)).
+		Set("end_date", sqliteNullDate(sub.EndDate)).
+		Set("trial_end_date", sqliteNullDate(sub.TrialEndDate)).
+		Set("intro_price", sub.IntroPrice).
+		Set("intro_months", sub.IntroMonths).
+		Set("updated_at", sqliteTimestamp(sub.UpdatedAt)).
+		Where(squirrel.Eq{"id": sub.ID.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to update subscription: %w", err)
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return fmt.Errorf("failed to update subscription: %w", err)
+		} else if n == 0 {
+			return fmt.Errorf("subscription not found")
+		}
+
+		return r.setTags(ctx, sub.ID, sub.Tags)
+	})
+}
+
+func (r *SQLiteSubscriptionRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("subscriptions").
+		Where(squirrel.Eq{"id": id.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("subscription not found")
+	}
+
+	return nil
+}
+
+func (r *SQLiteSubscriptionRepo) List(ctx context.Context, filter models.SubscriptionFilter) ([]*models.Subscription, error) {
+	qb := r.builder.
+		Select(sqliteSubscriptionSelect...).
+		From("subscriptions")
+
+	if filter.UserID != uuid.Nil {
+		qb = qb.Where(squirrel.Or{
+			squirrel.Eq{"user_id": filter.UserID.String()},
+			squirrel.Expr("EXISTS (SELECT 1 FROM subscription_members m WHERE m.subscription_id = subscriptions.id AND m.user_id = ?)", filter.UserID.String()),
+		})
+	}
+
+	if filter.Category != "" {
+		qb = qb.Where(squirrel.Eq{"category": filter.Category})
+	}
+
+	if filter.Tag != "" {
+		qb = qb.Where(squirrel.Expr(
+			"EXISTS (SELECT 1 FROM subscription_tags st JOIN tags t ON t.id = st.tag_id WHERE st.subscription_id = subscriptions.id AND t.name = ?)",
+			filter.Tag,
+		))
+	}
+
+	if filter.Status != "" {
+		cond, err := statusCondition(filter.Status, sqliteDate(filter.AsOf), sqliteDate(filter.AsOf.AddDate(0, models.EndingSoonMonths, 0)))
+		if err != nil {
+			return nil, err
+		}
+		qb = qb.Where(cond)
+	}
+
+	query, args, err := qb.OrderBy("created_at DESC").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	return collectSQLiteSubscriptions(rows)
+}
+
+// collectSQLiteSubscriptions читает строки sqliteSubscriptionSelect и закрывает rows.
+func collectSQLiteSubscriptions(rows *sql.Rows) ([]*models.Subscription, error) {
+	defer rows.Close()
+
+	subs := make([]*models.Subscription, 0)
+	for rows.Next() {
+		sub, err := scanSQLiteSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	return subs, nil
+}
+
+// sqlitePlanPrices — цены тарифов: базовая (effective_from IS NULL) и изменения.
+const sqlitePlanPrices = `(
+	SELECT id AS plan_id, NULL AS effective_from, price FROM plans
+	UNION ALL
+	SELECT plan_id, effective_from, price FROM plan_price_changes
+) pp ON pp.plan_id = s.plan_id`
+
+// sqliteRegularPrice — то же, что pgRegularPrice: деление целых в SQLite
+// тоже отбрасывает остаток.
+const sqliteRegularPrice = "CASE WHEN s.billing_period = '" + models.BillingYearly + "' THEN COALESCE(pp.price, s.price) / 12 ELSE COALESCE(pp.price, s.price) END"
+
+// sqlitePaidStart, sqliteRegularStart и sqliteIntroEnd — то же, что
+// pgPaidStart, pgRegularStart и pgIntroEnd.
+const (
+	sqlitePaidStart    = "COALESCE(date(s.trial_end_date, '+1 month'), s.start_date)"
+	sqliteRegularStart = "date(COALESCE(date(s.trial_end_date, '+1 month'), s.start_date), '+' || s.intro_months || ' months')"
+	sqliteIntroEnd     = "date(COALESCE(date(s.trial_end_date, '+1 month'), s.start_date), '+' || (s.intro_months - 1) || ' months')"
+)
+
+// sqliteSegments повторяет pgSegments.
+func sqliteSegments(userID uuid.UUID, serviceName string, start time.Time, end *time.Time) squirrel.SelectBuilder {
+	segments := sqliteSegmentsScope(squirrel.
+		Select(
+			"s.id", sqliteRegularStart+" AS start_date", "s.end_date", sqliteRegularPrice+" AS price", "pp.effective_from",
+			"LEAD(pp.effective_from) OVER (PARTITION BY s.id ORDER BY pp.effective_from NULLS FIRST) AS next_from",
+		).
+		From("subscriptions s").
+		LeftJoin(sqlitePlanPrices).
+		Where(squirrel.Or{
+			squirrel.Expr("s.end_date IS NULL"),
+			squirrel.GtOrEq{"s.end_date": sqliteDate(start)},
+		}).
+		Where("(s.end_date IS NULL OR "+sqliteRegularStart+" <= s.end_date)"), userID, serviceName)
+
+	if end != nil {
+		segments = segments.Where(squirrel.LtOrEq{sqliteRegularStart: sqliteDate(*end)})
+	}
+
+	intro := sqliteSegmentsScope(squirrel.
+		Select(
+			"s.id", "s.intro_price AS price", sqlitePaidStart+" AS seg_from",
+			"CASE WHEN s.end_date IS NULL THEN "+sqliteIntroEnd+" ELSE min(s.end_date, "+sqliteIntroEnd+") END AS seg_to",
+		).
+		From("subscriptions s").
+		Where("s.intro_price IS NOT NULL").
+		Where(squirrel.Or{
+			squirrel.Expr("s.end_date IS NULL"),
+			squirrel.GtOrEq{"s.end_date": sqliteDate(start)},
+		}).
+		Where("(s.end_date IS NULL OR "+sqlitePaidStart+" <= s.end_date)").
+		Where(squirrel.Gt{sqliteRegularStart: sqliteDate(start)}), userID, serviceName)
+
+	if end != nil {
+		intro = intro.Where(squirrel.LtOrEq{sqlitePaidStart: sqliteDate(*end)})
+	}
+
+	regular := squirrel.
+		Select(
+			"seg.id", "seg.price", "max(seg.start_date, COALESCE(seg.effective_from, seg.start_date)) AS seg_from",
+			`CASE WHEN seg.next_from IS NULL THEN seg.end_date
+				WHEN seg.end_date IS NULL THEN date(seg.next_from, '-1 month')
+				ELSE min(seg.end_date, date(seg.next_from, '-1 month')) END AS seg_to`,
+		).
+		FromSelect(segments, "seg").
+		Where("(seg.next_from IS NULL OR seg.next_from > seg.start_date)").
+		Where("(seg.effective_from IS NULL OR seg.end_date IS NULL OR seg.effective_from <= seg.end_date)").
+		Where(squirrel.Or{
+			squirrel.Expr("seg.next_from IS NULL"),
+			squirrel.Gt{"seg.next_from": sqliteDate(start)},
+		})
+
+	if end != nil {
+		regular = regular.Where(squirrel.Or{
+			squirrel.Expr("seg.effective_from IS NULL"),
+			squirrel.LtOrEq{"seg.effective_from": sqliteDate(*end)},
+		})
+	}
+
+	notPaused := squirrel.Expr(`NOT EXISTS (SELECT 1 FROM subscription_pauses p WHERE p.subscription_id = seg.id
+		AND p.start_date <= max(seg.seg_from, ?) AND (p.end_date IS NULL OR p.end_date >= seg.seg_to))`, sqliteDate(start))
+	if end != nil {
+		notPaused = squirrel.Expr(`NOT EXISTS (SELECT 1 FROM subscription_pauses p WHERE p.subscription_id = seg.id
+		AND p.start_date <= max(seg.seg_from, ?) AND (p.end_date IS NULL OR p.end_date >= min(COALESCE(seg.seg_to, ?), ?)))`,
+			sqliteDate(start), sqliteDate(*end), sqliteDate(*end))
+	}
+
+	return squirrel.
+		Select("seg.id", "seg.price").
+		FromSelect(regular.Suffix("UNION ALL").SuffixExpr(intro), "seg").
+		Where(notPaused)
+}
+
+// sqliteSegmentsScope повторяет pgSegmentsScope.
+func sqliteSegmentsScope(qb squirrel.SelectBuilder, userID uuid.UUID, serviceName string) squirrel.SelectBuilder {
+	if userID != uuid.Nil {
+		qb = qb.Where(squirrel.Or{
+			squirrel.And{
+				squirrel.Eq{"s.user_id": userID.String()},
+				squirrel.Expr("NOT EXISTS (SELECT 1 FROM subscription_members m WHERE m.subscription_id = s.id)"),
+			},
+			squirrel.Expr("EXISTS (SELECT 1 FROM subscription_members m WHERE m.subscription_id = s.id AND m.user_id = ?)", userID.String()),
+		})
+	}
+
+	if serviceName != "" {
+		qb = qb.Where(squirrel.Eq{"s.service_name": serviceName})
+	}
+
+	return qb
+}
+
+// sqliteMemberShares — накопленные веса участников, как pgMemberShares.
+const sqliteMemberShares = `(
+	SELECT subscription_id, user_id, weight,
+		SUM(weight) OVER (PARTITION BY subscription_id ORDER BY user_id) AS upto,
+		SUM(weight) OVER (PARTITION BY subscription_id) AS total
+	FROM subscription_members
+	WHERE subscription_id IN (SELECT subscription_id FROM subscription_members WHERE user_id = ?)
+) sh ON sh.subscription_id = tot.id AND sh.user_id = ?`
+
+// sqlitePriced — то же, что pgPriced.
+func sqlitePriced(userID uuid.UUID, serviceName string, start time.Time, end *time.Time) squirrel.SelectBuilder {
+	segments := sqliteSegments(userID, serviceName, start, end)
+	if userID == uuid.Nil {
+		return segments
+	}
+
+	totals := squirrel.
+		Select("seg.id", "SUM(seg.price) AS price").
+		FromSelect(segments, "seg").
+		GroupBy("seg.id")
+
+	return squirrel.
+		Select("tot.id", "COALESCE(tot.price * sh.upto / sh.total - tot.price * (sh.upto - sh.weight) / sh.total, tot.price) AS price").
+		FromSelect(totals, "tot").
+		LeftJoin(sqliteMemberShares, userID.String(), userID.String())
+}
+
+func (r *SQLiteSubscriptionRepo) SumForPeriod(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) (int, error) {
+	query, args, err := r.builder.
+		Select("COALESCE(SUM(priced.price), 0) AS total").
+		FromSelect(sqlitePriced(userID, serviceName, start, end), "priced").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build sum query: %w", err)
+	}
+
+	var total int
+	err = r.db.Conn(ctx).QueryRowContext(ctx, query, args...).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sum: %w", err)
+	}
+
+	return total, nil
+}
+
+func (r *SQLiteSubscriptionRepo) SumForPeriodByTag(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) ([]models.TagTotal, error) {
+	query, args, err := r.builder.
+		Select("COALESCE(t.name, '') AS tag", "SUM(priced.price)").
+		FromSelect(sqlitePriced(userID, serviceName, start, end), "priced").
+		LeftJoin("subscription_tags st ON st.subscription_id = priced.id").
+		LeftJoin("tags t ON t.id = st.tag_id").
+		GroupBy("t.name").
+		OrderBy("tag").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sum query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sum: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make([]models.TagTotal, 0)
+	for rows.Next() {
+		var t models.TagTotal
+		if err := rows.Scan(&t.Tag, &t.Total); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get sum: %w", err)
+	}
+
+	return totals, nil
+}
+
+// SumForPeriodBySubscription считает суммы как SumForPeriod, но отдельно для
+// каждой подписки.
+func (r *SQLiteSubscriptionRepo) SumForPeriodBySubscription(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) ([]models.SubscriptionTotal, error) {
+	query, args, err := r.builder.
+		Select("priced.id", "SUM(priced.price)").
+		FromSelect(sqlitePriced(userID, serviceName, start, end), "priced").
+		GroupBy("priced.id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sum query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sum: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make([]models.SubscriptionTotal, 0)
+	for rows.Next() {
+		var t models.SubscriptionTotal
+		if err := rows.Scan(&t.SubscriptionID, &t.Total); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get sum: %w", err)
+	}
+
+	return totals, nil
+}
+
+func (r *SQLiteSubscriptionRepo) UnlinkedServiceNames(ctx context.Context) ([]models.ServiceNameCount, error) {
+	query, args, err := r.builder.
+		Select("service_name", "COUNT(*)").
+		From("subscriptions").
+		Where(squirrel.Eq{"service_id": nil}).
+		GroupBy("service_name").
+		OrderBy("COUNT(*) DESC", "service_name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build service names query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service names: %w", err)
+	}
+	defer rows.Close()
+
+	names := make([]models.ServiceNameCount, 0)
+	for rows.Next() {
+		var n models.ServiceNameCount
+		if err := rows.Scan(&n.Name, &n.Count); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		names = append(names, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get service names: %w", err)
+	}
+
+	return names, nil
+}
+
+func (r *SQLiteSubscriptionRepo) LinkService(ctx context.Context, name string, svc *models.Service) (int, error) {
+	query, args, err := r.builder.
+		Update("subscriptions").
+		Set("service_id", svc.ID.String()).
+		Set("service_name", svc.Name).
+		Where(squirrel.Eq{"service_name": name, "service_id": nil}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build link query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to link subscriptions to service: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to link subscriptions to service: %w", err)
+	}
+
+	return int(n), nil
+}
+
+func (r *SQLiteSubscriptionRepo) RenameService(ctx context.Context, svc *models.Service) (int, error) {
+	query, args, err := r.builder.
+		Update("subscriptions").
+		Set("service_name", svc.Name).
+		Where(squirrel.And{
+			squirrel.Eq{"service_id": svc.ID.String()},
+			squirrel.NotEq{"service_name": svc.Name},
+		}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build rename query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename service in subscriptions: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename service in subscriptions: %w", err)
+	}
+
+	return int(n), nil
+}
@@ -0,0 +1,81 @@
+package adapter_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/adapter"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/google/uuid"
+)
+
+func openSQLite(t *testing.T) *sqlite.Database {
+	t.Helper()
+	db, err := sqlite.New(sqlite.SQLiteConfig{Path: filepath.Join(t.TempDir(), "subscriptions.db")})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSQLiteSubscriptionRepo(t *testing.T) {
+	runSubscriptionRepoContract(t, func(t *testing.T) usecase.SubscriptionRepo {
+		return adapter.NewSQLiteSubscriptionRepo(openSQLite(t))
+	})
+}
+
+func TestSQLiteTransactor(t *testing.T) {
+	runTransactorContract(t, func(t *testing.T) (usecase.Transactor, usecase.SubscriptionRepo) {
+		db := openSQLite(t)
+		return db, adapter.NewSQLiteSubscriptionRepo(db)
+	})
+}
+
+func TestSQLiteWithinTxRollsBack(t *testing.T) {
+	db := openSQLite(t)
+	repo := adapter.NewSQLiteSubscriptionRepo(db)
+	sub := newSubscription(uuid.New(), "Spotify", 200, month(2025, 1), nil)
+
+	errAbort := errors.New("abort")
+	err := db.WithinTx(context.Background(), func(ctx context.Context) error {
+		if _, err := repo.Create(ctx, sub); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("WithinTx returned %v, want %v", err, errAbort)
+	}
+
+	got, err := repo.GetById(context.Background(), sub.ID)
+	if err != nil {
+		t.Fatalf("GetById: %v", err)
+	}
+	if got != nil {
+		t.Fatal("subscription created inside a rolled back transaction is visible")
+	}
+}
+
+func TestSQLiteServiceRepo(t *testing.T) {
+	runServiceRepoContract(t, func(t *testing.T) (usecase.ServiceRepo, usecase.PlanRepo, usecase.SubscriptionRepo) {
+		db := openSQLite(t)
+		return adapter.NewSQLiteServiceRepo(db), adapter.NewSQLitePlanRepo(db), adapter.NewSQLiteSubscriptionRepo(db)
+	})
+}
+
+func TestSQLiteCostCenterRepo(t *testing.T) {
+	runCostCenterRepoContract(t, func(t *testing.T) (usecase.CostCenterRepo, usecase.SubscriptionRepo) {
+		db := openSQLite(t)
+		return adapter.NewSQLiteCostCenterRepo(db), adapter.NewSQLiteSubscriptionRepo(db)
+	})
+}
+
+func TestSQLiteBudgetRepo(t *testing.T) {
+	runBudgetRepoContract(t, func(t *testing.T) usecase.BudgetRepo {
+		return adapter.NewSQLiteBudgetRepo(openSQLite(t))
+	})
+}
@@ -0,0 +1,213 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// setTags заменяет теги подписки; вызывать внутри WithinTx.
+func (r *SQLiteSubscriptionRepo) setTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	query, args, err := r.builder.
+		Delete("subscription_tags").
+		Where(squirrel.Eq{"subscription_id": id.String()}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete tags query: %w", err)
+	}
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete subscription tags: %w", err)
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if err := r.ensureTags(ctx, tags...); err != nil {
+		return err
+	}
+
+	query, args, err = r.builder.
+		Insert("subscription_tags").
+		Columns("subscription_id", "tag_id").
+		Select(squirrel.
+			Select().
+			Column(squirrel.Expr("?", id.String())).
+			Column("id").
+			From("tags").
+			Where(squirrel.Eq{"name": tags})).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert tags query: %w", err)
+	}
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert subscription tags: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteSubscriptionRepo) ensureTags(ctx context.Context, tags ...string) error {
+	qb := r.builder.
+		Insert("tags").
+		Columns("name").
+		Suffix("ON CONFLICT (name) DO NOTHING")
+	for _, tag := range tags {
+		qb = qb.Values(tag)
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert tags query: %w", err)
+	}
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert tags: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteSubscriptionRepo) ListTags(ctx context.Context) ([]models.LabelCount, error) {
+	return r.labelCounts(ctx, r.builder.
+		Select("t.name", "COUNT(*)").
+		From("tags t").
+		Join("subscription_tags st ON st.tag_id = t.id").
+		GroupBy("t.name").
+		OrderBy("t.name"))
+}
+
+func (r *SQLiteSubscriptionRepo) RenameTag(ctx context.Context, from, to string) (int, error) {
+	var renamed int
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.ensureTags(ctx, to); err != nil {
+			return err
+		}
+
+		query, args, err := r.builder.
+			Insert("subscription_tags").
+			Columns("subscription_id", "tag_id").
+			Select(squirrel.
+				Select("st.subscription_id").
+				Column(squirrel.Expr("(SELECT id FROM tags WHERE name = ?)", to)).
+				From("subscription_tags st").
+				Join("tags t ON t.id = st.tag_id").
+				Where(squirrel.Eq{"t.name": from})).
+			Suffix("ON CONFLICT DO NOTHING").
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build rename tag query: %w", err)
+		}
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to rename tag: %w", err)
+		}
+
+		renamed, err = r.DeleteTag(ctx, from)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return renamed, nil
+}
+
+func (r *SQLiteSubscriptionRepo) DeleteTag(ctx context.Context, name string) (int, error) {
+	var deleted int
+	err := r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Delete("subscription_tags").
+			Where(squirrel.Expr("tag_id IN (?)", squirrel.Select("id").From("tags").Where(squirrel.Eq{"name": name}))).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build delete tag query: %w", err)
+		}
+		res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to delete tag: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to delete tag: %w", err)
+		}
+		deleted = int(n)
+
+		query, args, err = r.builder.
+			Delete("tags").
+			Where(squirrel.Eq{"name": name}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build delete tag query: %w", err)
+		}
+		if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete tag: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+func (r *SQLiteSubscriptionRepo) ListCategories(ctx context.Context) ([]models.LabelCount, error) {
+	return r.labelCounts(ctx, r.builder.
+		Select("category", "COUNT(*)").
+		From("subscriptions").
+		Where(squirrel.NotEq{"category": ""}).
+		GroupBy("category").
+		OrderBy("category"))
+}
+
+func (r *SQLiteSubscriptionRepo) RenameCategory(ctx context.Context, from, to string) (int, error) {
+	query, args, err := r.builder.
+		Update("subscriptions").
+		Set("category", to).
+		Where(squirrel.Eq{"category": from}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build rename category query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename category: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename category: %w", err)
+	}
+
+	return int(n), nil
+}
+
+func (r *SQLiteSubscriptionRepo) labelCounts(ctx context.Context, qb squirrel.SelectBuilder) ([]models.LabelCount, error) {
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build labels query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	defer rows.Close()
+
+	labels := make([]models.LabelCount, 0)
+	for rows.Next() {
+		var l models.LabelCount
+		if err := rows.Scan(&l.Name, &l.Count); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		labels = append(labels, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+
+	return labels, nil
+}
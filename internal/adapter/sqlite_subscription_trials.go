@@ -0,0 +1,72 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+func (r *SQLiteSubscriptionRepo) ListTrialsEnding(ctx context.Context, month time.Time) ([]*models.Subscription, error) {
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	query, args, err := r.builder.
+		Select(sqliteSubscriptionSelect...).
+		From("subscriptions").
+		Where(squirrel.GtOrEq{"trial_end_date": sqliteDate(month)}).
+		Where(squirrel.Lt{"trial_end_date": sqliteDate(month.AddDate(0, 1, 0))}).
+		Where("(end_date IS NULL OR end_date > trial_end_date)").
+		OrderBy("trial_end_date", "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trials query: %w", err)
+	}
+
+	rows, err := r.db.Conn(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	return collectSQLiteSubscriptions(rows)
+}
+
+func (r *SQLiteSubscriptionRepo) ClaimTrialReminder(ctx context.Context, subscriptionID uuid.UUID, paidStart time.Time) (bool, error) {
+	query, args, err := r.builder.
+		Insert("trial_reminders").
+		Columns("subscription_id", "paid_start", "created_at").
+		Values(subscriptionID.String(), sqliteDate(paidStart), sqliteTimestamp(time.Now())).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build claim reminder query: %w", err)
+	}
+
+	res, err := r.db.Conn(ctx).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim trial reminder: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim trial reminder: %w", err)
+	}
+
+	return n > 0, nil
+}
+
+func (r *SQLiteSubscriptionRepo) ReleaseTrialReminder(ctx context.Context, subscriptionID uuid.UUID, paidStart time.Time) error {
+	query, args, err := r.builder.
+		Delete("trial_reminders").
+		Where(squirrel.Eq{"subscription_id": subscriptionID.String(), "paid_start": sqliteDate(paidStart)}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build release reminder query: %w", err)
+	}
+
+	if _, err := r.db.Conn(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to release trial reminder: %w", err)
+	}
+
+	return nil
+}
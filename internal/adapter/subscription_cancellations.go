@@ -0,0 +1,134 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var cancellationColumns = []string{"subscription_id", "effective_date", "previous_end_date", "reason", "comment", "cancelled_at"}
+
+func (r *SubscriptionRepo) CreateCancellation(ctx context.Context, c models.Cancellation) error {
+	query, args, err := r.builder.
+		Insert("subscription_cancellations").
+		Columns(cancellationColumns...).
+		Values(c.SubscriptionID, c.EffectiveDate, c.PreviousEndDate, c.Reason, c.Comment, c.CancelledAt).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert cancellation query: %w", err)
+	}
+
+	_, err = r.db.Writer(ctx).Exec(ctx, query, args...)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+		return fmt.Errorf("subscription not found")
+	}
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return models.ErrAlreadyCancelled
+	}
+	if err != nil {
+		return fmt.Errorf("failed to insert subscription cancellation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SubscriptionRepo) DeleteCancellation(ctx context.Context, subscriptionID uuid.UUID) error {
+	query, args, err := r.builder.
+		Delete("subscription_cancellations").
+		Where(squirrel.Eq{"subscription_id": subscriptionID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete cancellation query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete subscription cancellation: %w", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return models.ErrNotCancelled
+	}
+
+	return nil
+}
+
+func (r *SubscriptionRepo) ListCancellations(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID]models.Cancellation, error) {
+	cancellations := map[uuid.UUID]models.Cancellation{}
+	if len(subscriptionIDs) == 0 {
+		return cancellations, nil
+	}
+
+	query, args, err := r.builder.
+		Select(cancellationColumns...).
+		From("subscription_cancellations").
+		Where(squirrel.Eq{"subscription_id": subscriptionIDs}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cancellations query: %w", err)
+	}
+
+	rows, err := r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription cancellations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var c models.Cancellation
+		if err := rows.Scan(&c.SubscriptionID, &c.EffectiveDate, &c.PreviousEndDate, &c.Reason, &c.Comment, &c.CancelledAt); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		cancellations[c.SubscriptionID] = c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get subscription cancellations: %w", err)
+	}
+
+	return cancellations, nil
+}
+
+func (r *SubscriptionRepo) ChurnReport(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]models.ChurnTotal, error) {
+	qb := r.builder.
+		Select("c.reason", "s.service_name", "COUNT(*)").
+		From("subscription_cancellations c").
+		Join("subscriptions s ON s.id = c.subscription_id").
+		Where(squirrel.GtOrEq{"c.effective_date": start}).
+		Where(squirrel.LtOrEq{"c.effective_date": end}).
+		GroupBy("c.reason", "s.service_name").
+		OrderBy("COUNT(*) DESC", "c.reason", "s.service_name")
+	if userID != uuid.Nil {
+		qb = qb.Where(squirrel.Eq{"s.user_id": userID})
+	}
+
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build churn query: %w", err)
+	}
+
+	rows, err := r.db.Reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get churn: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make([]models.ChurnTotal, 0)
+	for rows.Next() {
+		var t models.ChurnTotal
+		if err := rows.Scan(&t.Reason, &t.ServiceName, &t.Count); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get churn: %w", err)
+	}
+
+	return totals, nil
+}
@@ -0,0 +1,87 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SetMembers заменяет участников подписки; пустой список снимает разделение.
+func (r *SubscriptionRepo) SetMembers(ctx context.Context, subscriptionID uuid.UUID, members []models.SubscriptionMember) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Delete("subscription_members").
+			Where(squirrel.Eq{"subscription_id": subscriptionID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build delete members query: %w", err)
+		}
+		if _, err := r.db.Writer(ctx).Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete subscription members: %w", err)
+		}
+
+		if len(members) == 0 {
+			return nil
+		}
+
+		qb := r.builder.
+			Insert("subscription_members").
+			Columns("subscription_id", "user_id", "weight")
+		for _, m := range members {
+			qb = qb.Values(subscriptionID, m.UserID, m.Weight)
+		}
+		query, args, err = qb.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert members query: %w", err)
+		}
+
+		_, err = r.db.Writer(ctx).Exec(ctx, query, args...)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return fmt.Errorf("subscription not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert subscription members: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ListMembers возвращает участников подписки в порядке user_id.
+func (r *SubscriptionRepo) ListMembers(ctx context.Context, subscriptionID uuid.UUID) ([]models.SubscriptionMember, error) {
+	query, args, err := r.builder.
+		Select("user_id", "weight").
+		From("subscription_members").
+		Where(squirrel.Eq{"subscription_id": subscriptionID}).
+		OrderBy("user_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build members query: %w", err)
+	}
+
+	rows, err := r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]models.SubscriptionMember, 0)
+	for rows.Next() {
+		var m models.SubscriptionMember
+		if err := rows.Scan(&m.UserID, &m.Weight); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		members = append(members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get subscription members: %w", err)
+	}
+
+	return members, nil
+}
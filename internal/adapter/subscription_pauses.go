@@ -0,0 +1,94 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SetPauses заменяет паузы подписки.
+func (r *SubscriptionRepo) SetPauses(ctx context.Context, subscriptionID uuid.UUID, pauses []models.SubscriptionPause) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		query, args, err := r.builder.
+			Delete("subscription_pauses").
+			Where(squirrel.Eq{"subscription_id": subscriptionID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build delete pauses query: %w", err)
+		}
+		if _, err := r.db.Writer(ctx).Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to delete subscription pauses: %w", err)
+		}
+
+		if len(pauses) == 0 {
+			return nil
+		}
+
+		qb := r.builder.
+			Insert("subscription_pauses").
+			Columns("subscription_id", "start_date", "end_date")
+		for _, p := range pauses {
+			qb = qb.Values(subscriptionID, p.StartDate, p.EndDate)
+		}
+		query, args, err = qb.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert pauses query: %w", err)
+		}
+
+		_, err = r.db.Writer(ctx).Exec(ctx, query, args...)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgForeignKeyViolation {
+			return fmt.Errorf("subscription not found")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert subscription pauses: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ListPauses возвращает паузы подписок subscriptionIDs в порядке start_date.
+func (r *SubscriptionRepo) ListPauses(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID][]models.SubscriptionPause, error) {
+	pauses := map[uuid.UUID][]models.SubscriptionPause{}
+	if len(subscriptionIDs) == 0 {
+		return pauses, nil
+	}
+
+	query, args, err := r.builder.
+		Select("subscription_id", "start_date", "end_date").
+		From("subscription_pauses").
+		Where(squirrel.Eq{"subscription_id": subscriptionIDs}).
+		OrderBy("subscription_id", "start_date").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pauses query: %w", err)
+	}
+
+	rows, err := r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription pauses: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			subscriptionID uuid.UUID
+			p              models.SubscriptionPause
+		)
+		if err := rows.Scan(&subscriptionID, &p.StartDate, &p.EndDate); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		pauses[subscriptionID] = append(pauses[subscriptionID], p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get subscription pauses: %w", err)
+	}
+
+	return pauses, nil
+}
@@ -4,21 +4,35 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
 	"github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var subscriptionColumns = []string{
+	"id", "service_name", "service_id", "plan_id", "price", "billing_period", "category", "user_id", "start_date", "end_date",
+	"trial_end_date", "intro_price", "intro_months", "created_at", "updated_at",
+}
+
+// pgSubscriptionSelect — колонки подписки и её теги по алфавиту.
+var pgSubscriptionSelect = append(slices.Clone(subscriptionColumns),
+	`COALESCE((SELECT array_agg(t.name ORDER BY t.name) FROM subscription_tags st
+		JOIN tags t ON t.id = st.tag_id WHERE st.subscription_id = subscriptions.id), '{}') AS tags`,
+)
+
+// SubscriptionRepo пишет в primary, а List и SumForPeriod читает с реплик,
+// если они настроены.
 type SubscriptionRepo struct {
-	db      *pgxpool.Pool
+	db      *postgres.Database
 	builder squirrel.StatementBuilderType
 }
 
-func NewSubscriptionRepo(db *pgxpool.Pool) *SubscriptionRepo {
+func NewSubscriptionRepo(db *postgres.Database) *SubscriptionRepo {
 	return &SubscriptionRepo{
 		db:      db,
 		builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
@@ -28,8 +42,8 @@ func NewSubscriptionRepo(db *pgxpool.Pool) *SubscriptionRepo {
 func (r *SubscriptionRepo) Create(ctx context.Context, sub *models.Subscription) (uuid.UUID, error) {
 	query, args, err := r.builder.
 		Insert("subscriptions").
-		Columns("id", "service_name", "price", "user_id", "start_date", "end_date", "created_at", "updated_at").
-		Values(sub.ID, sub.ServiceName, sub.Price, sub.UserID, sub.StartDate, sub.EndDate, sub.CreatedAt, sub.UpdatedAt).
+		Columns(subscriptionColumns...).
+		Values(sub.ID, sub.ServiceName, sub.ServiceID, sub.PlanID, sub.Price, billingPeriodOrDefault(sub.BillingPeriod), sub.Category, sub.UserID, sub.StartDate, sub.EndDate, sub.TrialEndDate, sub.IntroPrice, sub.IntroMonths, sub.CreatedAt, sub.UpdatedAt).
 		Suffix("RETURNING id").
 		ToSql()
 	if err != nil {
@@ -37,29 +51,81 @@ func (r *SubscriptionRepo) Create(ctx context.Context, sub *models.Subscription)
 	}
 
 	var id uuid.UUID
-	err = r.db.QueryRow(ctx, query, args...).Scan(&id)
+	err = r.db.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.db.Writer(ctx).QueryRow(ctx, query, args...).Scan(&id); err != nil {
+			return fmt.Errorf("failed to insert subscription: %w", err)
+		}
+		return r.setTags(ctx, id, sub.Tags)
+	})
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("failed to insert subscription: %w", err)
+		return uuid.Nil, err
 	}
 
 	return id, nil
 }
 
+// createBatchSize ограничивает число строк в одном INSERT: у Postgres не
+// больше 65535 параметров на запрос.
+const createBatchSize = 1000
+
+func (r *SubscriptionRepo) CreateBatch(ctx context.Context, subs []*models.Subscription) error {
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		for chunk := range slices.Chunk(subs, createBatchSize) {
+			qb := r.builder.
+				Insert("subscriptions").
+				Columns(subscriptionColumns...)
+			for _, sub := range chunk {
+				qb = qb.Values(sub.ID, sub.ServiceName, sub.ServiceID, sub.PlanID, sub.Price, billingPeriodOrDefault(sub.BillingPeriod), sub.Category, sub.UserID, sub.StartDate, sub.EndDate, sub.TrialEndDate, sub.IntroPrice, sub.IntroMonths, sub.CreatedAt, sub.UpdatedAt)
+			}
+
+			query, args, err := qb.ToSql()
+			if err != nil {
+				return fmt.Errorf("failed to build insert query: %w", err)
+			}
+
+			if _, err := r.db.Writer(ctx).Exec(ctx, query, args...); err != nil {
+				return fmt.Errorf("failed to insert subscriptions: %w", err)
+			}
+
+			for _, sub := range chunk {
+				if err := r.setTags(ctx, sub.ID, sub.Tags); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
 func (r *SubscriptionRepo) GetById(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
-	query, args, err := r.builder.
-		Select("id", "service_name", "price", "user_id", "start_date", "end_date", "created_at", "updated_at").
+	return r.getById(ctx, r.db.Primary(ctx), id, false)
+}
+
+// GetByIdForUpdate блокирует строку до конца транзакции; вызывать внутри Transactor.WithinTx.
+func (r *SubscriptionRepo) GetByIdForUpdate(ctx context.Context, id uuid.UUID) (*models.Subscription, error) {
+	return r.getById(ctx, r.db.Writer(ctx), id, true)
+}
+
+func (r *SubscriptionRepo) getById(ctx context.Context, q postgres.Querier, id uuid.UUID, forUpdate bool) (*models.Subscription, error) {
+	qb := r.builder.
+		Select(pgSubscriptionSelect...).
 		From("subscriptions").
-		Where(squirrel.Eq{"id": id}).
-		ToSql()
+		Where(squirrel.Eq{"id": id})
+
+	if forUpdate {
+		qb = qb.Suffix("FOR UPDATE")
+	}
+
+	query, args, err := qb.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build select query: %w", err)
 	}
 
 	var sub models.Subscription
-	err = r.db.QueryRow(ctx, query, args...).Scan(
-		&sub.ID, &sub.ServiceName, &sub.Price,
-		&sub.UserID, &sub.StartDate, &sub.EndDate,
-		&sub.CreatedAt, &sub.UpdatedAt,
+	err = q.QueryRow(ctx, query, args...).Scan(
+		&sub.ID, &sub.ServiceName, &sub.ServiceID, &sub.PlanID, &sub.Price, &sub.BillingPeriod, &sub.Category,
+		&sub.UserID, &sub.StartDate, &sub.EndDate, &sub.TrialEndDate, &sub.IntroPrice, &sub.IntroMonths,
+		&sub.CreatedAt, &sub.UpdatedAt, &sub.Tags,
 	)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
@@ -75,25 +141,34 @@ func (r *SubscriptionRepo) Update(ctx context.Context, sub *models.Subscription)
 	query, args, err := r.builder.
 		Update("subscriptions").
 		Set("service_name", sub.ServiceName).
+		Set("service_id", sub.ServiceID).
+		Set("plan_id", sub.PlanID).
 		Set("price", sub.Price).
+		Set("billing_period", billingPeriodOrDefault(sub.BillingPeriod)).
+		Set("category", sub.Category).
 		Set("start_date", sub.StartDate).
 		Set("end_date", sub.EndDate).
-		Set("updated_at", "NOW()").
+		Set("trial_end_date", sub.TrialEndDate).
+		Set("intro_price", sub.IntroPrice).
+		Set("intro_months", sub.IntroMonths).
+		Set("updated_at", sub.UpdatedAt).
 		Where(squirrel.Eq{"id": sub.ID}).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("failed to build update query: %w", err)
 	}
 
-	cmd, err := r.db.Exec(ctx, query, args...)
-	if err != nil {
-		return fmt.Errorf("failed to update subscription: %w", err)
-	}
-	if cmd.RowsAffected() == 0 {
-		return fmt.Errorf("subscription not found")
-	}
+	return r.db.WithinTx(ctx, func(ctx context.Context) error {
+		cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to update subscription: %w", err)
+		}
+		if cmd.RowsAffected() == 0 {
+			return fmt.Errorf("subscription not found")
+		}
 
-	return nil
+		return r.setTags(ctx, sub.ID, sub.Tags)
+	})
 }
 
 func (r *SubscriptionRepo) Delete(ctx context.Context, id uuid.UUID) error {
@@ -105,7 +180,7 @@ func (r *SubscriptionRepo) Delete(ctx context.Context, id uuid.UUID) error {
 		return fmt.Errorf("failed to build delete query: %w", err)
 	}
 
-	cmd, err := r.db.Exec(ctx, query, args...)
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("failed to delete subscription: %w", err)
 	}
@@ -116,13 +191,35 @@ func (r *SubscriptionRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *SubscriptionRepo) List(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error) {
+func (r *SubscriptionRepo) List(ctx context.Context, filter models.SubscriptionFilter) ([]*models.Subscription, error) {
 	qb := r.builder.
-		Select("id", "service_name", "price", "user_id", "start_date", "end_date", "created_at", "updated_at").
+		Select(pgSubscriptionSelect...).
 		From("subscriptions")
 
-	if userID.String() != "" {
-		qb = qb.Where(squirrel.Eq{"user_id": userID})
+	if filter.UserID != uuid.Nil {
+		qb = qb.Where(squirrel.Or{
+			squirrel.Eq{"user_id": filter.UserID},
+			squirrel.Expr("EXISTS (SELECT 1 FROM subscription_members m WHERE m.subscription_id = subscriptions.id AND m.user_id = ?)", filter.UserID),
+		})
+	}
+
+	if filter.Category != "" {
+		qb = qb.Where(squirrel.Eq{"category": filter.Category})
+	}
+
+	if filter.Tag != "" {
+		qb = qb.Where(squirrel.Expr(
+			"EXISTS (SELECT 1 FROM subscription_tags st JOIN tags t ON t.id = st.tag_id WHERE st.subscription_id = subscriptions.id AND t.name = ?)",
+			filter.Tag,
+		))
+	}
+
+	if filter.Status != "" {
+		cond, err := statusCondition(filter.Status, filter.AsOf, filter.AsOf.AddDate(0, models.EndingSoonMonths, 0))
+		if err != nil {
+			return nil, err
+		}
+		qb = qb.Where(cond)
 	}
 
 	query, args, err := qb.OrderBy("created_at DESC").ToSql()
@@ -130,58 +227,341 @@ func (r *SubscriptionRepo) List(ctx context.Context, userID uuid.UUID) ([]*model
 		return nil, fmt.Errorf("failed to build list query: %w", err)
 	}
 
-	rows, err := r.db.Query(ctx, query, args...)
+	rows, err := r.db.Reader(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
 	}
+
+	return collectSubscriptions(rows)
+}
+
+// collectSubscriptions читает строки pgSubscriptionSelect и закрывает rows.
+func collectSubscriptions(rows pgx.Rows) ([]*models.Subscription, error) {
 	defer rows.Close()
 
 	subs := make([]*models.Subscription, 0)
 	for rows.Next() {
 		var s models.Subscription
 		if err := rows.Scan(
-			&s.ID, &s.ServiceName, &s.Price, &s.UserID,
-			&s.StartDate, &s.EndDate, &s.CreatedAt, &s.UpdatedAt,
+			&s.ID, &s.ServiceName, &s.ServiceID, &s.PlanID, &s.Price, &s.BillingPeriod, &s.Category, &s.UserID,
+			&s.StartDate, &s.EndDate, &s.TrialEndDate, &s.IntroPrice, &s.IntroMonths, &s.CreatedAt, &s.UpdatedAt, &s.Tags,
 		); err != nil {
 			return nil, fmt.Errorf("scan: %w", err)
 		}
 		subs = append(subs, &s)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
 
 	return subs, nil
 }
 
-func (r *SubscriptionRepo) SumForPeriod(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) (int, error) {
-	qb := r.builder.
-		Select("COALESCE(SUM(price), 0) AS total").
-		From("subscriptions").
+// pgPlanPrices — цены тарифов: базовая (effective_from IS NULL) и изменения.
+const pgPlanPrices = `(
+	SELECT id AS plan_id, NULL::DATE AS effective_from, price FROM plans
+	UNION ALL
+	SELECT plan_id, effective_from, price FROM plan_price_changes
+) pp ON pp.plan_id = s.plan_id`
+
+// pgRegularPrice — месячная цена отрезка, как в models.MonthlyPrice.
+const pgRegularPrice = "CASE WHEN s.billing_period = '" + models.BillingYearly + "' THEN COALESCE(pp.price, s.price) / 12 ELSE COALESCE(pp.price, s.price) END"
+
+// pgPaidStart — первый платный месяц подписки, pgRegularStart — первый
+// месяц по обычной цене (см. models.Subscription.RegularStart).
+const (
+	pgPaidStart    = "COALESCE(s.trial_end_date + INTERVAL '1 month', s.start_date)::DATE"
+	pgRegularStart = "(COALESCE(s.trial_end_date + INTERVAL '1 month', s.start_date) + make_interval(months => s.intro_months))::DATE"
+	// pgIntroEnd — последний месяц вводной цены.
+	pgIntroEnd = "(COALESCE(s.trial_end_date + INTERVAL '1 month', s.start_date) + make_interval(months => s.intro_months - 1))::DATE"
+)
+
+// pgSegments делит подписки, пересекающиеся с периодом, на отрезки цены.
+// С userID в выборку попадают подписки пользователя без участников и
+// подписки, где он участник.
+// Подписка на тариф делится изменениями цены тарифа на отрезки
+// [effective_from, next_from), и в выборку попадает каждый отрезок,
+// пересекающийся с периодом, со своей ценой. Подписка без тарифа — один
+// отрезок с её собственной ценой. Пробный период бесплатен и отрезка не
+// даёт, вводная цена — отдельный отрезок, а обычные отрезки начинаются с
+// pgRegularStart. Отрезок, все месяцы которого в периоде приходятся на
+// паузу, не учитывается. Цена отрезка входит в сумму один раз как месячная:
+// годовая цена делится на 12 (pgRegularPrice). Колонки: id подписки и price.
+func pgSegments(userID uuid.UUID, serviceName string, start time.Time, end *time.Time) squirrel.SelectBuilder {
+	segments := pgSegmentsScope(squirrel.
+		Select(
+			"s.id", pgRegularStart+" AS start_date", "s.end_date", pgRegularPrice+" AS price", "pp.effective_from",
+			"LEAD(pp.effective_from) OVER (PARTITION BY s.id ORDER BY pp.effective_from NULLS FIRST) AS next_from",
+		).
+		From("subscriptions s").
+		LeftJoin(pgPlanPrices).
+		Where(squirrel.Or{
+			squirrel.Expr("s.end_date IS NULL"),
+			squirrel.GtOrEq{"s.end_date": start},
+		}).
+		Where("(s.end_date IS NULL OR "+pgRegularStart+" <= s.end_date)"), userID, serviceName)
+
+	if end != nil {
+		segments = segments.Where(squirrel.LtOrEq{pgRegularStart: *end})
+	}
+
+	intro := pgSegmentsScope(squirrel.
+		Select("s.id", "s.intro_price AS price", pgPaidStart+" AS seg_from", "LEAST(s.end_date, "+pgIntroEnd+") AS seg_to").
+		From("subscriptions s").
+		Where("s.intro_price IS NOT NULL").
 		Where(squirrel.Or{
-			squirrel.Expr("end_date IS NULL"),
-			squirrel.GtOrEq{"end_date": start},
+			squirrel.Expr("s.end_date IS NULL"),
+			squirrel.GtOrEq{"s.end_date": start},
+		}).
+		Where("(s.end_date IS NULL OR "+pgPaidStart+" <= s.end_date)").
+		Where(squirrel.Gt{pgRegularStart: start}), userID, serviceName)
+
+	if end != nil {
+		intro = intro.Where(squirrel.LtOrEq{pgPaidStart: *end})
+	}
+
+	regular := squirrel.
+		Select(
+			"seg.id", "seg.price", "GREATEST(seg.start_date, seg.effective_from) AS seg_from",
+			"LEAST(seg.end_date, (seg.next_from - INTERVAL '1 month')::DATE) AS seg_to",
+		).
+		FromSelect(segments, "seg").
+		Where("(seg.next_from IS NULL OR seg.next_from > seg.start_date)").
+		Where("(seg.effective_from IS NULL OR seg.end_date IS NULL OR seg.effective_from <= seg.end_date)").
+		Where(squirrel.Or{
+			squirrel.Expr("seg.next_from IS NULL"),
+			squirrel.Gt{"seg.next_from": start},
+		})
+
+	if end != nil {
+		regular = regular.Where(squirrel.Or{
+			squirrel.Expr("seg.effective_from IS NULL"),
+			squirrel.LtOrEq{"seg.effective_from": *end},
 		})
+	}
 
+	// Отрезок [seg_from, seg_to] внутри периода целиком покрыт одной паузой:
+	// примыкающие паузы сливаются (см. models.AddPause).
+	notPaused := squirrel.Expr(`NOT EXISTS (SELECT 1 FROM subscription_pauses p WHERE p.subscription_id = seg.id
+		AND p.start_date <= GREATEST(seg.seg_from, ?) AND (p.end_date IS NULL OR p.end_date >= seg.seg_to))`, start)
 	if end != nil {
-		qb = qb.Where(squirrel.LtOrEq{"start_date": *end})
+		notPaused = squirrel.Expr(`NOT EXISTS (SELECT 1 FROM subscription_pauses p WHERE p.subscription_id = seg.id
+		AND p.start_date <= GREATEST(seg.seg_from, ?) AND (p.end_date IS NULL OR p.end_date >= LEAST(seg.seg_to, ?)))`, start, *end)
 	}
 
-	if userID.String() != "" {
-		qb = qb.Where(squirrel.Eq{"user_id": userID})
+	return squirrel.
+		Select("seg.id", "seg.price").
+		FromSelect(regular.Suffix("UNION ALL").SuffixExpr(intro), "seg").
+		Where(notPaused)
+}
+
+// pgSegmentsScope ограничивает подписки s пользователем и сервисом.
+func pgSegmentsScope(qb squirrel.SelectBuilder, userID uuid.UUID, serviceName string) squirrel.SelectBuilder {
+	if userID != uuid.Nil {
+		qb = qb.Where(squirrel.Or{
+			squirrel.And{
+				squirrel.Eq{"s.user_id": userID},
+				squirrel.Expr("NOT EXISTS (SELECT 1 FROM subscription_members m WHERE m.subscription_id = s.id)"),
+			},
+			squirrel.Expr("EXISTS (SELECT 1 FROM subscription_members m WHERE m.subscription_id = s.id AND m.user_id = ?)", userID),
+		})
 	}
 
 	if serviceName != "" {
-		qb = qb.Where(squirrel.Eq{"service_name": serviceName})
+		qb = qb.Where(squirrel.Eq{"s.service_name": serviceName})
 	}
 
-	query, args, err := qb.ToSql()
+	return qb
+}
+
+// pgMemberShares — накопленные веса участников подписок, разделённых с
+// пользователем: доля участника считается как в models.MemberShare.
+const pgMemberShares = `(
+	SELECT subscription_id, user_id, weight,
+		SUM(weight) OVER (PARTITION BY subscription_id ORDER BY user_id) AS upto,
+		SUM(weight) OVER (PARTITION BY subscription_id) AS total
+	FROM subscription_members
+	WHERE subscription_id IN (SELECT subscription_id FROM subscription_members WHERE user_id = ?)
+) sh ON sh.subscription_id = tot.id AND sh.user_id = ?`
+
+// pgPriced возвращает отрезки из pgSegments, а с userID — сумму каждой
+// подписки за период, из которой у разделённых подписок взята доля
+// пользователя. Колонки: id подписки и price.
+func pgPriced(userID uuid.UUID, serviceName string, start time.Time, end *time.Time) squirrel.SelectBuilder {
+	segments := pgSegments(userID, serviceName, start, end)
+	if userID == uuid.Nil {
+		return segments
+	}
+
+	totals := squirrel.
+		Select("seg.id", "SUM(seg.price) AS price").
+		FromSelect(segments, "seg").
+		GroupBy("seg.id")
+
+	return squirrel.
+		Select("tot.id", "COALESCE(tot.price * sh.upto / sh.total - tot.price * (sh.upto - sh.weight) / sh.total, tot.price)::INTEGER AS price").
+		FromSelect(totals, "tot").
+		LeftJoin(pgMemberShares, userID, userID)
+}
+
+// SumForPeriod суммирует цены из pgPriced.
+func (r *SubscriptionRepo) SumForPeriod(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) (int, error) {
+	query, args, err := r.builder.
+		Select("COALESCE(SUM(priced.price), 0) AS total").
+		FromSelect(pgPriced(userID, serviceName, start, end), "priced").
+		ToSql()
 	if err != nil {
 		return 0, fmt.Errorf("failed to build sum query: %w", err)
 	}
 
 	var total int
-	err = r.db.QueryRow(ctx, query, args...).Scan(&total)
+	err = r.db.Reader(ctx).QueryRow(ctx, query, args...).Scan(&total)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get sum: %w", err)
 	}
 
 	return total, nil
 }
+
+// SumForPeriodByTag считает суммы как SumForPeriod, но по тегам: подписка
+// входит в сумму каждого своего тега, а подписки без тегов — в сумму с
+// пустым тегом.
+func (r *SubscriptionRepo) SumForPeriodByTag(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) ([]models.TagTotal, error) {
+	query, args, err := r.builder.
+		Select("COALESCE(t.name, '') AS tag", "SUM(priced.price)").
+		FromSelect(pgPriced(userID, serviceName, start, end), "priced").
+		LeftJoin("subscription_tags st ON st.subscription_id = priced.id").
+		LeftJoin("tags t ON t.id = st.tag_id").
+		GroupBy("t.name").
+		OrderBy("tag").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sum query: %w", err)
+	}
+
+	rows, err := r.db.Reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sum: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make([]models.TagTotal, 0)
+	for rows.Next() {
+		var t models.TagTotal
+		if err := rows.Scan(&t.Tag, &t.Total); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get sum: %w", err)
+	}
+
+	return totals, nil
+}
+
+// SumForPeriodBySubscription считает суммы как SumForPeriod, но отдельно для
+// каждой подписки.
+func (r *SubscriptionRepo) SumForPeriodBySubscription(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) ([]models.SubscriptionTotal, error) {
+	query, args, err := r.builder.
+		Select("priced.id", "SUM(priced.price)").
+		FromSelect(pgPriced(userID, serviceName, start, end), "priced").
+		GroupBy("priced.id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sum query: %w", err)
+	}
+
+	rows, err := r.db.Reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sum: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make([]models.SubscriptionTotal, 0)
+	for rows.Next() {
+		var t models.SubscriptionTotal
+		if err := rows.Scan(&t.SubscriptionID, &t.Total); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get sum: %w", err)
+	}
+
+	return totals, nil
+}
+
+func (r *SubscriptionRepo) UnlinkedServiceNames(ctx context.Context) ([]models.ServiceNameCount, error) {
+	query, args, err := r.builder.
+		Select("service_name", "COUNT(*)").
+		From("subscriptions").
+		Where(squirrel.Eq{"service_id": nil}).
+		GroupBy("service_name").
+		OrderBy("COUNT(*) DESC", "service_name").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build service names query: %w", err)
+	}
+
+	rows, err := r.db.Primary(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service names: %w", err)
+	}
+	defer rows.Close()
+
+	names := make([]models.ServiceNameCount, 0)
+	for rows.Next() {
+		var n models.ServiceNameCount
+		if err := rows.Scan(&n.Name, &n.Count); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		names = append(names, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get service names: %w", err)
+	}
+
+	return names, nil
+}
+
+func (r *SubscriptionRepo) LinkService(ctx context.Context, name string, svc *models.Service) (int, error) {
+	query, args, err := r.builder.
+		Update("subscriptions").
+		Set("service_id", svc.ID).
+		Set("service_name", svc.Name).
+		Where(squirrel.Eq{"service_name": name, "service_id": nil}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build link query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to link subscriptions to service: %w", err)
+	}
+
+	return int(cmd.RowsAffected()), nil
+}
+
+func (r *SubscriptionRepo) RenameService(ctx context.Context, svc *models.Service) (int, error) {
+	query, args, err := r.builder.
+		Update("subscriptions").
+		Set("service_name", svc.Name).
+		Where(squirrel.And{
+			squirrel.Eq{"service_id": svc.ID},
+			squirrel.NotEq{"service_name": svc.Name},
+		}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build rename query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rename service in subscriptions: %w", err)
+	}
+
+	return int(cmd.RowsAffected()), nil
+}
@@ -0,0 +1,941 @@
+package adapter_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// runSubscriptionRepoContract проверяет поведение, общее для всех реализаций
+// usecase.SubscriptionRepo. newRepo должен возвращать пустое хранилище.
+func runSubscriptionRepoContract(t *testing.T, newRepo func(t *testing.T) usecase.SubscriptionRepo) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+
+		sub := newSubscription(uuid.New(), "Yandex Plus", 400, month(2025, 7), nil)
+		end := month(2025, 12)
+		sub.EndDate = &end
+
+		id, err := repo.Create(ctx, sub)
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if id != sub.ID {
+			t.Fatalf("Create returned id %s, want %s", id, sub.ID)
+		}
+
+		got, err := repo.GetById(ctx, id)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		assertSubscription(t, got, sub)
+	})
+
+	t.Run("CreateRejectsEndBeforeStart", func(t *testing.T) {
+		repo := newRepo(t)
+		end := month(2025, 1)
+		sub := newSubscription(uuid.New(), "Netflix", 100, month(2025, 2), &end)
+
+		if _, err := repo.Create(context.Background(), sub); err == nil {
+			t.Fatal("Create: expected error for end_date before start_date")
+		}
+	})
+
+	t.Run("CreateBatch", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		end := month(2025, 3)
+		subs := []*models.Subscription{
+			newSubscription(userID, "Netflix", 100, month(2025, 1), &end),
+			newSubscription(userID, "Spotify", 200, month(2025, 2), nil),
+			newSubscription(userID, "Okko", 300, month(2025, 3), nil),
+		}
+		if err := repo.CreateBatch(ctx, subs); err != nil {
+			t.Fatalf("CreateBatch: %v", err)
+		}
+
+		for _, sub := range subs {
+			got, err := repo.GetById(ctx, sub.ID)
+			if err != nil {
+				t.Fatalf("GetById: %v", err)
+			}
+			assertSubscription(t, got, sub)
+		}
+
+		if err := repo.CreateBatch(ctx, nil); err != nil {
+			t.Fatalf("CreateBatch with no subscriptions: %v", err)
+		}
+	})
+
+	t.Run("CreateBatchIsAtomic", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		end := month(2025, 1)
+		subs := []*models.Subscription{
+			newSubscription(userID, "Netflix", 100, month(2025, 1), nil),
+			newSubscription(userID, "Spotify", 200, month(2025, 2), &end),
+		}
+		if err := repo.CreateBatch(ctx, subs); err == nil {
+			t.Fatal("CreateBatch: expected error for end_date before start_date")
+		}
+
+		list, err := repo.List(ctx, models.SubscriptionFilter{UserID: userID})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(list) != 0 {
+			t.Fatalf("CreateBatch left %d subscriptions after a failed batch", len(list))
+		}
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		repo := newRepo(t)
+
+		got, err := repo.GetById(context.Background(), uuid.New())
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if got != nil {
+			t.Fatalf("GetById: expected nil for missing subscription, got %+v", got)
+		}
+	})
+
+	t.Run("ReturnedValueIsCopy", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		sub := mustCreate(t, repo, newSubscription(uuid.New(), "Spotify", 200, month(2025, 1), nil))
+
+		got, err := repo.GetById(ctx, sub.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		got.Price = 999
+
+		again, err := repo.GetById(ctx, sub.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if again.Price != 200 {
+			t.Fatalf("stored subscription changed through returned value: price %d", again.Price)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		sub := mustCreate(t, repo, newSubscription(uuid.New(), "Spotify", 200, month(2025, 1), nil))
+
+		end := month(2025, 6)
+		sub.ServiceName = "Spotify Family"
+		sub.Price = 300
+		sub.StartDate = month(2025, 2)
+		sub.EndDate = &end
+		sub.UpdatedAt = sub.UpdatedAt.Add(time.Hour)
+
+		if err := repo.Update(ctx, sub); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+
+		got, err := repo.GetByIdForUpdate(ctx, sub.ID)
+		if err != nil {
+			t.Fatalf("GetByIdForUpdate: %v", err)
+		}
+		assertSubscription(t, got, sub)
+
+		sub.EndDate = nil
+		if err := repo.Update(ctx, sub); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, err = repo.GetById(ctx, sub.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if got.EndDate != nil {
+			t.Fatalf("Update did not clear end_date: %v", got.EndDate)
+		}
+	})
+
+	t.Run("UpdateMissing", func(t *testing.T) {
+		repo := newRepo(t)
+		sub := newSubscription(uuid.New(), "Spotify", 200, month(2025, 1), nil)
+
+		if err := repo.Update(context.Background(), sub); err == nil {
+			t.Fatal("Update: expected error for missing subscription")
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		sub := mustCreate(t, repo, newSubscription(uuid.New(), "Spotify", 200, month(2025, 1), nil))
+
+		if err := repo.Delete(ctx, sub.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		got, err := repo.GetById(ctx, sub.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if got != nil {
+			t.Fatal("GetById: subscription still exists after Delete")
+		}
+		if err := repo.Delete(ctx, sub.ID); err == nil {
+			t.Fatal("Delete: expected error for missing subscription")
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		userID := uuid.New()
+		otherID := uuid.New()
+
+		base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		var created []*models.Subscription
+		for i, name := range []string{"first", "second", "third"} {
+			sub := newSubscription(userID, name, 100, month(2025, 1), nil)
+			sub.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+			sub.UpdatedAt = sub.CreatedAt
+			created = append(created, mustCreate(t, repo, sub))
+		}
+		other := newSubscription(otherID, "other", 100, month(2025, 1), nil)
+		other.CreatedAt = base.Add(time.Hour)
+		mustCreate(t, repo, other)
+
+		subs, err := repo.List(ctx, models.SubscriptionFilter{UserID: userID})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(subs) != len(created) {
+			t.Fatalf("List returned %d subscriptions, want %d", len(subs), len(created))
+		}
+		for i, sub := range subs {
+			want := created[len(created)-1-i]
+			if sub.ID != want.ID {
+				t.Fatalf("List[%d] = %s, want %s (ordered by created_at DESC)", i, sub.ServiceName, want.ServiceName)
+			}
+		}
+
+		all, err := repo.List(ctx, models.SubscriptionFilter{UserID: uuid.Nil})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if len(all) != 4 {
+			t.Fatalf("List without user returned %d subscriptions, want 4", len(all))
+		}
+		if all[0].ID != other.ID {
+			t.Fatalf("List without user: first is %s, want %s", all[0].ServiceName, other.ServiceName)
+		}
+
+		empty, err := repo.List(ctx, models.SubscriptionFilter{UserID: uuid.New()})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if empty == nil || len(empty) != 0 {
+			t.Fatalf("List for unknown user: want empty non-nil slice, got %#v", empty)
+		}
+	})
+
+	t.Run("SumForPeriod", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		userID := uuid.New()
+		otherID := uuid.New()
+
+		endMarch := month(2025, 3)
+		endMay := month(2025, 5)
+		mustCreate(t, repo, newSubscription(userID, "Netflix", 100, month(2025, 1), &endMarch))
+		mustCreate(t, repo, newSubscription(userID, "Spotify", 200, month(2025, 2), &endMay))
+		mustCreate(t, repo, newSubscription(userID, "Yandex Plus", 300, month(2025, 5), nil))
+		mustCreate(t, repo, newSubscription(otherID, "Spotify", 1000, month(2025, 1), nil))
+
+		tests := []struct {
+			name        string
+			userID      uuid.UUID
+			serviceName string
+			start       time.Time
+			end         *time.Time
+			want        int
+		}{
+			{name: "single month", userID: userID, start: month(2025, 4), end: ptr(month(2025, 4)), want: 200},
+			{name: "range", userID: userID, start: month(2025, 3), end: ptr(month(2025, 5)), want: 600},
+			{name: "boundary end_date", userID: userID, start: month(2025, 3), end: ptr(month(2025, 3)), want: 300},
+			{name: "boundary start_date", userID: userID, start: month(2025, 5), end: ptr(month(2025, 5)), want: 500},
+			{name: "open period", userID: userID, start: month(2025, 4), want: 500},
+			{name: "before everything", userID: userID, start: month(2024, 1), end: ptr(month(2024, 12)), want: 0},
+			{name: "service filter", userID: userID, serviceName: "Spotify", start: month(2025, 1), end: ptr(month(2025, 12)), want: 200},
+			{name: "all users", userID: uuid.Nil, serviceName: "Spotify", start: month(2025, 1), end: ptr(month(2025, 12)), want: 1200},
+			{name: "unknown user", userID: uuid.New(), start: month(2025, 1), want: 0},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := repo.SumForPeriod(ctx, tt.userID, tt.serviceName, tt.start, tt.end)
+				if err != nil {
+					t.Fatalf("SumForPeriod: %v", err)
+				}
+				if got != tt.want {
+					t.Fatalf("SumForPeriod = %d, want %d", got, tt.want)
+				}
+
+				bySub, err := repo.SumForPeriodBySubscription(ctx, tt.userID, tt.serviceName, tt.start, tt.end)
+				if err != nil {
+					t.Fatalf("SumForPeriodBySubscription: %v", err)
+				}
+				total := 0
+				for _, s := range bySub {
+					total += s.Total
+				}
+				if total != tt.want {
+					t.Fatalf("SumForPeriodBySubscription totals sum to %d, want %d", total, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("Labels", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		netflix := newSubscription(userID, "Netflix", 100, month(2025, 1), nil)
+		netflix.Category = "video"
+		netflix.Tags = []string{"family", "hd"}
+		spotify := newSubscription(userID, "Spotify", 200, month(2025, 1), nil)
+		spotify.Category = "music"
+		spotify.Tags = []string{"family"}
+		okko := newSubscription(userID, "Okko", 300, month(2025, 1), nil)
+		okko.Category = "video"
+		mustCreate(t, repo, netflix)
+		if err := repo.CreateBatch(ctx, []*models.Subscription{spotify, okko}); err != nil {
+			t.Fatalf("CreateBatch: %v", err)
+		}
+
+		got, err := repo.GetById(ctx, netflix.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		assertSubscription(t, got, netflix)
+
+		assertListed := func(filter models.SubscriptionFilter, want ...*models.Subscription) {
+			t.Helper()
+			list, err := repo.List(ctx, filter)
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(list) != len(want) {
+				t.Fatalf("List(%+v) returned %d subscriptions, want %d", filter, len(list), len(want))
+			}
+			for _, w := range want {
+				if !slices.ContainsFunc(list, func(s *models.Subscription) bool { return s.ID == w.ID }) {
+					t.Fatalf("List(%+v) is missing %s", filter, w.ServiceName)
+				}
+			}
+		}
+		assertListed(models.SubscriptionFilter{UserID: userID, Tag: "family"}, netflix, spotify)
+		assertListed(models.SubscriptionFilter{UserID: userID, Category: "video"}, netflix, okko)
+		assertListed(models.SubscriptionFilter{Category: "video", Tag: "family"}, netflix)
+		assertListed(models.SubscriptionFilter{UserID: userID, Tag: "missing"})
+
+		byTag, err := repo.SumForPeriodByTag(ctx, userID, "", month(2025, 1), ptr(month(2025, 2)))
+		if err != nil {
+			t.Fatalf("SumForPeriodByTag: %v", err)
+		}
+		wantByTag := []models.TagTotal{{Tag: "", Total: 300}, {Tag: "family", Total: 300}, {Tag: "hd", Total: 100}}
+		if !slices.Equal(byTag, wantByTag) {
+			t.Fatalf("SumForPeriodByTag = %+v, want %+v", byTag, wantByTag)
+		}
+
+		tags, err := repo.ListTags(ctx)
+		if err != nil {
+			t.Fatalf("ListTags: %v", err)
+		}
+		if want := []models.LabelCount{{Name: "family", Count: 2}, {Name: "hd", Count: 1}}; !slices.Equal(tags, want) {
+			t.Fatalf("ListTags = %+v, want %+v", tags, want)
+		}
+
+		renamed, err := repo.RenameTag(ctx, "hd", "family")
+		if err != nil {
+			t.Fatalf("RenameTag: %v", err)
+		}
+		if renamed != 1 {
+			t.Fatalf("RenameTag renamed %d subscriptions, want 1", renamed)
+		}
+		got, err = repo.GetById(ctx, netflix.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if want := []string{"family"}; !slices.Equal(got.Tags, want) {
+			t.Fatalf("tags after merge = %v, want %v", got.Tags, want)
+		}
+
+		deleted, err := repo.DeleteTag(ctx, "family")
+		if err != nil {
+			t.Fatalf("DeleteTag: %v", err)
+		}
+		if deleted != 2 {
+			t.Fatalf("DeleteTag removed tag from %d subscriptions, want 2", deleted)
+		}
+		tags, err = repo.ListTags(ctx)
+		if err != nil {
+			t.Fatalf("ListTags: %v", err)
+		}
+		if len(tags) != 0 {
+			t.Fatalf("ListTags after delete = %+v, want none", tags)
+		}
+
+		categories, err := repo.ListCategories(ctx)
+		if err != nil {
+			t.Fatalf("ListCategories: %v", err)
+		}
+		if want := []models.LabelCount{{Name: "music", Count: 1}, {Name: "video", Count: 2}}; !slices.Equal(categories, want) {
+			t.Fatalf("ListCategories = %+v, want %+v", categories, want)
+		}
+		if n, err := repo.RenameCategory(ctx, "video", "streaming"); err != nil || n != 2 {
+			t.Fatalf("RenameCategory = %d, %v; want 2", n, err)
+		}
+		if n, err := repo.RenameCategory(ctx, "music", ""); err != nil || n != 1 {
+			t.Fatalf("RenameCategory to empty = %d, %v; want 1", n, err)
+		}
+		categories, err = repo.ListCategories(ctx)
+		if err != nil {
+			t.Fatalf("ListCategories: %v", err)
+		}
+		if want := []models.LabelCount{{Name: "streaming", Count: 2}}; !slices.Equal(categories, want) {
+			t.Fatalf("ListCategories = %+v, want %+v", categories, want)
+		}
+	})
+
+	t.Run("Members", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		alice := uuid.MustParse("11111111-1111-4111-8111-111111111111")
+		bob := uuid.MustParse("22222222-2222-4222-8222-222222222222")
+		owner := uuid.MustParse("33333333-3333-4333-8333-333333333333")
+
+		family := newSubscription(owner, "Netflix", 100, month(2025, 1), nil)
+		family.Tags = []string{"family"}
+		mustCreate(t, repo, family)
+		solo := mustCreate(t, repo, newSubscription(alice, "Spotify", 50, month(2025, 1), nil))
+
+		members := []models.SubscriptionMember{{UserID: bob, Weight: 2}, {UserID: alice, Weight: 1}}
+		if err := repo.SetMembers(ctx, family.ID, members); err != nil {
+			t.Fatalf("SetMembers: %v", err)
+		}
+		got, err := repo.ListMembers(ctx, family.ID)
+		if err != nil {
+			t.Fatalf("ListMembers: %v", err)
+		}
+		if want := []models.SubscriptionMember{members[1], members[0]}; !slices.Equal(got, want) {
+			t.Fatalf("ListMembers = %+v, want %+v", got, want)
+		}
+		if err := repo.SetMembers(ctx, uuid.New(), members); err == nil {
+			t.Fatal("SetMembers: expected error for missing subscription")
+		}
+
+		period, end := month(2025, 3), ptr(month(2025, 3))
+		assertSum := func(userID uuid.UUID, want int) {
+			t.Helper()
+			sum, err := repo.SumForPeriod(ctx, userID, "", period, end)
+			if err != nil {
+				t.Fatalf("SumForPeriod: %v", err)
+			}
+			if sum != want {
+				t.Fatalf("SumForPeriod(%s) = %d, want %d", userID, sum, want)
+			}
+		}
+		// Доли 1:2 округляются накопительно: 33 и 67.
+		assertSum(alice, 83)
+		assertSum(bob, 67)
+		assertSum(owner, 0)
+		assertSum(uuid.Nil, 150)
+
+		totals, err := repo.SumForPeriodBySubscription(ctx, bob, "", period, end)
+		if err != nil {
+			t.Fatalf("SumForPeriodBySubscription: %v", err)
+		}
+		if want := []models.SubscriptionTotal{{SubscriptionID: family.ID, Total: 67}}; !slices.Equal(totals, want) {
+			t.Fatalf("SumForPeriodBySubscription = %+v, want %+v", totals, want)
+		}
+		byTag, err := repo.SumForPeriodByTag(ctx, alice, "", period, end)
+		if err != nil {
+			t.Fatalf("SumForPeriodByTag: %v", err)
+		}
+		if want := []models.TagTotal{{Tag: "", Total: 50}, {Tag: "family", Total: 33}}; !slices.Equal(byTag, want) {
+			t.Fatalf("SumForPeriodByTag = %+v, want %+v", byTag, want)
+		}
+
+		assertListed := func(userID uuid.UUID, want ...*models.Subscription) {
+			t.Helper()
+			list, err := repo.List(ctx, models.SubscriptionFilter{UserID: userID})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			var ids, wantIDs []uuid.UUID
+			for _, s := range list {
+				ids = append(ids, s.ID)
+			}
+			for _, s := range want {
+				wantIDs = append(wantIDs, s.ID)
+			}
+			slices.SortFunc(ids, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+			slices.SortFunc(wantIDs, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+			if !slices.Equal(ids, wantIDs) {
+				t.Fatalf("List(%s) = %v, want %v", userID, ids, wantIDs)
+			}
+		}
+		assertListed(alice, family, solo)
+		assertListed(bob, family)
+		assertListed(owner, family)
+
+		if err := repo.SetMembers(ctx, family.ID, nil); err != nil {
+			t.Fatalf("SetMembers with no members: %v", err)
+		}
+		assertSum(owner, 100)
+		assertSum(bob, 0)
+		assertListed(bob)
+
+		if err := repo.SetMembers(ctx, family.ID, members); err != nil {
+			t.Fatalf("SetMembers: %v", err)
+		}
+		if err := repo.Delete(ctx, family.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if got, err := repo.ListMembers(ctx, family.ID); err != nil || len(got) != 0 {
+			t.Fatalf("ListMembers after Delete = %+v, %v; want none", got, err)
+		}
+	})
+
+	t.Run("Trials", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		// Бесплатно январь–февраль, вводная цена в марте–апреле, дальше обычная.
+		intro := newSubscription(userID, "Netflix", 100, month(2025, 1), nil)
+		intro.TrialEndDate = ptr(month(2025, 2))
+		intro.IntroPrice = ptr(50)
+		intro.IntroMonths = 2
+		mustCreate(t, repo, intro)
+		// Отменена в пробный период.
+		cancelled := newSubscription(userID, "Spotify", 300, month(2025, 1), ptr(month(2025, 1)))
+		cancelled.TrialEndDate = ptr(month(2025, 1))
+		mustCreate(t, repo, cancelled)
+		trial := newSubscription(userID, "Yandex Plus", 200, month(2025, 2), nil)
+		trial.TrialEndDate = ptr(month(2025, 2))
+		mustCreate(t, repo, trial)
+		// Закончилась во время вводной цены.
+		short := newSubscription(userID, "Kinopoisk", 999, month(2025, 1), ptr(month(2025, 2)))
+		short.IntroPrice = ptr(10)
+		short.IntroMonths = 3
+		mustCreate(t, repo, short)
+
+		got, err := repo.GetById(ctx, intro.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		assertSubscription(t, got, intro)
+		if got.TrialEndDate == nil || !got.TrialEndDate.Equal(month(2025, 2)) || got.IntroPrice == nil || *got.IntroPrice != 50 || got.IntroMonths != 2 {
+			t.Fatalf("trial_end_date, intro_price, intro_months = %v, %v, %d; want 2025-02, 50, 2", got.TrialEndDate, got.IntroPrice, got.IntroMonths)
+		}
+
+		tests := []struct {
+			name   string
+			userID uuid.UUID
+			start  time.Time
+			end    *time.Time
+			want   int
+		}{
+			{name: "trial months", userID: userID, start: month(2025, 1), end: ptr(month(2025, 2)), want: 10},
+			{name: "intro month", userID: userID, start: month(2025, 3), end: ptr(month(2025, 3)), want: 250},
+			{name: "intro and regular", userID: userID, start: month(2025, 3), end: ptr(month(2025, 6)), want: 350},
+			{name: "regular month", userID: userID, start: month(2025, 5), end: ptr(month(2025, 5)), want: 300},
+			{name: "open period", userID: userID, start: month(2025, 1), want: 360},
+			{name: "all users", userID: uuid.Nil, start: month(2025, 1), want: 360},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := repo.SumForPeriod(ctx, tt.userID, "", tt.start, tt.end)
+				if err != nil {
+					t.Fatalf("SumForPeriod: %v", err)
+				}
+				if got != tt.want {
+					t.Fatalf("SumForPeriod = %d, want %d", got, tt.want)
+				}
+			})
+		}
+
+		assertEnding := func(m time.Time, want ...*models.Subscription) {
+			t.Helper()
+			list, err := repo.ListTrialsEnding(ctx, m)
+			if err != nil {
+				t.Fatalf("ListTrialsEnding: %v", err)
+			}
+			var ids, wantIDs []uuid.UUID
+			for _, s := range list {
+				ids = append(ids, s.ID)
+			}
+			for _, s := range want {
+				wantIDs = append(wantIDs, s.ID)
+			}
+			slices.SortFunc(ids, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+			slices.SortFunc(wantIDs, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+			if !slices.Equal(ids, wantIDs) {
+				t.Fatalf("ListTrialsEnding(%s) = %v, want %v", m.Format("01-2006"), ids, wantIDs)
+			}
+		}
+		assertEnding(month(2025, 1))
+		assertEnding(month(2025, 2), intro, trial)
+		assertEnding(month(2025, 3))
+
+		claim := func(want bool) {
+			t.Helper()
+			claimed, err := repo.ClaimTrialReminder(ctx, intro.ID, month(2025, 3))
+			if err != nil {
+				t.Fatalf("ClaimTrialReminder: %v", err)
+			}
+			if claimed != want {
+				t.Fatalf("ClaimTrialReminder = %v, want %v", claimed, want)
+			}
+		}
+		claim(true)
+		claim(false)
+		if err := repo.ReleaseTrialReminder(ctx, intro.ID, month(2025, 3)); err != nil {
+			t.Fatalf("ReleaseTrialReminder: %v", err)
+		}
+		claim(true)
+
+		trial.TrialEndDate = nil
+		trial.IntroPrice = ptr(150)
+		trial.IntroMonths = 1
+		if err := repo.Update(ctx, trial); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		got, err = repo.GetById(ctx, trial.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if got.TrialEndDate != nil || got.IntroPrice == nil || *got.IntroPrice != 150 || got.IntroMonths != 1 {
+			t.Fatalf("trial_end_date, intro_price, intro_months = %v, %v, %d; want nil, 150, 1", got.TrialEndDate, got.IntroPrice, got.IntroMonths)
+		}
+		assertEnding(month(2025, 2), intro)
+	})
+
+	t.Run("Pauses", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		setPauses := func(sub *models.Subscription, pauses ...models.SubscriptionPause) {
+			t.Helper()
+			if err := repo.SetPauses(ctx, sub.ID, pauses); err != nil {
+				t.Fatalf("SetPauses: %v", err)
+			}
+		}
+		spring := mustCreate(t, repo, newSubscription(userID, "Netflix", 100, month(2025, 1), nil))
+		setPauses(spring, models.SubscriptionPause{StartDate: month(2025, 3), EndDate: ptr(month(2025, 5))})
+		open := mustCreate(t, repo, newSubscription(userID, "Spotify", 200, month(2025, 1), nil))
+		setPauses(open, models.SubscriptionPause{StartDate: month(2025, 6)})
+		// Пауза на все месяцы вводной цены.
+		intro := newSubscription(userID, "Yandex Plus", 300, month(2025, 1), nil)
+		intro.IntroPrice = ptr(30)
+		intro.IntroMonths = 2
+		mustCreate(t, repo, intro)
+		setPauses(intro, models.SubscriptionPause{StartDate: month(2025, 1), EndDate: ptr(month(2025, 2))})
+
+		tests := []struct {
+			name   string
+			userID uuid.UUID
+			start  time.Time
+			end    *time.Time
+			want   int
+		}{
+			{name: "paused month", userID: userID, start: month(2025, 4), end: ptr(month(2025, 4)), want: 500},
+			{name: "whole pause", userID: userID, start: month(2025, 3), end: ptr(month(2025, 5)), want: 500},
+			{name: "partly paused", userID: userID, start: month(2025, 2), end: ptr(month(2025, 3)), want: 600},
+			{name: "paused intro", userID: userID, start: month(2025, 1), end: ptr(month(2025, 2)), want: 300},
+			{name: "open pause", userID: userID, start: month(2025, 6), want: 400},
+			{name: "open period", userID: userID, start: month(2025, 3), want: 600},
+			{name: "all users", userID: uuid.Nil, start: month(2025, 4), end: ptr(month(2025, 4)), want: 500},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := repo.SumForPeriod(ctx, tt.userID, "", tt.start, tt.end)
+				if err != nil {
+					t.Fatalf("SumForPeriod: %v", err)
+				}
+				if got != tt.want {
+					t.Fatalf("SumForPeriod = %d, want %d", got, tt.want)
+				}
+			})
+		}
+
+		pauses, err := repo.ListPauses(ctx, []uuid.UUID{spring.ID, open.ID, uuid.New()})
+		if err != nil {
+			t.Fatalf("ListPauses: %v", err)
+		}
+		if len(pauses) != 2 || len(pauses[spring.ID]) != 1 || len(pauses[open.ID]) != 1 {
+			t.Fatalf("ListPauses = %+v, want one pause for each of two subscriptions", pauses)
+		}
+		if p := pauses[spring.ID][0]; !p.StartDate.Equal(month(2025, 3)) || p.EndDate == nil || !p.EndDate.Equal(month(2025, 5)) {
+			t.Fatalf("pause = %+v, want 03-2025..05-2025", p)
+		}
+		if p := pauses[open.ID][0]; !p.StartDate.Equal(month(2025, 6)) || p.EndDate != nil {
+			t.Fatalf("pause = %+v, want open from 06-2025", p)
+		}
+
+		if err := repo.SetPauses(ctx, uuid.New(), []models.SubscriptionPause{{StartDate: month(2025, 1)}}); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("SetPauses for missing subscription: %v, want not found", err)
+		}
+
+		setPauses(spring)
+		if err := repo.Delete(ctx, open.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if pauses, err := repo.ListPauses(ctx, []uuid.UUID{spring.ID, open.ID}); err != nil || len(pauses) != 0 {
+			t.Fatalf("ListPauses after clearing = %+v, %v; want none", pauses, err)
+		}
+	})
+
+	t.Run("ListByStatus", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		scheduled := mustCreate(t, repo, newSubscription(userID, "Okko", 100, month(2025, 7), nil))
+		ended := mustCreate(t, repo, newSubscription(userID, "Ivi", 100, month(2025, 1), ptr(month(2025, 5))))
+		paused := mustCreate(t, repo, newSubscription(userID, "Netflix", 100, month(2025, 1), ptr(month(2025, 7))))
+		if err := repo.SetPauses(ctx, paused.ID, []models.SubscriptionPause{{StartDate: month(2025, 6)}}); err != nil {
+			t.Fatalf("SetPauses: %v", err)
+		}
+		lastMonth := mustCreate(t, repo, newSubscription(userID, "Spotify", 100, month(2025, 1), ptr(month(2025, 6))))
+		nextMonth := mustCreate(t, repo, newSubscription(userID, "Kinopoisk", 100, month(2025, 1), ptr(month(2025, 7))))
+		active := mustCreate(t, repo, newSubscription(userID, "YouTube", 100, month(2025, 1), ptr(month(2025, 8))))
+		trialOver := newSubscription(userID, "Wink", 100, month(2025, 1), nil)
+		trialOver.TrialEndDate = ptr(month(2025, 5))
+		mustCreate(t, repo, trialOver)
+		trial := newSubscription(userID, "Start", 100, month(2025, 1), nil)
+		trial.TrialEndDate = ptr(month(2025, 6))
+		mustCreate(t, repo, trial)
+
+		tests := []struct {
+			status string
+			asOf   time.Time
+			want   []*models.Subscription
+		}{
+			{status: models.StatusScheduled, asOf: month(2025, 6), want: []*models.Subscription{scheduled}},
+			{status: models.StatusEnded, asOf: month(2025, 6), want: []*models.Subscription{ended}},
+			{status: models.StatusPaused, asOf: month(2025, 6), want: []*models.Subscription{paused}},
+			{status: models.StatusEndingSoon, asOf: month(2025, 6), want: []*models.Subscription{lastMonth, nextMonth}},
+			{status: models.StatusTrial, asOf: month(2025, 6), want: []*models.Subscription{trial}},
+			{status: models.StatusActive, asOf: month(2025, 6), want: []*models.Subscription{active, trialOver}},
+			{status: models.StatusActive, asOf: month(2025, 7), want: []*models.Subscription{scheduled, trialOver, trial}},
+			{status: models.StatusScheduled, asOf: month(2025, 7)},
+		}
+		for _, tt := range tests {
+			t.Run(tt.status+" "+tt.asOf.Format("01-2006"), func(t *testing.T) {
+				filter := models.SubscriptionFilter{UserID: userID, Status: tt.status, AsOf: tt.asOf}
+				list, err := repo.List(ctx, filter)
+				if err != nil {
+					t.Fatalf("List: %v", err)
+				}
+				if len(list) != len(tt.want) {
+					t.Fatalf("List(%+v) returned %d subscriptions, want %d", filter, len(list), len(tt.want))
+				}
+				for _, w := range tt.want {
+					if !slices.ContainsFunc(list, func(s *models.Subscription) bool { return s.ID == w.ID }) {
+						t.Fatalf("List(%+v) is missing %s", filter, w.ServiceName)
+					}
+				}
+				for _, s := range list {
+					pauses, err := repo.ListPauses(ctx, []uuid.UUID{s.ID})
+					if err != nil {
+						t.Fatalf("ListPauses: %v", err)
+					}
+					if got := s.Status(pauses[s.ID], tt.asOf); got != tt.status {
+						t.Fatalf("%s has status %s, want %s", s.ServiceName, got, tt.status)
+					}
+				}
+			})
+		}
+
+		if _, err := repo.List(ctx, models.SubscriptionFilter{Status: "unknown", AsOf: month(2025, 6)}); err == nil {
+			t.Fatal("List with unknown status succeeded, want error")
+		}
+	})
+
+	t.Run("Cancellations", func(t *testing.T) {
+		repo := newRepo(t)
+		ctx := context.Background()
+		userID := uuid.New()
+
+		netflix := mustCreate(t, repo, newSubscription(userID, "Netflix", 100, month(2025, 1), nil))
+		spotify := mustCreate(t, repo, newSubscription(userID, "Spotify", 200, month(2025, 1), ptr(month(2025, 12))))
+		okko := mustCreate(t, repo, newSubscription(userID, "Okko", 300, month(2025, 1), nil))
+		other := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", 100, month(2025, 1), nil))
+
+		cancelledAt := time.Date(2025, 5, 20, 12, 0, 0, 0, time.UTC)
+		cancel := func(sub *models.Subscription, effective time.Time, reason string) models.Cancellation {
+			t.Helper()
+			c := models.Cancellation{
+				SubscriptionID:  sub.ID,
+				EffectiveDate:   effective,
+				PreviousEndDate: sub.EndDate,
+				Reason:          reason,
+				Comment:         "comment",
+				CancelledAt:     cancelledAt,
+			}
+			if err := repo.CreateCancellation(ctx, c); err != nil {
+				t.Fatalf("CreateCancellation: %v", err)
+			}
+			return c
+		}
+		want := cancel(spotify, month(2025, 6), models.CancelReasonTooExpensive)
+		cancel(netflix, month(2025, 7), models.CancelReasonTooExpensive)
+		cancel(okko, month(2025, 9), models.CancelReasonNotUsing)
+		cancel(other, month(2025, 6), models.CancelReasonTooExpensive)
+
+		if err := repo.CreateCancellation(ctx, want); !errors.Is(err, models.ErrAlreadyCancelled) {
+			t.Fatalf("second CreateCancellation: %v, want %v", err, models.ErrAlreadyCancelled)
+		}
+		if err := repo.CreateCancellation(ctx, models.Cancellation{
+			SubscriptionID: uuid.New(), EffectiveDate: month(2025, 6), Reason: models.CancelReasonOther, CancelledAt: cancelledAt,
+		}); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Fatalf("CreateCancellation for missing subscription: %v, want not found", err)
+		}
+
+		cancellations, err := repo.ListCancellations(ctx, []uuid.UUID{spotify.ID, uuid.New()})
+		if err != nil {
+			t.Fatalf("ListCancellations: %v", err)
+		}
+		got, ok := cancellations[spotify.ID]
+		if len(cancellations) != 1 || !ok {
+			t.Fatalf("ListCancellations = %+v, want one cancellation", cancellations)
+		}
+		if !got.EffectiveDate.Equal(want.EffectiveDate) || got.PreviousEndDate == nil || !got.PreviousEndDate.Equal(month(2025, 12)) ||
+			got.Reason != want.Reason || got.Comment != want.Comment || !got.CancelledAt.Equal(cancelledAt) {
+			t.Fatalf("cancellation = %+v, want %+v", got, want)
+		}
+
+		tests := []struct {
+			name   string
+			userID uuid.UUID
+			start  time.Time
+			end    time.Time
+			want   []models.ChurnTotal
+		}{
+			{
+				name:   "user",
+				userID: userID,
+				start:  month(2025, 6),
+				end:    month(2025, 9),
+				want: []models.ChurnTotal{
+					{Reason: models.CancelReasonNotUsing, ServiceName: "Okko", Count: 1},
+					{Reason: models.CancelReasonTooExpensive, ServiceName: "Netflix", Count: 1},
+					{Reason: models.CancelReasonTooExpensive, ServiceName: "Spotify", Count: 1},
+				},
+			},
+			{
+				name:  "all users",
+				start: month(2025, 6),
+				end:   month(2025, 7),
+				want: []models.ChurnTotal{
+					{Reason: models.CancelReasonTooExpensive, ServiceName: "Netflix", Count: 2},
+					{Reason: models.CancelReasonTooExpensive, ServiceName: "Spotify", Count: 1},
+				},
+			},
+			{name: "empty period", start: month(2025, 1), end: month(2025, 5), want: []models.ChurnTotal{}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := repo.ChurnReport(ctx, tt.userID, tt.start, tt.end)
+				if err != nil {
+					t.Fatalf("ChurnReport: %v", err)
+				}
+				if !slices.Equal(got, tt.want) {
+					t.Fatalf("ChurnReport = %+v, want %+v", got, tt.want)
+				}
+			})
+		}
+
+		if err := repo.DeleteCancellation(ctx, spotify.ID); err != nil {
+			t.Fatalf("DeleteCancellation: %v", err)
+		}
+		if err := repo.DeleteCancellation(ctx, spotify.ID); !errors.Is(err, models.ErrNotCancelled) {
+			t.Fatalf("second DeleteCancellation: %v, want %v", err, models.ErrNotCancelled)
+		}
+		if err := repo.Delete(ctx, okko.ID); err != nil {
+			t.Fatalf("Delete: %v", err)
+		}
+		if cancellations, err := repo.ListCancellations(ctx, []uuid.UUID{spotify.ID, okko.ID}); err != nil || len(cancellations) != 0 {
+			t.Fatalf("ListCancellations after removing = %+v, %v; want none", cancellations, err)
+		}
+	})
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func newSubscription(userID uuid.UUID, serviceName string, price int, start time.Time, end *time.Time) *models.Subscription {
+	now := time.Date(2025, 1, 15, 10, 30, 0, 123456000, time.UTC)
+	return &models.Subscription{
+		ID:          uuid.New(),
+		ServiceName: serviceName,
+		Price:       price,
+		UserID:      userID,
+		StartDate:   start,
+		EndDate:     end,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+func mustCreate(t *testing.T, repo usecase.SubscriptionRepo, sub *models.Subscription) *models.Subscription {
+	t.Helper()
+	if _, err := repo.Create(context.Background(), sub); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	return sub
+}
+
+func assertSubscription(t *testing.T, got, want *models.Subscription) {
+	t.Helper()
+	if got == nil {
+		t.Fatal("subscription not found")
+	}
+	if got.ID != want.ID || got.ServiceName != want.ServiceName || got.Price != want.Price || got.UserID != want.UserID {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if !got.StartDate.Equal(want.StartDate) {
+		t.Fatalf("start_date = %s, want %s", got.StartDate, want.StartDate)
+	}
+	switch {
+	case want.EndDate == nil && got.EndDate != nil:
+		t.Fatalf("end_date = %s, want nil", got.EndDate)
+	case want.EndDate != nil && (got.EndDate == nil || !got.EndDate.Equal(*want.EndDate)):
+		t.Fatalf("end_date = %v, want %s", got.EndDate, want.EndDate)
+	}
+	if !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Fatalf("updated_at = %s, want %s", got.UpdatedAt, want.UpdatedAt)
+	}
+	if got.Category != want.Category || !slices.Equal(got.Tags, want.Tags) {
+		t.Fatalf("category, tags = %q, %v; want %q, %v", got.Category, got.Tags, want.Category, want.Tags)
+	}
+}
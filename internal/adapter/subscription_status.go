@@ -0,0 +1,42 @@
+package adapter
+
+import (
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/Masterminds/squirrel"
+)
+
+// statusCondition отбирает строки subscriptions со статусом status в месяце
+// month так же, как models.Subscription.Status; soon — последний месяц окна
+// models.EndingSoonMonths. Даты передаются в формате хранилища.
+func statusCondition(status string, month, soon any) (squirrel.Sqlizer, error) {
+	started := squirrel.Expr("start_date <= ?", month)
+	notEnded := squirrel.Expr("(end_date IS NULL OR end_date >= ?)", month)
+	paused := squirrel.Expr(
+		"EXISTS (SELECT 1 FROM subscription_pauses p WHERE p.subscription_id = subscriptions.id AND p.start_date <= ? AND (p.end_date IS NULL OR p.end_date >= ?))",
+		month, month,
+	)
+	notPaused := squirrel.Expr(
+		"NOT EXISTS (SELECT 1 FROM subscription_pauses p WHERE p.subscription_id = subscriptions.id AND p.start_date <= ? AND (p.end_date IS NULL OR p.end_date >= ?))",
+		month, month,
+	)
+	notEndingSoon := squirrel.Expr("(end_date IS NULL OR end_date > ?)", soon)
+
+	switch status {
+	case models.StatusScheduled:
+		return squirrel.Expr("start_date > ?", month), nil
+	case models.StatusEnded:
+		return squirrel.Expr("end_date < ?", month), nil
+	case models.StatusPaused:
+		return squirrel.And{started, notEnded, paused}, nil
+	case models.StatusEndingSoon:
+		return squirrel.And{started, notEnded, notPaused, squirrel.Expr("end_date <= ?", soon)}, nil
+	case models.StatusTrial:
+		return squirrel.And{started, notEnded, notPaused, notEndingSoon, squirrel.Expr("trial_end_date >= ?", month)}, nil
+	case models.StatusActive:
+		return squirrel.And{started, notEnded, notPaused, notEndingSoon, squirrel.Expr("(trial_end_date IS NULL OR trial_end_date < ?)", month)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown subscription status %q", status)
+}
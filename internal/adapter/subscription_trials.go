@@ -0,0 +1,73 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// ListTrialsEnding возвращает подписки, пробный период которых заканчивается
+// в месяце month, кроме тех, что заканчиваются вместе с ним.
+func (r *SubscriptionRepo) ListTrialsEnding(ctx context.Context, month time.Time) ([]*models.Subscription, error) {
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	query, args, err := r.builder.
+		Select(pgSubscriptionSelect...).
+		From("subscriptions").
+		Where(squirrel.GtOrEq{"trial_end_date": month}).
+		Where(squirrel.Lt{"trial_end_date": month.AddDate(0, 1, 0)}).
+		Where("(end_date IS NULL OR end_date > trial_end_date)").
+		OrderBy("trial_end_date", "id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trials query: %w", err)
+	}
+
+	rows, err := r.db.Reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	return collectSubscriptions(rows)
+}
+
+// ClaimTrialReminder отмечает напоминание о первом платном месяце и
+// возвращает false, если его уже отметил кто-то раньше.
+func (r *SubscriptionRepo) ClaimTrialReminder(ctx context.Context, subscriptionID uuid.UUID, paidStart time.Time) (bool, error) {
+	query, args, err := r.builder.
+		Insert("trial_reminders").
+		Columns("subscription_id", "paid_start", "created_at").
+		Values(subscriptionID, paidStart, time.Now()).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build claim reminder query: %w", err)
+	}
+
+	cmd, err := r.db.Writer(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim trial reminder: %w", err)
+	}
+
+	return cmd.RowsAffected() > 0, nil
+}
+
+// ReleaseTrialReminder снимает отметку, чтобы напоминание отправилось повторно.
+func (r *SubscriptionRepo) ReleaseTrialReminder(ctx context.Context, subscriptionID uuid.UUID, paidStart time.Time) error {
+	query, args, err := r.builder.
+		Delete("trial_reminders").
+		Where(squirrel.Eq{"subscription_id": subscriptionID, "paid_start": paidStart}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build release reminder query: %w", err)
+	}
+
+	if _, err := r.db.Writer(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to release trial reminder: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+package adapter_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// runTransactorContract проверяет, что usecase.Transactor откатывает все
+// записи транзакции, если fn вернула ошибку. newEnv должен возвращать пустое
+// хранилище и репозиторий поверх него.
+func runTransactorContract(t *testing.T, newEnv func(t *testing.T) (usecase.Transactor, usecase.SubscriptionRepo)) {
+	// cancel повторяет записи CancelSubscription: отмена, затем обновление
+	// подписки; с twice следом идёт повторная отмена, которая не удаётся.
+	cancel := func(ctx context.Context, repo usecase.SubscriptionRepo, sub *models.Subscription, twice bool) error {
+		end := month(2025, 5)
+		c := models.Cancellation{SubscriptionID: sub.ID, EffectiveDate: month(2025, 6), Reason: models.CancelReasonOther, CancelledAt: time.Now()}
+		if err := repo.CreateCancellation(ctx, c); err != nil {
+			return err
+		}
+		sub.EndDate = &end
+		if err := repo.Update(ctx, sub); err != nil {
+			return err
+		}
+		if twice {
+			return repo.CreateCancellation(ctx, c)
+		}
+		return nil
+	}
+
+	t.Run("RollsBackOnError", func(t *testing.T) {
+		tx, repo := newEnv(t)
+		ctx := context.Background()
+		sub := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", 500, month(2025, 1), nil))
+		created := newSubscription(uuid.New(), "Spotify", 300, month(2025, 1), nil)
+
+		err := tx.WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := repo.Create(ctx, created); err != nil {
+				return err
+			}
+			return cancel(ctx, repo, sub, true)
+		})
+		if !errors.Is(err, models.ErrAlreadyCancelled) {
+			t.Fatalf("WithinTx returned %v, want %v", err, models.ErrAlreadyCancelled)
+		}
+
+		got, err := repo.GetById(ctx, sub.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if got.EndDate != nil {
+			t.Fatalf("end_date = %s after rollback, want none", got.EndDate)
+		}
+		cancellations, err := repo.ListCancellations(ctx, []uuid.UUID{sub.ID})
+		if err != nil {
+			t.Fatalf("ListCancellations: %v", err)
+		}
+		if len(cancellations) != 0 {
+			t.Fatalf("cancellations after rollback = %+v, want none", cancellations)
+		}
+		if got, err := repo.GetById(ctx, created.ID); err != nil || got != nil {
+			t.Fatalf("subscription created in a rolled back transaction: %+v, %v", got, err)
+		}
+	})
+
+	t.Run("NestedRollsBackWithOuter", func(t *testing.T) {
+		tx, repo := newEnv(t)
+		ctx := context.Background()
+		sub := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", 500, month(2025, 1), nil))
+		errAbort := errors.New("abort")
+
+		err := tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := tx.WithinTx(ctx, func(ctx context.Context) error {
+				return cancel(ctx, repo, sub, false)
+			}); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		if !errors.Is(err, errAbort) {
+			t.Fatalf("WithinTx returned %v, want %v", err, errAbort)
+		}
+
+		cancellations, err := repo.ListCancellations(ctx, []uuid.UUID{sub.ID})
+		if err != nil {
+			t.Fatalf("ListCancellations: %v", err)
+		}
+		if len(cancellations) != 0 {
+			t.Fatalf("cancellations after rollback = %+v, want none", cancellations)
+		}
+	})
+
+	t.Run("Commits", func(t *testing.T) {
+		tx, repo := newEnv(t)
+		ctx := context.Background()
+		sub := mustCreate(t, repo, newSubscription(uuid.New(), "Netflix", 500, month(2025, 1), nil))
+
+		if err := tx.WithinTx(ctx, func(ctx context.Context) error {
+			return cancel(ctx, repo, sub, false)
+		}); err != nil {
+			t.Fatalf("WithinTx: %v", err)
+		}
+
+		got, err := repo.GetById(ctx, sub.ID)
+		if err != nil {
+			t.Fatalf("GetById: %v", err)
+		}
+		if got.EndDate == nil || !got.EndDate.Equal(month(2025, 5)) {
+			t.Fatalf("end_date = %v, want 05-2025", got.EndDate)
+		}
+		cancellations, err := repo.ListCancellations(ctx, []uuid.UUID{sub.ID})
+		if err != nil {
+			t.Fatalf("ListCancellations: %v", err)
+		}
+		if _, ok := cancellations[sub.ID]; !ok {
+			t.Fatal("cancellation is missing after commit")
+		}
+	})
+}
@@ -11,26 +11,68 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/I-Van-Radkov/subscription-service/internal/adapter"
 	"github.com/I-Van-Radkov/subscription-service/internal/config"
 	v1 "github.com/I-Van-Radkov/subscription-service/internal/controller/http/v1"
-	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
 	"github.com/I-Van-Radkov/subscription-service/pkg/logger"
+	"github.com/I-Van-Radkov/subscription-service/pkg/tracing"
 	"go.uber.org/zap"
 )
 
 type App struct {
 	httpServer *v1.Server
-	postgresDb *postgres.Database
+	storage    *Storage
+	tracing    *tracing.Provider
 	logger     logger.Logger
+
+	budgets        *usecase.BudgetUsecase
+	budgetInterval time.Duration
+
+	trialReminders        *usecase.TrialReminderUsecase
+	trialReminderInterval time.Duration
+
+	drainDelay time.Duration
+	configPath string
+	tls        bool
 }
 
 func NewApp(cfg *config.Config, lg logger.Logger) (*App, error) {
-	db, err := postgres.New(cfg.PostgresConfig)
+	tp, err := tracing.New(context.Background(), cfg.TracingConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init tracing: %w", err)
+	}
+
+	storage, err := OpenStorage(cfg, lg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, err
+	}
+
+	subUseCase := usecase.NewSubscriptionUsecase(storage.Repo, storage.Services, storage.Plans, storage.Transactor)
+	serviceUseCase := usecase.NewServiceUsecase(storage.Services, storage.Plans, storage.Repo, storage.Transactor)
+	costCenterUseCase := usecase.NewCostCenterUsecase(storage.CostCenters, storage.Repo, storage.Transactor)
+
+	var notifier usecase.Notifier = adapter.NewLogNotifier(lg)
+	if cfg.BudgetAlertWebhookURL != "" {
+		notifier = adapter.NewWebhookNotifier(cfg.BudgetAlertWebhookURL, webhookTimeout)
+	}
+	budgetUseCase := usecase.NewBudgetUsecase(storage.Budgets, storage.Repo, notifier)
+
+	var trialNotifier usecase.TrialNotifier = adapter.NewLogNotifier(lg)
+	if cfg.TrialReminderWebhookURL != "" {
+		trialNotifier = adapter.NewWebhookNotifier(cfg.TrialReminderWebhookURL, webhookTimeout)
+	}
+	trialReminderUseCase := usecase.NewTrialReminderUsecase(storage.Repo, storage.Plans, trialNotifier, cfg.TrialReminderLead)
+
+	health := v1.NewHealthHandler(storage.Postgres, storage.SQLite)
+
+	server := v1.NewServer(cfg.Port, cfg.ReadTimeout, cfg.WriteTimeout, subUseCase, serviceUseCase, costCenterUseCase, budgetUseCase, health, lg, cfg.AccessLogConfig, cfg.AdminConfig)
+	if cfg.TLSConfig.Enabled() {
+		if err := server.ConfigureTLS(cfg.TLSConfig); err != nil {
+			return nil, fmt.Errorf("failed to configure TLS: %w", err)
+		}
 	}
 
-	server := v1.NewServer(cfg.Port, cfg.ReadTimeout, cfg.WriteTimeout, db.Pool)
 	err = server.RegisterHandlers()
 	if err != nil {
 		return nil, fmt.Errorf("failed to register handlers: %w", err)
@@ -38,8 +80,19 @@ func NewApp(cfg *config.Config, lg logger.Logger) (*App, error) {
 
 	return &App{
 		httpServer: server,
-		postgresDb: db,
+		storage:    storage,
+		tracing:    tp,
 		logger:     lg,
+
+		budgets:        budgetUseCase,
+		budgetInterval: cfg.BudgetEvalInterval,
+
+		trialReminders:        trialReminderUseCase,
+		trialReminderInterval: cfg.TrialReminderInterval,
+
+		drainDelay: cfg.ShutdownDrainDelay,
+		configPath: cfg.Path(),
+		tls:        cfg.TLSConfig.Enabled(),
 	}, nil
 }
 
@@ -48,18 +101,59 @@ func (a *App) MustRun(ctx context.Context, port int, timeout time.Duration) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		a.logger.Info(ctx, fmt.Sprintf("HTTP server listening on port %d", port))
+		a.logger.Info(ctx, fmt.Sprintf("HTTP server listening on port %d", port), zap.Bool("tls", a.tls))
 		if err := a.httpServer.Start(); !errors.Is(err, http.ErrServerClosed) {
 			a.logger.Error(ctx, "server error", zap.Error(err))
 		}
 	}()
 
+	backgroundCtx, stopBackground := context.WithCancel(ctx)
+	defer stopBackground()
+	backgroundWg := sync.WaitGroup{}
+	if a.budgetInterval > 0 {
+		backgroundWg.Add(1)
+		go func() {
+			defer backgroundWg.Done()
+			a.runBudgetEvaluator(backgroundCtx)
+		}()
+	}
+	if a.trialReminderInterval > 0 {
+		backgroundWg.Add(1)
+		go func() {
+			defer backgroundWg.Done()
+			a.runTrialReminders(backgroundCtx)
+		}()
+	}
+
 	graceSh := make(chan os.Signal, 1)
 	signal.Notify(graceSh, os.Interrupt, syscall.SIGTERM)
-	<-graceSh
+	reloadSh := make(chan os.Signal, 1)
+	signal.Notify(reloadSh, syscall.SIGHUP)
+
+wait:
+	for {
+		select {
+		case <-reloadSh:
+			a.reloadConfig(ctx)
+		case <-graceSh:
+			break wait
+		}
+	}
+	signal.Stop(reloadSh)
 
 	a.logger.Info(ctx, "Shutdown signal received, starting graceful shutdown...")
 
+	// Проверка бюджетов и пробных периодов обращается к хранилищу, поэтому
+	// завершается до его закрытия.
+	stopBackground()
+	backgroundWg.Wait()
+
+	// Сначала /readyz начинает отвечать 503, и только после паузы сервер
+	// перестаёт принимать соединения.
+	a.httpServer.SetShuttingDown()
+	a.logger.Info(ctx, fmt.Sprintf("Readiness disabled, draining traffic for %s", a.drainDelay))
+	time.Sleep(a.drainDelay)
+
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
@@ -68,9 +162,35 @@ func (a *App) MustRun(ctx context.Context, port int, timeout time.Duration) {
 
 	}
 
-	a.postgresDb.Close()
-	a.logger.Info(ctx, "Database connection pool closed")
+	if err := a.storage.Close(); err != nil {
+		a.logger.Error(ctx, "storage close error", zap.Error(err))
+	}
+	a.logger.Info(ctx, "Storage closed")
+
+	if err := a.tracing.Shutdown(shutdownCtx); err != nil {
+		a.logger.Error(ctx, "tracing shutdown error", zap.Error(err))
+	}
 
 	wg.Wait()
 	a.logger.Info(ctx, "Server stopped gracefully")
 }
+
+// reloadConfig перечитывает конфигурацию и применяет настройки, которые можно
+// менять без перезапуска: уровень логирования и правила access-лога.
+// Остальные изменения вступят в силу только после рестарта.
+func (a *App) reloadConfig(ctx context.Context) {
+	a.logger.Info(ctx, "SIGHUP received, reloading configuration", zap.String("path", a.configPath))
+
+	cfg, err := config.Load(a.configPath)
+	if err != nil {
+		a.logger.Error(ctx, "config reload failed, keeping current settings", zap.Error(err))
+		return
+	}
+
+	if err := a.logger.SetLevel(cfg.LoggerConfig.EffectiveLevel(cfg.Env)); err != nil {
+		a.logger.Error(ctx, "failed to apply log level", zap.Error(err))
+	}
+	a.httpServer.UpdateAccessLog(cfg.AccessLogConfig)
+
+	a.logger.Info(ctx, "Configuration reloaded", zap.String("log_level", a.logger.Level()))
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// webhookTimeout ограничивает доставку одного уведомления.
+const webhookTimeout = 10 * time.Second
+
+// runBudgetEvaluator проверяет бюджеты сразу после старта и затем с периодом
+// budgetInterval, пока не отменён ctx. Предупреждения, которые не удалось
+// отправить, уйдут при следующей проверке.
+func (a *App) runBudgetEvaluator(ctx context.Context) {
+	ticker := time.NewTicker(a.budgetInterval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := a.budgets.EvaluateBudgets(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			a.logger.Error(ctx, "budget evaluation failed", zap.Error(err))
+		}
+		if sent > 0 {
+			a.logger.Info(ctx, "Budget alerts sent", zap.Int("count", sent))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/adapter"
+	"github.com/I-Van-Radkov/subscription-service/internal/config"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/I-Van-Radkov/subscription-service/migrations"
+	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
+	"github.com/I-Van-Radkov/subscription-service/pkg/logger"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"go.uber.org/zap"
+)
+
+// Storage — хранилище подписок, каталога сервисов с тарифами, центров
+// затрат и бюджетов, выбранное через STORAGE.
+// Postgres и SQLite заполнены только для соответствующего бэкенда.
+type Storage struct {
+	Repo        usecase.SubscriptionRepo
+	Services    usecase.ServiceRepo
+	Plans       usecase.PlanRepo
+	CostCenters usecase.CostCenterRepo
+	Budgets     usecase.BudgetRepo
+	Transactor  usecase.Transactor
+
+	Postgres *postgres.Database
+	SQLite   *sqlite.Database
+}
+
+// OpenStorage открывает хранилище из конфигурации. Им пользуются и сервер,
+// и утилиты из cmd/.
+func OpenStorage(cfg *config.Config, lg logger.Logger) (*Storage, error) {
+	switch cfg.Storage {
+	case config.StorageSQLite:
+		db, err := sqlite.New(cfg.SQLiteConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+		}
+		return &Storage{
+			Repo:        adapter.NewSQLiteSubscriptionRepo(db),
+			Services:    adapter.NewSQLiteServiceRepo(db),
+			Plans:       adapter.NewSQLitePlanRepo(db),
+			CostCenters: adapter.NewSQLiteCostCenterRepo(db),
+			Budgets:     adapter.NewSQLiteBudgetRepo(db),
+			Transactor:  db,
+			SQLite:      db,
+		}, nil
+
+	case config.StorageMemory:
+		store := adapter.NewMemoryStore()
+		lg.Info(context.Background(), "Using in-memory storage, data will be lost on restart")
+		return &Storage{
+			Repo:        adapter.NewMemorySubscriptionRepo(store),
+			Services:    adapter.NewMemoryServiceRepo(store),
+			Plans:       adapter.NewMemoryPlanRepo(store),
+			CostCenters: adapter.NewMemoryCostCenterRepo(store),
+			Budgets:     adapter.NewMemoryBudgetRepo(store),
+			Transactor:  store,
+		}, nil
+
+	default:
+		db, err := postgres.New(cfg.PostgresConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		if err := prepareSchema(cfg, db, lg); err != nil {
+			db.Close()
+			return nil, err
+		}
+		return &Storage{
+			Repo:        adapter.NewSubscriptionRepo(db),
+			Services:    adapter.NewServiceRepo(db),
+			Plans:       adapter.NewPlanRepo(db),
+			CostCenters: adapter.NewCostCenterRepo(db),
+			Budgets:     adapter.NewBudgetRepo(db),
+			Transactor:  db,
+			Postgres:    db,
+		}, nil
+	}
+}
+
+// prepareSchema применяет миграции при AUTO_MIGRATE, а без него только
+// проверяет схему. С базой в состоянии dirty приложение не стартует.
+func prepareSchema(cfg *config.Config, db *postgres.Database, lg logger.Logger) error {
+	ctx := context.Background()
+
+	if cfg.AutoMigrate {
+		migrateCtx, cancel := context.WithTimeout(ctx, cfg.MigrateTimeout)
+		defer cancel()
+
+		from, to, err := db.MigrateUp(migrateCtx, cfg.PostgresConfig, migrations.FS)
+		if err != nil {
+			return fmt.Errorf("failed to migrate database: %w", err)
+		}
+		lg.Info(ctx, "Database schema is up to date", zap.Uint("from_version", from), zap.Uint("version", to))
+		return nil
+	}
+
+	version, err := postgres.CheckMigrations(cfg.PostgresConfig, migrations.FS)
+	if err != nil {
+		return fmt.Errorf("failed to check database schema: %w", err)
+	}
+	latest, err := migrations.Latest()
+	if err != nil {
+		return err
+	}
+	if version != latest {
+		lg.Info(ctx, "Database schema does not match embedded migrations, run migrate up or set AUTO_MIGRATE=true",
+			zap.Uint("version", version), zap.Uint("latest_version", latest))
+	}
+
+	return nil
+}
+
+func (s *Storage) Close() error {
+	if s.Postgres != nil {
+		s.Postgres.Close()
+	}
+	if s.SQLite != nil {
+		return s.SQLite.Close()
+	}
+	return nil
+}
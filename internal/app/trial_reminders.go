@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// runTrialReminders проверяет пробные периоды сразу после старта и затем с
+// периодом trialReminderInterval, пока не отменён ctx. Напоминания, которые
+// не удалось отправить, уйдут при следующей проверке.
+func (a *App) runTrialReminders(ctx context.Context) {
+	ticker := time.NewTicker(a.trialReminderInterval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := a.trialReminders.SendTrialReminders(ctx, time.Now())
+		if err != nil && ctx.Err() == nil {
+			a.logger.Error(ctx, "trial reminders failed", zap.Error(err))
+		}
+		if sent > 0 {
+			a.logger.Info(ctx, "Trial reminders sent", zap.Int("count", sent))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,21 +1,65 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"os"
 	"time"
 
+	v1 "github.com/I-Van-Radkov/subscription-service/internal/controller/http/v1"
 	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
+	"github.com/I-Van-Radkov/subscription-service/pkg/logger"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/I-Van-Radkov/subscription-service/pkg/tracing"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+	StorageSQLite   = "sqlite"
+)
+
 type Config struct {
-	Env string `env:"ENV" env-default:"development"`
+	Env string `yaml:"env" toml:"env" env:"ENV" env-default:"development"`
+
+	Port         int           `yaml:"port" toml:"port" env:"PORT" env-default:"8080"`
+	ReadTimeout  time.Duration `yaml:"read_timeout" toml:"read_timeout" env:"HTTP_READ_TIMEOUT" env-default:"30s"`
+	WriteTimeout time.Duration `yaml:"write_timeout" toml:"write_timeout" env:"HTTP_WRITE_TIMEOUT" env-default:"30s"`
+
+	ShutdownDrainDelay time.Duration `yaml:"shutdown_drain_delay" toml:"shutdown_drain_delay" env:"SHUTDOWN_DRAIN_DELAY" env-default:"5s"`
+
+	// Storage выбирает хранилище подписок: postgres, sqlite или memory (данные в памяти процесса).
+	Storage string `yaml:"storage" toml:"storage" env:"STORAGE" env-default:"postgres"`
+
+	// AutoMigrate применяет встроенные миграции Postgres при старте. MigrateTimeout
+	// ограничивает ожидание блокировки и само применение: по истечении
+	// начатая миграция завершается, следующие не запускаются.
+	AutoMigrate    bool          `yaml:"auto_migrate" toml:"auto_migrate" env:"AUTO_MIGRATE" env-default:"false"`
+	MigrateTimeout time.Duration `yaml:"migrate_timeout" toml:"migrate_timeout" env:"MIGRATE_TIMEOUT" env-default:"5m"`
 
-	Port         int           `env:"PORT"         env-default:"8080"`
-	ReadTimeout  time.Duration `env:"HTTP_READ_TIMEOUT" env-default:"30s"`
-	WriteTimeout time.Duration `env:"HTTP_WRITE_TIMEOUT" env-default:"30s"`
+	// BudgetEvalInterval задаёт период проверки бюджетов; 0 отключает проверку.
+	// Без BudgetAlertWebhookURL предупреждения о бюджетах только пишутся в лог.
+	BudgetEvalInterval    time.Duration `yaml:"budget_eval_interval" toml:"budget_eval_interval" env:"BUDGET_EVAL_INTERVAL" env-default:"1h"`
+	BudgetAlertWebhookURL string        `yaml:"budget_alert_webhook_url" toml:"budget_alert_webhook_url" env:"BUDGET_ALERT_WEBHOOK_URL"`
 
-	postgres.PostgresConfig
+	// TrialReminderInterval задаёт период проверки пробных периодов; 0 отключает
+	// напоминания. Напоминание уходит за TrialReminderLead до первого платного
+	// месяца, без TrialReminderWebhookURL — только в лог.
+	TrialReminderInterval   time.Duration `yaml:"trial_reminder_interval" toml:"trial_reminder_interval" env:"TRIAL_REMINDER_INTERVAL" env-default:"1h"`
+	TrialReminderLead       time.Duration `yaml:"trial_reminder_lead" toml:"trial_reminder_lead" env:"TRIAL_REMINDER_LEAD" env-default:"72h"`
+	TrialReminderWebhookURL string        `yaml:"trial_reminder_webhook_url" toml:"trial_reminder_webhook_url" env:"TRIAL_REMINDER_WEBHOOK_URL"`
+
+	v1.AccessLogConfig      `yaml:"access_log" toml:"access_log"`
+	v1.AdminConfig          `yaml:"admin" toml:"admin"`
+	v1.TLSConfig            `yaml:"tls" toml:"tls"`
+	logger.LoggerConfig     `yaml:"log" toml:"log"`
+	postgres.PostgresConfig `yaml:"postgres" toml:"postgres"`
+	sqlite.SQLiteConfig     `yaml:"sqlite" toml:"sqlite"`
+	tracing.TracingConfig   `yaml:"tracing" toml:"tracing"`
+
+	path string
 }
 
 func ParseConfigFromEnv() (*Config, error) {
@@ -27,3 +71,111 @@ func ParseConfigFromEnv() (*Config, error) {
 
 	return cfg, nil
 }
+
+// Load читает конфигурацию из файла (YAML, TOML, JSON или .env), после чего
+// значения переопределяются переменными окружения. Пустой путь означает
+// конфигурацию только из окружения.
+func Load(path string) (*Config, error) {
+	cfg := &Config{path: path}
+
+	if path == "" {
+		if err := cleanenv.ReadEnv(cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config from env: %w", err)
+		}
+	} else if err := cleanenv.ReadConfig(path, cfg); err != nil {
+		return nil, fmt.Errorf("failed to read config from %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+const defaultConfigPath = "./config/.env"
+
+// ResolvePath выбирает файл конфигурации: явно заданный путь, затем
+// CONFIG_PATH, ENV_PATH и ./config/.env, если он существует. Пустой результат
+// означает конфигурацию только из окружения.
+func ResolvePath(path string) string {
+	if path == "" {
+		path = os.Getenv("CONFIG_PATH")
+	}
+	if path == "" {
+		path = os.Getenv("ENV_PATH")
+	}
+	if path == "" {
+		if _, err := os.Stat(defaultConfigPath); err == nil {
+			path = defaultConfigPath
+		}
+	}
+	return path
+}
+
+func (c *Config) Path() string {
+	return c.path
+}
+
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Port <= 0 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("PORT: must be between 1 and 65535, got %d", c.Port))
+	}
+	if c.ReadTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("HTTP_READ_TIMEOUT: must be positive, got %s", c.ReadTimeout))
+	}
+	if c.WriteTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("HTTP_WRITE_TIMEOUT: must be positive, got %s", c.WriteTimeout))
+	}
+	if c.ShutdownDrainDelay < 0 {
+		errs = append(errs, fmt.Errorf("SHUTDOWN_DRAIN_DELAY: must not be negative, got %s", c.ShutdownDrainDelay))
+	}
+
+	if c.AutoMigrate && c.MigrateTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("MIGRATE_TIMEOUT: must be positive, got %s", c.MigrateTimeout))
+	}
+
+	if c.BudgetEvalInterval < 0 {
+		errs = append(errs, fmt.Errorf("BUDGET_EVAL_INTERVAL: must not be negative, got %s", c.BudgetEvalInterval))
+	}
+	if c.BudgetAlertWebhookURL != "" && !isWebhookURL(c.BudgetAlertWebhookURL) {
+		errs = append(errs, fmt.Errorf("BUDGET_ALERT_WEBHOOK_URL: must be an absolute http(s) URL, got %q", c.BudgetAlertWebhookURL))
+	}
+
+	if c.TrialReminderInterval < 0 {
+		errs = append(errs, fmt.Errorf("TRIAL_REMINDER_INTERVAL: must not be negative, got %s", c.TrialReminderInterval))
+	}
+	if c.TrialReminderInterval > 0 && c.TrialReminderLead <= 0 {
+		errs = append(errs, fmt.Errorf("TRIAL_REMINDER_LEAD: must be positive, got %s", c.TrialReminderLead))
+	}
+	if c.TrialReminderWebhookURL != "" && !isWebhookURL(c.TrialReminderWebhookURL) {
+		errs = append(errs, fmt.Errorf("TRIAL_REMINDER_WEBHOOK_URL: must be an absolute http(s) URL, got %q", c.TrialReminderWebhookURL))
+	}
+
+	switch c.Storage {
+	case StoragePostgres:
+		errs = append(errs, c.PostgresConfig.Validate())
+	case StorageSQLite:
+		errs = append(errs, c.SQLiteConfig.Validate())
+	case StorageMemory:
+	default:
+		errs = append(errs, fmt.Errorf("STORAGE: must be one of %s, %s, %s, got %q", StoragePostgres, StorageSQLite, StorageMemory, c.Storage))
+	}
+
+	errs = append(errs,
+		c.AccessLogConfig.Validate(),
+		c.TLSConfig.Validate(),
+		c.LoggerConfig.Validate(),
+		c.TracingConfig.Validate(),
+	)
+
+	return errors.Join(errs...)
+}
+
+// isWebhookURL сообщает, что raw — абсолютный http(s) адрес.
+func isWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/I-Van-Radkov/subscription-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AdminConfig struct {
+	// Без токена административные эндпоинты не регистрируются.
+	Token string `yaml:"token" toml:"token" env:"ADMIN_TOKEN"`
+}
+
+type LogLevelRequest struct {
+	Level string `json:"level" validate:"required"`
+}
+
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
+
+type AdminHandler struct {
+	logger logger.Logger
+}
+
+func NewAdminHandler(lg logger.Logger) *AdminHandler {
+	return &AdminHandler{
+		logger: lg,
+	}
+}
+
+func AdminAuthMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.Next()
+	}
+}
+
+func (h *AdminHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, LogLevelResponse{Level: h.logger.Level()})
+}
+
+func (h *AdminHandler) SetLogLevel(c *gin.Context) {
+	var inputForm LogLevelRequest
+
+	if err := c.ShouldBindJSON(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.logger.SetLevel(inputForm.Level); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.logger.Info(c.Request.Context(), "log level changed", zap.String("level", h.logger.Level()))
+
+	c.JSON(http.StatusOK, LogLevelResponse{Level: h.logger.Level()})
+}
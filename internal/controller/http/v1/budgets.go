@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+type BudgetUsecase interface {
+	CreateBudget(ctx context.Context, input dto.CreateBudgetRequest) (dto.CreateBudgetResponse, error)
+	GetBudget(ctx context.Context, id string) (dto.GetBudgetResponse, error)
+	GetBudgetsList(ctx context.Context) (dto.GetBudgetListResponse, error)
+	UpdateBudget(ctx context.Context, id string, input dto.UpdateBudgetRequest) (dto.GetBudgetResponse, error)
+	DeleteBudget(ctx context.Context, id string) error
+
+	GetBudgetStatus(ctx context.Context, id string) (dto.BudgetStatus, error)
+	GetBudgetStatuses(ctx context.Context) (dto.GetBudgetStatusListResponse, error)
+}
+
+func (h *HandlerFacade) CreateBudget(c *gin.Context) {
+	ctx, span := startSpan(c, "CreateBudget")
+	defer span.End()
+
+	var inputForm dto.CreateBudgetRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.budgets.CreateBudget(ctx, inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, outputForm)
+}
+
+func (h *HandlerFacade) GetBudget(c *gin.Context) {
+	ctx, span := startSpan(c, "GetBudget")
+	defer span.End()
+
+	outputForm, err := h.budgets.GetBudget(ctx, c.Param("id"))
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) GetBudgetsList(c *gin.Context) {
+	ctx, span := startSpan(c, "GetBudgetsList")
+	defer span.End()
+
+	outputForm, err := h.budgets.GetBudgetsList(ctx)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) UpdateBudget(c *gin.Context) {
+	ctx, span := startSpan(c, "UpdateBudget")
+	defer span.End()
+
+	var inputForm dto.UpdateBudgetRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.budgets.UpdateBudget(ctx, c.Param("id"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) DeleteBudget(c *gin.Context) {
+	ctx, span := startSpan(c, "DeleteBudget")
+	defer span.End()
+
+	if err := h.budgets.DeleteBudget(ctx, c.Param("id")); err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "successful"})
+}
+
+func (h *HandlerFacade) GetBudgetStatus(c *gin.Context) {
+	ctx, span := startSpan(c, "GetBudgetStatus")
+	defer span.End()
+
+	outputForm, err := h.budgets.GetBudgetStatus(ctx, c.Param("id"))
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) GetBudgetStatuses(c *gin.Context) {
+	ctx, span := startSpan(c, "GetBudgetStatuses")
+	defer span.End()
+
+	outputForm, err := h.budgets.GetBudgetStatuses(ctx)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// cancelErrorStatus возвращает 409 для конфликтов с состоянием подписки:
+// она уже отменена или закончилась, отмена не оставляет оплаченных месяцев,
+// отмены нет или она уже вступила в силу. Прочие ошибки — 500.
+func cancelErrorStatus(err error) int {
+	if errors.Is(err, models.ErrAlreadyCancelled) || errors.Is(err, models.ErrNotCancelled) ||
+		errors.Is(err, models.ErrCancellationActive) || errors.Is(err, models.ErrCancelBeforeStart) ||
+		errors.Is(err, models.ErrSubscriptionEnded) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func (h *HandlerFacade) CancelSubscription(c *gin.Context) {
+	ctx, span := startSpan(c, "CancelSubscription")
+	defer span.End()
+
+	var inputForm dto.CancelSubscriptionRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.usecase.CancelSubscription(ctx, c.Param("id"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(cancelErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) UncancelSubscription(c *gin.Context) {
+	ctx, span := startSpan(c, "UncancelSubscription")
+	defer span.End()
+
+	outputForm, err := h.usecase.UncancelSubscription(ctx, c.Param("id"))
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(cancelErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) GetChurnReport(c *gin.Context) {
+	ctx, span := startSpan(c, "GetChurnReport")
+	defer span.End()
+
+	start := c.Query("start_date")
+	end := c.Query("end_date")
+
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date and end_date are required"})
+		return
+	}
+
+	outputForm, err := h.usecase.GetChurnReport(ctx, c.Query("user_id"), start, end)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
@@ -0,0 +1,172 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+type CostCenterUsecase interface {
+	CreateCostCenter(ctx context.Context, input dto.CreateCostCenterRequest) (dto.CreateCostCenterResponse, error)
+	GetCostCenter(ctx context.Context, id string) (dto.GetCostCenterResponse, error)
+	GetCostCentersList(ctx context.Context) (dto.GetCostCenterListResponse, error)
+	UpdateCostCenter(ctx context.Context, id string, input dto.UpdateCostCenterRequest) (dto.GetCostCenterResponse, error)
+	DeleteCostCenter(ctx context.Context, id string) error
+
+	GetAllocations(ctx context.Context, subscriptionID string) (dto.GetAllocationsResponse, error)
+	SetAllocations(ctx context.Context, subscriptionID string, input dto.SetAllocationsRequest) (dto.GetAllocationsResponse, error)
+	GetCostCenterReport(ctx context.Context, userIdStr, start, end string) (dto.GetCostCenterReportResponse, error)
+}
+
+// costCenterErrorStatus отличает занятое название и удаление используемого
+// центра затрат (409) от прочих ошибок.
+func costCenterErrorStatus(err error) int {
+	if errors.Is(err, models.ErrCostCenterNameTaken) || errors.Is(err, models.ErrCostCenterInUse) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func (h *HandlerFacade) CreateCostCenter(c *gin.Context) {
+	ctx, span := startSpan(c, "CreateCostCenter")
+	defer span.End()
+
+	var inputForm dto.CreateCostCenterRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.costs.CreateCostCenter(ctx, inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(costCenterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, outputForm)
+}
+
+func (h *HandlerFacade) GetCostCenter(c *gin.Context) {
+	ctx, span := startSpan(c, "GetCostCenter")
+	defer span.End()
+
+	outputForm, err := h.costs.GetCostCenter(ctx, c.Param("id"))
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) GetCostCentersList(c *gin.Context) {
+	ctx, span := startSpan(c, "GetCostCentersList")
+	defer span.End()
+
+	outputForm, err := h.costs.GetCostCentersList(ctx)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) UpdateCostCenter(c *gin.Context) {
+	ctx, span := startSpan(c, "UpdateCostCenter")
+	defer span.End()
+
+	var inputForm dto.UpdateCostCenterRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.costs.UpdateCostCenter(ctx, c.Param("id"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(costCenterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) DeleteCostCenter(c *gin.Context) {
+	ctx, span := startSpan(c, "DeleteCostCenter")
+	defer span.End()
+
+	if err := h.costs.DeleteCostCenter(ctx, c.Param("id")); err != nil {
+		failSpan(span, err)
+		c.JSON(costCenterErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "successful"})
+}
+
+func (h *HandlerFacade) GetAllocations(c *gin.Context) {
+	ctx, span := startSpan(c, "GetAllocations")
+	defer span.End()
+
+	outputForm, err := h.costs.GetAllocations(ctx, c.Param("id"))
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) SetAllocations(c *gin.Context) {
+	ctx, span := startSpan(c, "SetAllocations")
+	defer span.End()
+
+	var inputForm dto.SetAllocationsRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.costs.SetAllocations(ctx, c.Param("id"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) GetCostCenterReport(c *gin.Context) {
+	ctx, span := startSpan(c, "GetCostCenterReport")
+	defer span.End()
+
+	start := c.Query("start_date")
+	end := c.Query("end_date")
+
+	if start == "" || end == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date and end_date are required"})
+		return
+	}
+
+	outputForm, err := h.costs.GetCostCenterReport(ctx, c.Query("user_id"), start, end)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
@@ -5,29 +5,67 @@ import (
 	"net/http"
 
 	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/I-Van-Radkov/subscription-service/internal/controller/http/v1")
+
 type SubscriptionUsecase interface {
 	CreateSubscription(ctx context.Context, input dto.CreateSubstractionRequest) (dto.CreateSubstractionResponse, error)
-	GetSubscription(ctx context.Context, id string) (dto.GetSubscriptionResponse, error)
+	GetSubscription(ctx context.Context, id, asOf string) (dto.GetSubscriptionResponse, error)
 	UpdateSubscription(ctx context.Context, idString string, input dto.UpdateSubscriptionRequest) (dto.UpdateSubscriptionResponse, error)
 	DeleteSubscription(ctx context.Context, id string) error
-	GetSubscriptionsList(ctx context.Context, userId string) (dto.GetSubsListResponse, error)
-	GetSubscriptionsSum(ctx context.Context, userIdStr, serviceName, start string, end *string) (dto.GetSubSumResponse, error)
+	GetSubscriptionsList(ctx context.Context, input dto.GetSubsListFilter) (dto.GetSubsListResponse, error)
+	GetSubscriptionsSum(ctx context.Context, userIdStr, serviceName, start string, end *string, groupBy string) (dto.GetSubSumResponse, error)
+	GetMembers(ctx context.Context, id string) (dto.GetMembersResponse, error)
+	SetMembers(ctx context.Context, id string, input dto.SetMembersRequest) (dto.GetMembersResponse, error)
+	PauseSubscription(ctx context.Context, id string, input dto.PauseSubscriptionRequest) (dto.GetSubscriptionResponse, error)
+	ResumeSubscription(ctx context.Context, id string, input dto.ResumeSubscriptionRequest) (dto.GetSubscriptionResponse, error)
+	CancelSubscription(ctx context.Context, id string, input dto.CancelSubscriptionRequest) (dto.GetSubscriptionResponse, error)
+	UncancelSubscription(ctx context.Context, id string) (dto.GetSubscriptionResponse, error)
+	GetChurnReport(ctx context.Context, userIdStr, start, end string) (dto.GetChurnReportResponse, error)
+
+	GetTags(ctx context.Context) (dto.GetLabelListResponse, error)
+	RenameTag(ctx context.Context, name string, input dto.RenameLabelRequest) (dto.RenameLabelResponse, error)
+	DeleteTag(ctx context.Context, name string) (dto.RenameLabelResponse, error)
+	GetCategories(ctx context.Context) (dto.GetLabelListResponse, error)
+	RenameCategory(ctx context.Context, name string, input dto.RenameLabelRequest) (dto.RenameLabelResponse, error)
+	DeleteCategory(ctx context.Context, name string) (dto.RenameLabelResponse, error)
 }
 
 type HandlerFacade struct {
-	usecase SubscriptionUsecase
+	usecase  SubscriptionUsecase
+	services ServiceUsecase
+	costs    CostCenterUsecase
+	budgets  BudgetUsecase
 }
 
-func NewHandlerFacade(usecase SubscriptionUsecase) *HandlerFacade {
+func NewHandlerFacade(usecase SubscriptionUsecase, services ServiceUsecase, costs CostCenterUsecase, budgets BudgetUsecase) *HandlerFacade {
 	return &HandlerFacade{
-		usecase: usecase,
+		usecase:  usecase,
+		services: services,
+		costs:    costs,
+		budgets:  budgets,
 	}
 }
 
+func startSpan(c *gin.Context, name string) (context.Context, trace.Span) {
+	return tracer.Start(c.Request.Context(), "HandlerFacade."+name)
+}
+
+func failSpan(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
 func (h *HandlerFacade) CreateSubscription(c *gin.Context) {
+	ctx, span := startSpan(c, "CreateSubscription")
+	defer span.End()
+
 	var inputForm dto.CreateSubstractionRequest
 
 	if err := c.ShouldBind(&inputForm); err != nil {
@@ -35,8 +73,9 @@ func (h *HandlerFacade) CreateSubscription(c *gin.Context) {
 		return
 	}
 
-	outputForm, err := h.usecase.CreateSubscription(c.Request.Context(), inputForm)
+	outputForm, err := h.usecase.CreateSubscription(ctx, inputForm)
 	if err != nil {
+		failSpan(span, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -45,10 +84,14 @@ func (h *HandlerFacade) CreateSubscription(c *gin.Context) {
 }
 
 func (h *HandlerFacade) GetSubscription(c *gin.Context) {
+	ctx, span := startSpan(c, "GetSubscription")
+	defer span.End()
+
 	id := c.Param("id")
 
-	outputForm, err := h.usecase.GetSubscription(c.Request.Context(), id)
+	outputForm, err := h.usecase.GetSubscription(ctx, id, c.Query("as_of"))
 	if err != nil {
+		failSpan(span, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -57,6 +100,9 @@ func (h *HandlerFacade) GetSubscription(c *gin.Context) {
 }
 
 func (h *HandlerFacade) UpdateSubscription(c *gin.Context) {
+	ctx, span := startSpan(c, "UpdateSubscription")
+	defer span.End()
+
 	id := c.Param("id")
 
 	var inputForm dto.UpdateSubscriptionRequest
@@ -66,9 +112,10 @@ func (h *HandlerFacade) UpdateSubscription(c *gin.Context) {
 		return
 	}
 
-	outputForm, err := h.usecase.UpdateSubscription(c.Request.Context(), id, inputForm)
+	outputForm, err := h.usecase.UpdateSubscription(ctx, id, inputForm)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		failSpan(span, err)
+		c.JSON(cancelErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -76,10 +123,14 @@ func (h *HandlerFacade) UpdateSubscription(c *gin.Context) {
 }
 
 func (h *HandlerFacade) DeleteSubscription(c *gin.Context) {
+	ctx, span := startSpan(c, "DeleteSubscription")
+	defer span.End()
+
 	id := c.Param("id")
 
-	err := h.usecase.DeleteSubscription(c.Request.Context(), id)
+	err := h.usecase.DeleteSubscription(ctx, id)
 	if err != nil {
+		failSpan(span, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -88,14 +139,28 @@ func (h *HandlerFacade) DeleteSubscription(c *gin.Context) {
 }
 
 func (h *HandlerFacade) GetSubscriptionsList(c *gin.Context) {
+	ctx, span := startSpan(c, "GetSubscriptionsList")
+	defer span.End()
+
 	userID := c.Query("user_id")
 	if userID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
 		return
 	}
+	if status := c.Query("status"); status != "" && !models.IsStatus(status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown status: " + status})
+		return
+	}
 
-	outputForm, err := h.usecase.GetSubscriptionsList(c.Request.Context(), userID)
+	outputForm, err := h.usecase.GetSubscriptionsList(ctx, dto.GetSubsListFilter{
+		UserID:   userID,
+		Category: c.Query("category"),
+		Tag:      c.Query("tag"),
+		Status:   c.Query("status"),
+		AsOf:     c.Query("as_of"),
+	})
 	if err != nil {
+		failSpan(span, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -104,17 +169,26 @@ func (h *HandlerFacade) GetSubscriptionsList(c *gin.Context) {
 }
 
 func (h *HandlerFacade) GetSubscriptionsSum(c *gin.Context) {
+	ctx, span := startSpan(c, "GetSubscriptionsSum")
+	defer span.End()
+
 	userID := c.Query("user_id")
 	serviceName := c.Query("service_name")
 	start := c.Query("start_date")
 	end := c.Query("end_date")
+	groupBy := c.Query("group_by")
 
 	if start == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date is required"})
 		return
 	}
-	outputForm, err := h.usecase.GetSubscriptionsSum(c.Request.Context(), userID, serviceName, start, &end)
+	if groupBy != "" && groupBy != dto.SumGroupByTag {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group_by must be empty or " + dto.SumGroupByTag})
+		return
+	}
+	outputForm, err := h.usecase.GetSubscriptionsSum(ctx, userID, serviceName, start, &end, groupBy)
 	if err != nil {
+		failSpan(span, err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -0,0 +1,223 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/migrations"
+	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
+	"github.com/I-Van-Radkov/subscription-service/pkg/sqlite"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	checkStatusOK        = "ok"
+	checkStatusFailed    = "failed"
+	checkStatusSaturated = "saturated"
+	checkStatusDegraded  = "degraded"
+
+	readinessTimeout = 2 * time.Second
+)
+
+type DependencyCheck struct {
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Latency string `json:"latency,omitempty"`
+
+	CurrentVersion *uint `json:"current_version,omitempty"`
+	LatestVersion  *uint `json:"latest_version,omitempty"`
+	Dirty          *bool `json:"dirty,omitempty"`
+
+	AcquiredConns *int32   `json:"acquired_conns,omitempty"`
+	MaxConns      *int32   `json:"max_conns,omitempty"`
+	Saturation    *float64 `json:"saturation,omitempty"`
+
+	HealthyReplicas *int     `json:"healthy_replicas,omitempty"`
+	TotalReplicas   *int     `json:"total_replicas,omitempty"`
+	Unhealthy       []string `json:"unhealthy,omitempty"`
+}
+
+type ReadinessResponse struct {
+	Status       string                     `json:"status"`
+	ShuttingDown bool                       `json:"shutting_down"`
+	Checks       map[string]DependencyCheck `json:"checks"`
+}
+
+// HealthHandler проверяет зависимости сервиса. db и sqlite равны nil, если
+// соответствующее хранилище не используется; тогда его проверки пропускаются.
+type HealthHandler struct {
+	db           *postgres.Database
+	sqlite       *sqlite.Database
+	shuttingDown atomic.Bool
+}
+
+func NewHealthHandler(db *postgres.Database, sqliteDB *sqlite.Database) *HealthHandler {
+	return &HealthHandler{
+		db:     db,
+		sqlite: sqliteDB,
+	}
+}
+
+// SetShuttingDown переводит /readyz в состояние not-ready, чтобы балансировщик
+// успел снять трафик до остановки сервера.
+func (h *HealthHandler) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": checkStatusOK})
+}
+
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), readinessTimeout)
+	defer cancel()
+
+	output := ReadinessResponse{
+		Status:       "ready",
+		ShuttingDown: h.shuttingDown.Load(),
+		Checks:       map[string]DependencyCheck{},
+	}
+	if h.db != nil {
+		output.Checks["postgres"] = h.checkPostgres(ctx)
+		output.Checks["migrations"] = h.checkMigrations(ctx)
+		output.Checks["pool"] = h.checkPool()
+		if replicas := h.db.Replicas(); len(replicas) > 0 {
+			output.Checks["replicas"] = checkReplicas(replicas)
+		}
+	}
+	if h.sqlite != nil {
+		output.Checks["sqlite"] = h.checkSQLite(ctx)
+	}
+
+	ready := !output.ShuttingDown
+	for _, check := range output.Checks {
+		if check.Status == checkStatusFailed {
+			ready = false
+		}
+	}
+
+	if !ready {
+		output.Status = "not_ready"
+		c.JSON(http.StatusServiceUnavailable, output)
+		return
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func (h *HealthHandler) checkPostgres(ctx context.Context) DependencyCheck {
+	start := time.Now()
+	if err := h.db.Pool.Ping(ctx); err != nil {
+		return DependencyCheck{Status: checkStatusFailed, Error: err.Error()}
+	}
+
+	return DependencyCheck{
+		Status:  checkStatusOK,
+		Latency: time.Since(start).String(),
+	}
+}
+
+// Миграции SQLite применяются при старте, поэтому достаточно проверить доступность файла.
+func (h *HealthHandler) checkSQLite(ctx context.Context) DependencyCheck {
+	start := time.Now()
+	if err := h.sqlite.Ping(ctx); err != nil {
+		return DependencyCheck{Status: checkStatusFailed, Error: err.Error()}
+	}
+
+	return DependencyCheck{
+		Status:  checkStatusOK,
+		Latency: time.Since(start).String(),
+	}
+}
+
+func (h *HealthHandler) checkMigrations(ctx context.Context) DependencyCheck {
+	latest, err := migrations.Latest()
+	if err != nil {
+		return DependencyCheck{Status: checkStatusFailed, Error: err.Error()}
+	}
+
+	var (
+		current int64
+		dirty   bool
+	)
+	err = h.db.Pool.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&current, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return DependencyCheck{Status: checkStatusFailed, Error: "no migrations applied", LatestVersion: &latest}
+	}
+	if err != nil {
+		return DependencyCheck{Status: checkStatusFailed, Error: err.Error(), LatestVersion: &latest}
+	}
+
+	currentVersion := uint(current)
+	check := DependencyCheck{
+		Status:         checkStatusOK,
+		CurrentVersion: &currentVersion,
+		LatestVersion:  &latest,
+		Dirty:          &dirty,
+	}
+
+	switch {
+	case dirty:
+		check.Status = checkStatusFailed
+		check.Error = "database is in dirty migration state"
+	case currentVersion != latest:
+		check.Status = checkStatusFailed
+		check.Error = "schema version does not match latest embedded migration"
+	}
+
+	return check
+}
+
+func (h *HealthHandler) checkPool() DependencyCheck {
+	stat := h.db.Pool.Stat()
+	acquired := stat.AcquiredConns()
+	maxConns := stat.MaxConns()
+
+	var saturation float64
+	if maxConns > 0 {
+		saturation = float64(acquired) / float64(maxConns)
+	}
+
+	check := DependencyCheck{
+		Status:        checkStatusOK,
+		AcquiredConns: &acquired,
+		MaxConns:      &maxConns,
+		Saturation:    &saturation,
+	}
+	// Полностью занятый пул не делает сервис неготовым, но это видно в ответе.
+	if saturation >= 1 {
+		check.Status = checkStatusSaturated
+	}
+
+	return check
+}
+
+// Недоступные реплики не делают сервис неготовым: чтения уходят в primary.
+func checkReplicas(replicas []postgres.ReplicaStatus) DependencyCheck {
+	total := len(replicas)
+	healthy := 0
+	var unhealthy []string
+	for _, r := range replicas {
+		if r.Healthy {
+			healthy++
+			continue
+		}
+		unhealthy = append(unhealthy, r.Addr)
+	}
+
+	check := DependencyCheck{
+		Status:          checkStatusOK,
+		HealthyReplicas: &healthy,
+		TotalReplicas:   &total,
+		Unhealthy:       unhealthy,
+	}
+	if healthy < total {
+		check.Status = checkStatusDegraded
+	}
+
+	return check
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *HandlerFacade) GetTags(c *gin.Context) {
+	h.getLabels(c, "GetTags", h.usecase.GetTags)
+}
+
+func (h *HandlerFacade) RenameTag(c *gin.Context) {
+	h.renameLabel(c, "RenameTag", h.usecase.RenameTag)
+}
+
+func (h *HandlerFacade) DeleteTag(c *gin.Context) {
+	h.deleteLabel(c, "DeleteTag", h.usecase.DeleteTag)
+}
+
+func (h *HandlerFacade) GetCategories(c *gin.Context) {
+	h.getLabels(c, "GetCategories", h.usecase.GetCategories)
+}
+
+func (h *HandlerFacade) RenameCategory(c *gin.Context) {
+	h.renameLabel(c, "RenameCategory", h.usecase.RenameCategory)
+}
+
+func (h *HandlerFacade) DeleteCategory(c *gin.Context) {
+	h.deleteLabel(c, "DeleteCategory", h.usecase.DeleteCategory)
+}
+
+func (h *HandlerFacade) getLabels(c *gin.Context, name string, list func(context.Context) (dto.GetLabelListResponse, error)) {
+	ctx, span := startSpan(c, name)
+	defer span.End()
+
+	outputForm, err := list(ctx)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) renameLabel(c *gin.Context, name string, rename func(context.Context, string, dto.RenameLabelRequest) (dto.RenameLabelResponse, error)) {
+	ctx, span := startSpan(c, name)
+	defer span.End()
+
+	var inputForm dto.RenameLabelRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := rename(ctx, c.Param("name"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) deleteLabel(c *gin.Context, name string, del func(context.Context, string) (dto.RenameLabelResponse, error)) {
+	ctx, span := startSpan(c, name)
+	defer span.End()
+
+	outputForm, err := del(ctx, c.Param("name"))
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
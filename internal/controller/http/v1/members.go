@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *HandlerFacade) GetMembers(c *gin.Context) {
+	ctx, span := startSpan(c, "GetMembers")
+	defer span.End()
+
+	outputForm, err := h.usecase.GetMembers(ctx, c.Param("id"))
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) SetMembers(c *gin.Context) {
+	ctx, span := startSpan(c, "SetMembers")
+	defer span.End()
+
+	var inputForm dto.SetMembersRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.usecase.SetMembers(ctx, c.Param("id"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
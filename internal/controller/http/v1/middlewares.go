@@ -1,19 +1,182 @@
 package v1
 
 import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	postgres "github.com/I-Van-Radkov/subscription-service/pkg/db"
 	"github.com/I-Van-Radkov/subscription-service/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	requestIDHeader     = "X-Request-ID"
+	principalContextKey = "principal"
+	anonymousPrincipal  = "anonymous"
+	redactedValue       = "[REDACTED]"
 )
 
+type AccessLogConfig struct {
+	RedactQueryParams []string `yaml:"redact_query" toml:"redact_query" env:"ACCESS_LOG_REDACT_QUERY" env-separator:"," env-default:"token,api_key,password"`
+	RedactHeaders     []string `yaml:"redact_headers" toml:"redact_headers" env:"ACCESS_LOG_REDACT_HEADERS" env-separator:"," env-default:"Authorization,Cookie,Set-Cookie,X-Api-Key"`
+	// Доля успешных GET-запросов, попадающих в лог: 1 — все, 0 — ни одного.
+	SuccessGetSampleRate float64 `yaml:"get_sample_rate" toml:"get_sample_rate" env:"ACCESS_LOG_GET_SAMPLE_RATE" env-default:"1"`
+}
+
 func LoggingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		reqID := c.Request.Header.Get("x-request-id")
+		reqID := c.Request.Header.Get(requestIDHeader)
 		if reqID == "" {
 			reqID = uuid.NewString()
 		}
+		c.Header(requestIDHeader, reqID)
+
 		ctx := logger.WithRequestID(c.Request.Context(), reqID)
 		c.Request = c.Request.WithContext(ctx)
 		c.Next()
 	}
 }
+
+// ReadYourWritesMiddleware открывает для запроса сессию, в которой чтения
+// после записи идут в primary, а не в реплику.
+func ReadYourWritesMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(postgres.WithSession(c.Request.Context()))
+		c.Next()
+	}
+}
+
+func (c AccessLogConfig) Validate() error {
+	if c.SuccessGetSampleRate < 0 || c.SuccessGetSampleRate > 1 {
+		return fmt.Errorf("ACCESS_LOG_GET_SAMPLE_RATE: must be between 0 and 1, got %v", c.SuccessGetSampleRate)
+	}
+	return nil
+}
+
+type accessLogRules struct {
+	redactQuery   map[string]struct{}
+	redactHeaders map[string]struct{}
+	sampleRate    float64
+}
+
+func newAccessLogRules(cfg AccessLogConfig) *accessLogRules {
+	rules := &accessLogRules{
+		redactQuery:   make(map[string]struct{}, len(cfg.RedactQueryParams)),
+		redactHeaders: make(map[string]struct{}, len(cfg.RedactHeaders)),
+		sampleRate:    cfg.SuccessGetSampleRate,
+	}
+	for _, p := range cfg.RedactQueryParams {
+		rules.redactQuery[strings.ToLower(strings.TrimSpace(p))] = struct{}{}
+	}
+	for _, h := range cfg.RedactHeaders {
+		rules.redactHeaders[http.CanonicalHeaderKey(strings.TrimSpace(h))] = struct{}{}
+	}
+	return rules
+}
+
+// AccessLogger пишет строку лога на каждый запрос. Правила редактирования
+// и сэмплирования можно заменить на лету через Update.
+type AccessLogger struct {
+	logger logger.Logger
+	rules  atomic.Pointer[accessLogRules]
+}
+
+func NewAccessLogger(lg logger.Logger, cfg AccessLogConfig) *AccessLogger {
+	a := &AccessLogger{logger: lg}
+	a.Update(cfg)
+	return a
+}
+
+func (a *AccessLogger) Update(cfg AccessLogConfig) {
+	a.rules.Store(newAccessLogRules(cfg))
+}
+
+func (a *AccessLogger) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if isProbe(c.Request) {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+		latency := time.Since(start)
+
+		rules := a.rules.Load()
+
+		status := c.Writer.Status()
+		if c.Request.Method == http.MethodGet && status >= 200 && status < 300 &&
+			rules.sampleRate < 1 && rand.Float64() >= rules.sampleRate {
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		size := c.Writer.Size()
+		if size < 0 {
+			size = 0
+		}
+
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("route", route),
+			zap.Int("status", status),
+			zap.Duration("latency", latency),
+			zap.Int("response_size", size),
+			zap.String("principal", principalFrom(c)),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("query", redactQueryString(c.Request.URL.Query(), rules.redactQuery)),
+			zap.Any("headers", redactHeaderValues(c.Request.Header, rules.redactHeaders)),
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.String("errors", c.Errors.String()))
+		}
+
+		if status >= http.StatusInternalServerError {
+			a.logger.Error(c.Request.Context(), "http request", fields...)
+			return
+		}
+		a.logger.Info(c.Request.Context(), "http request", fields...)
+	}
+}
+
+func principalFrom(c *gin.Context) string {
+	if p := c.GetString(principalContextKey); p != "" {
+		return p
+	}
+	return anonymousPrincipal
+}
+
+func redactQueryString(query url.Values, redact map[string]struct{}) string {
+	for key, values := range query {
+		if _, ok := redact[strings.ToLower(key)]; !ok {
+			continue
+		}
+		for i := range values {
+			values[i] = redactedValue
+		}
+	}
+	return query.Encode()
+}
+
+func redactHeaderValues(header http.Header, redact map[string]struct{}) map[string]string {
+	out := make(map[string]string, len(header))
+	for key, values := range header {
+		if _, ok := redact[key]; ok {
+			out[key] = redactedValue
+			continue
+		}
+		out[key] = strings.Join(values, ", ")
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// pauseErrorStatus отличает повторную паузу и возобновление подписки без
+// паузы (409) от прочих ошибок.
+func pauseErrorStatus(err error) int {
+	if errors.Is(err, models.ErrAlreadyPaused) || errors.Is(err, models.ErrNotPaused) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// PauseSubscription принимает и пустое тело: тогда пауза начинается с
+// текущего месяца и длится до возобновления.
+func (h *HandlerFacade) PauseSubscription(c *gin.Context) {
+	ctx, span := startSpan(c, "PauseSubscription")
+	defer span.End()
+
+	var inputForm dto.PauseSubscriptionRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.usecase.PauseSubscription(ctx, c.Param("id"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(pauseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+// ResumeSubscription принимает и пустое тело: тогда подписка возобновляется
+// с текущего месяца.
+func (h *HandlerFacade) ResumeSubscription(c *gin.Context) {
+	ctx, span := startSpan(c, "ResumeSubscription")
+	defer span.End()
+
+	var inputForm dto.ResumeSubscriptionRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.usecase.ResumeSubscription(ctx, c.Param("id"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(pauseErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
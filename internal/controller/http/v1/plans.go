@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *HandlerFacade) CreatePlan(c *gin.Context) {
+	ctx, span := startSpan(c, "CreatePlan")
+	defer span.End()
+
+	var inputForm dto.CreatePlanRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.services.CreatePlan(ctx, c.Param("id"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(serviceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, outputForm)
+}
+
+func (h *HandlerFacade) GetPlan(c *gin.Context) {
+	ctx, span := startSpan(c, "GetPlan")
+	defer span.End()
+
+	outputForm, err := h.services.GetPlan(ctx, c.Param("id"))
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) UpdatePlan(c *gin.Context) {
+	ctx, span := startSpan(c, "UpdatePlan")
+	defer span.End()
+
+	var inputForm dto.UpdatePlanRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.services.UpdatePlan(ctx, c.Param("id"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(serviceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) DeletePlan(c *gin.Context) {
+	ctx, span := startSpan(c, "DeletePlan")
+	defer span.End()
+
+	if err := h.services.DeletePlan(ctx, c.Param("id")); err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "successful"})
+}
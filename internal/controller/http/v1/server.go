@@ -6,18 +6,29 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/I-Van-Radkov/subscription-service/internal/adapter"
-	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/I-Van-Radkov/subscription-service/pkg/logger"
 	"github.com/gin-gonic/gin"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
+const serviceName = "subscription-service"
+
 type Server struct {
-	srv *http.Server
-	db  *pgxpool.Pool
+	srv       *http.Server
+	usecase   SubscriptionUsecase
+	services  ServiceUsecase
+	costs     CostCenterUsecase
+	budgets   BudgetUsecase
+	health    *HealthHandler
+	logger    logger.Logger
+	accessLog *AccessLogger
+	admin     AdminConfig
+
+	certs       *certReloader
+	stopWatcher context.CancelFunc
 }
 
-func NewServer(port int, readTimeout, writeTimeout time.Duration, db *pgxpool.Pool) *Server {
+func NewServer(port int, readTimeout, writeTimeout time.Duration, uc SubscriptionUsecase, services ServiceUsecase, costs CostCenterUsecase, budgets BudgetUsecase, health *HealthHandler, lg logger.Logger, accessLog AccessLogConfig, admin AdminConfig) *Server {
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%v", port),
 		ReadTimeout:  readTimeout,
@@ -26,19 +37,38 @@ func NewServer(port int, readTimeout, writeTimeout time.Duration, db *pgxpool.Po
 	}
 
 	return &Server{
-		srv: srv,
-		db:  db,
+		srv:       srv,
+		usecase:   uc,
+		services:  services,
+		costs:     costs,
+		budgets:   budgets,
+		health:    health,
+		logger:    lg,
+		accessLog: NewAccessLogger(lg, accessLog),
+		admin:     admin,
 	}
 }
 
-func (s *Server) RegisterHandlers() error {
-	subRepo := adapter.NewSubscriptionRepo(s.db)
-	subUseCase := usecase.NewSubscriptionUsecase(subRepo)
+func isProbe(r *http.Request) bool {
+	return r.URL.Path == "/healthz" || r.URL.Path == "/readyz"
+}
 
-	handler := NewHandlerFacade(subUseCase)
+func traceFilter(r *http.Request) bool {
+	return !isProbe(r)
+}
+
+func (s *Server) RegisterHandlers() error {
+	handler := NewHandlerFacade(s.usecase, s.services, s.costs, s.budgets)
 
 	router := gin.New()
+	router.Use(otelgin.Middleware(serviceName, otelgin.WithFilter(traceFilter)))
 	router.Use(LoggingMiddleware())
+	router.Use(ClientCertPrincipalMiddleware())
+	router.Use(ReadYourWritesMiddleware())
+	router.Use(s.accessLog.Middleware())
+
+	router.GET("/healthz", s.health.Liveness)
+	router.GET("/readyz", s.health.Readiness)
 
 	api := router.Group("/api/v1")
 	{
@@ -48,6 +78,58 @@ func (s *Server) RegisterHandlers() error {
 		api.DELETE("/subscriptions/:id", handler.DeleteSubscription)
 		api.GET("/subscriptions", handler.GetSubscriptionsList)
 		api.GET("/subscriptions/summary", handler.GetSubscriptionsSum)
+		api.GET("/subscriptions/churn", handler.GetChurnReport)
+		api.GET("/subscriptions/:id/members", handler.GetMembers)
+		api.PUT("/subscriptions/:id/members", handler.SetMembers)
+		api.POST("/subscriptions/:id/pause", handler.PauseSubscription)
+		api.POST("/subscriptions/:id/resume", handler.ResumeSubscription)
+		api.POST("/subscriptions/:id/cancel", handler.CancelSubscription)
+		api.POST("/subscriptions/:id/uncancel", handler.UncancelSubscription)
+		api.GET("/subscriptions/:id/allocations", handler.GetAllocations)
+		api.PUT("/subscriptions/:id/allocations", handler.SetAllocations)
+
+		api.GET("/tags", handler.GetTags)
+		api.PUT("/tags/:name", handler.RenameTag)
+		api.DELETE("/tags/:name", handler.DeleteTag)
+		api.GET("/categories", handler.GetCategories)
+		api.PUT("/categories/:name", handler.RenameCategory)
+		api.DELETE("/categories/:name", handler.DeleteCategory)
+
+		api.POST("/services", handler.CreateService)
+		api.GET("/services", handler.GetServicesList)
+		api.GET("/services/:id", handler.GetService)
+		api.PUT("/services/:id", handler.UpdateService)
+		api.DELETE("/services/:id", handler.DeleteService)
+		api.POST("/services/:id/plans", handler.CreatePlan)
+
+		api.GET("/plans/:id", handler.GetPlan)
+		api.PUT("/plans/:id", handler.UpdatePlan)
+		api.DELETE("/plans/:id", handler.DeletePlan)
+
+		api.POST("/cost-centers", handler.CreateCostCenter)
+		api.GET("/cost-centers", handler.GetCostCentersList)
+		api.GET("/cost-centers/report", handler.GetCostCenterReport)
+		api.GET("/cost-centers/:id", handler.GetCostCenter)
+		api.PUT("/cost-centers/:id", handler.UpdateCostCenter)
+		api.DELETE("/cost-centers/:id", handler.DeleteCostCenter)
+
+		api.POST("/budgets", handler.CreateBudget)
+		api.GET("/budgets", handler.GetBudgetsList)
+		api.GET("/budgets/status", handler.GetBudgetStatuses)
+		api.GET("/budgets/:id", handler.GetBudget)
+		api.PUT("/budgets/:id", handler.UpdateBudget)
+		api.DELETE("/budgets/:id", handler.DeleteBudget)
+		api.GET("/budgets/:id/status", handler.GetBudgetStatus)
+	}
+
+	if s.admin.Token != "" {
+		adminHandler := NewAdminHandler(s.logger)
+
+		admin := router.Group("/admin", AdminAuthMiddleware(s.admin.Token))
+		{
+			admin.GET("/log-level", adminHandler.GetLogLevel)
+			admin.PUT("/log-level", adminHandler.SetLogLevel)
+		}
 	}
 
 	s.srv.Handler = router
@@ -55,10 +137,43 @@ func (s *Server) RegisterHandlers() error {
 	return nil
 }
 
+// ConfigureTLS включает HTTPS с автоматической перезагрузкой сертификатов
+// и, при необходимости, проверкой клиентских сертификатов.
+func (s *Server) ConfigureTLS(cfg TLSConfig) error {
+	certs, err := newCertReloader(cfg, s.logger)
+	if err != nil {
+		return err
+	}
+
+	s.certs = certs
+	s.srv.TLSConfig = certs.tlsConfig()
+
+	return nil
+}
+
 func (s *Server) Start() error {
-	return s.srv.ListenAndServe()
+	if s.certs == nil {
+		return s.srv.ListenAndServe()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopWatcher = cancel
+	go s.certs.watch(ctx)
+
+	return s.srv.ListenAndServeTLS("", "")
+}
+
+func (s *Server) UpdateAccessLog(cfg AccessLogConfig) {
+	s.accessLog.Update(cfg)
+}
+
+func (s *Server) SetShuttingDown() {
+	s.health.SetShuttingDown()
 }
 
 func (s *Server) Stop(ctx context.Context) error {
+	if s.stopWatcher != nil {
+		s.stopWatcher()
+	}
 	return s.srv.Shutdown(ctx)
 }
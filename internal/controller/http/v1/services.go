@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceUsecase interface {
+	CreateService(ctx context.Context, input dto.CreateServiceRequest) (dto.CreateServiceResponse, error)
+	GetService(ctx context.Context, id string) (dto.GetServiceResponse, error)
+	GetServicesList(ctx context.Context) (dto.GetServiceListResponse, error)
+	UpdateService(ctx context.Context, id string, input dto.UpdateServiceRequest) (dto.GetServiceResponse, error)
+	DeleteService(ctx context.Context, id string) error
+
+	CreatePlan(ctx context.Context, serviceID string, input dto.CreatePlanRequest) (dto.CreatePlanResponse, error)
+	GetPlan(ctx context.Context, id string) (dto.GetPlanResponse, error)
+	UpdatePlan(ctx context.Context, id string, input dto.UpdatePlanRequest) (dto.GetPlanResponse, error)
+	DeletePlan(ctx context.Context, id string) error
+}
+
+// serviceErrorStatus отличает занятое название сервиса или тарифа (409) от
+// прочих ошибок.
+func serviceErrorStatus(err error) int {
+	if errors.Is(err, models.ErrServiceNameTaken) || errors.Is(err, models.ErrPlanNameTaken) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func (h *HandlerFacade) CreateService(c *gin.Context) {
+	ctx, span := startSpan(c, "CreateService")
+	defer span.End()
+
+	var inputForm dto.CreateServiceRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.services.CreateService(ctx, inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(serviceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, outputForm)
+}
+
+func (h *HandlerFacade) GetService(c *gin.Context) {
+	ctx, span := startSpan(c, "GetService")
+	defer span.End()
+
+	outputForm, err := h.services.GetService(ctx, c.Param("id"))
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) GetServicesList(c *gin.Context) {
+	ctx, span := startSpan(c, "GetServicesList")
+	defer span.End()
+
+	outputForm, err := h.services.GetServicesList(ctx)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) UpdateService(c *gin.Context) {
+	ctx, span := startSpan(c, "UpdateService")
+	defer span.End()
+
+	var inputForm dto.UpdateServiceRequest
+
+	if err := c.ShouldBind(&inputForm); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	outputForm, err := h.services.UpdateService(ctx, c.Param("id"), inputForm)
+	if err != nil {
+		failSpan(span, err)
+		c.JSON(serviceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, outputForm)
+}
+
+func (h *HandlerFacade) DeleteService(c *gin.Context) {
+	ctx, span := startSpan(c, "DeleteService")
+	defer span.End()
+
+	if err := h.services.DeleteService(ctx, c.Param("id")); err != nil {
+		failSpan(span, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": "successful"})
+}
@@ -0,0 +1,211 @@
+package v1
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	ClientAuthNone    = "none"
+	ClientAuthRequest = "request"
+	ClientAuthRequire = "require"
+)
+
+type TLSConfig struct {
+	// TLS включается, если задан CertFile.
+	CertFile       string        `yaml:"cert_file" toml:"cert_file" env:"TLS_CERT_FILE"`
+	KeyFile        string        `yaml:"key_file" toml:"key_file" env:"TLS_KEY_FILE"`
+	ClientCAFile   string        `yaml:"client_ca_file" toml:"client_ca_file" env:"TLS_CLIENT_CA_FILE"`
+	ClientAuth     string        `yaml:"client_auth" toml:"client_auth" env:"TLS_CLIENT_AUTH" env-default:"none"`
+	ReloadInterval time.Duration `yaml:"reload_interval" toml:"reload_interval" env:"TLS_RELOAD_INTERVAL" env-default:"30s"`
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+func (c TLSConfig) Validate() error {
+	var errs []error
+
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		errs = append(errs, errors.New("TLS_CERT_FILE and TLS_KEY_FILE must be set together"))
+	}
+
+	switch c.ClientAuth {
+	case "", ClientAuthNone:
+	case ClientAuthRequest, ClientAuthRequire:
+		if !c.Enabled() {
+			errs = append(errs, fmt.Errorf("TLS_CLIENT_AUTH: %q requires TLS_CERT_FILE", c.ClientAuth))
+		}
+		if c.ClientCAFile == "" {
+			errs = append(errs, fmt.Errorf("TLS_CLIENT_CA_FILE: required when TLS_CLIENT_AUTH=%s", c.ClientAuth))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("TLS_CLIENT_AUTH: must be one of none, request, require, got %q", c.ClientAuth))
+	}
+
+	if c.Enabled() && c.ReloadInterval <= 0 {
+		errs = append(errs, fmt.Errorf("TLS_RELOAD_INTERVAL: must be positive, got %s", c.ReloadInterval))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c TLSConfig) clientAuthType() tls.ClientAuthType {
+	switch c.ClientAuth {
+	case ClientAuthRequest:
+		return tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		return tls.RequireAndVerifyClientCert
+	default:
+		return tls.NoClientCert
+	}
+}
+
+// certReloader держит актуальные сертификат сервера и пул CA клиентов и
+// перечитывает их, когда файлы на диске меняются.
+type certReloader struct {
+	cfg    TLSConfig
+	logger logger.Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+func newCertReloader(cfg TLSConfig, lg logger.Logger) (*certReloader, error) {
+	r := &certReloader{
+		cfg:      cfg,
+		logger:   lg,
+		modTimes: make(map[string]time.Time),
+	}
+
+	if _, err := r.reloadIfChanged(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *certReloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+func (r *certReloader) reloadIfChanged() (bool, error) {
+	modTimes := make(map[string]time.Time, 3)
+	changed := false
+	for _, f := range r.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			return false, fmt.Errorf("failed to stat %s: %w", f, err)
+		}
+		modTimes[f] = info.ModTime()
+		if !info.ModTime().Equal(r.modTimes[f]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read client CA bundle: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return false, fmt.Errorf("no certificates found in client CA bundle %s", r.cfg.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.mu.Unlock()
+
+	return true, nil
+}
+
+func (r *certReloader) watch(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.reloadIfChanged()
+			if err != nil {
+				// Оставляем предыдущие сертификаты, пока файлы не придут в порядок.
+				r.logger.Error(ctx, "failed to reload TLS certificates", zap.Error(err))
+				continue
+			}
+			if reloaded {
+				r.logger.Info(ctx, "TLS certificates reloaded")
+			}
+		}
+	}
+}
+
+func (r *certReloader) tlsConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				NextProtos:   []string{"h2", "http/1.1"},
+				Certificates: []tls.Certificate{*r.cert},
+				ClientAuth:   r.cfg.clientAuthType(),
+				ClientCAs:    r.clientCAs,
+			}, nil
+		},
+	}
+}
+
+// ClientCertPrincipalMiddleware делает субъект проверенного клиентского
+// сертификата аутентифицированным принципалом запроса.
+func ClientCertPrincipalMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state := c.Request.TLS
+		if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+			c.Next()
+			return
+		}
+
+		subject := state.VerifiedChains[0][0].Subject
+		principal := subject.CommonName
+		if principal == "" {
+			principal = subject.String()
+		}
+
+		c.Set(principalContextKey, principal)
+		c.Next()
+	}
+}
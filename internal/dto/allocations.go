@@ -0,0 +1,20 @@
+package dto
+
+// Allocation — доля подписки, относимая на центр затрат. В ответах
+// заполнено и название центра.
+type Allocation struct {
+	CostCenterID   string `json:"cost_center_id" validate:"required,uuid4"`
+	CostCenterName string `json:"cost_center_name,omitempty"`
+	Percent        int    `json:"percent" validate:"required,min=1,max=100"`
+}
+
+// SetAllocationsRequest заменяет распределение подписки целиком: доли
+// должны давать в сумме 100, пустой список снимает распределение.
+type SetAllocationsRequest struct {
+	Allocations []Allocation `json:"allocations"`
+}
+
+type GetAllocationsResponse struct {
+	SubscriptionID string       `json:"subscription_id"`
+	Allocations    []Allocation `json:"allocations"`
+}
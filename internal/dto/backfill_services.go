@@ -0,0 +1,13 @@
+package dto
+
+type BackfillServiceLink struct {
+	Name    string `json:"name"`
+	Service string `json:"service"`
+	Created bool   `json:"created"`
+	Count   int    `json:"count"`
+}
+
+type BackfillServicesResponse struct {
+	Total int                   `json:"total"`
+	Links []BackfillServiceLink `json:"links"`
+}
@@ -0,0 +1,60 @@
+package dto
+
+// CreateBudgetRequest: scope_value — user_id для scope = user и тег для
+// scope = tag, у бюджета организации он пустой. Без thresholds
+// предупреждения отправляются на 50, 80 и 100%.
+type CreateBudgetRequest struct {
+	Name       string `json:"name" validate:"required"`
+	Amount     int    `json:"amount" validate:"required,min=1"`
+	Scope      string `json:"scope" validate:"required,oneof=user tag organization"`
+	ScopeValue string `json:"scope_value,omitempty"`
+	Period     string `json:"period" validate:"required,oneof=month year"`
+	Thresholds []int  `json:"thresholds,omitempty"`
+}
+
+type CreateBudgetResponse struct {
+	ID string `json:"id" validate:"required,uuid4"`
+}
+
+// UpdateBudgetRequest меняет только переданные поля.
+type UpdateBudgetRequest struct {
+	Name       *string `json:"name,omitempty"`
+	Amount     *int    `json:"amount,omitempty"`
+	Thresholds *[]int  `json:"thresholds,omitempty"`
+}
+
+type GetBudgetResponse struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Amount     int    `json:"amount"`
+	Scope      string `json:"scope"`
+	ScopeValue string `json:"scope_value,omitempty"`
+	Period     string `json:"period"`
+	Thresholds []int  `json:"thresholds"`
+}
+
+type GetBudgetListResponse struct {
+	Total int                 `json:"total"`
+	List  []GetBudgetResponse `json:"list"`
+}
+
+// BudgetStatus — расходы текущего периода бюджета. Actual — расходы
+// прошедших и текущего месяцев, Committed — расходы всего периода по уже
+// оформленным подпискам; Percent и пороги считаются от Committed.
+type BudgetStatus struct {
+	Budget            GetBudgetResponse `json:"budget"`
+	PeriodStart       string            `json:"period_start"`
+	PeriodEnd         string            `json:"period_end"`
+	Actual            int               `json:"actual"`
+	Committed         int               `json:"committed"`
+	Remaining         int               `json:"remaining"`
+	Percent           int               `json:"percent"`
+	Exceeded          bool              `json:"exceeded"`
+	ReachedThresholds []int             `json:"reached_thresholds"`
+	AlertedThresholds []int             `json:"alerted_thresholds"`
+}
+
+type GetBudgetStatusListResponse struct {
+	Total int            `json:"total"`
+	List  []BudgetStatus `json:"list"`
+}
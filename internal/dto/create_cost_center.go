@@ -0,0 +1,9 @@
+package dto
+
+type CreateCostCenterRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type CreateCostCenterResponse struct {
+	ID string `json:"id" validate:"required,uuid4"`
+}
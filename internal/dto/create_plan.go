@@ -0,0 +1,12 @@
+package dto
+
+type CreatePlanRequest struct {
+	Name          string `json:"name" validate:"required"`
+	Price         int    `json:"price" validate:"required,min=1"`
+	BillingPeriod string `json:"billing_period" validate:"omitempty,oneof=month year"`
+	Default       bool   `json:"default,omitempty"`
+}
+
+type CreatePlanResponse struct {
+	ID string `json:"id" validate:"required,uuid4"`
+}
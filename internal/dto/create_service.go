@@ -0,0 +1,23 @@
+package dto
+
+// ServicePlan — тариф в описании сервиса. В ответах id заполнен, а price —
+// цена, действующая в текущем месяце.
+type ServicePlan struct {
+	ID            string `json:"id,omitempty"`
+	Name          string `json:"name"`
+	Price         int    `json:"price"`
+	BillingPeriod string `json:"billing_period,omitempty"`
+	Default       bool   `json:"default,omitempty"`
+}
+
+type CreateServiceRequest struct {
+	Name     string        `json:"name" validate:"required"`
+	Aliases  []string      `json:"aliases,omitempty"`
+	LogoURL  string        `json:"logo_url,omitempty"`
+	Category string        `json:"category,omitempty"`
+	Plans    []ServicePlan `json:"plans,omitempty"`
+}
+
+type CreateServiceResponse struct {
+	ID string `json:"id" validate:"required,uuid4"`
+}
@@ -1,11 +1,22 @@
 package dto
 
+// CreateSubstractionRequest: price можно не указывать для сервиса из
+// каталога — тогда берётся цена тарифа по умолчанию. plan_id задаёт тариф
+// явно: сервис, цена и период оплаты берутся из него, а service_name можно
+// не передавать. trial_end_date — последний бесплатный месяц, intro_price
+// действует intro_months месяцев после пробного периода.
 type CreateSubstractionRequest struct {
-	ServiceName string  `json:"service_name" validate:"required"`
-	Price       int     `json:"price" validate:"required,min=1"`
-	UserID      string  `json:"user_id" validate:"required,uuid4"`
-	StartDate   string  `json:"start_date" validate:"required"`
-	EndDate     *string `json:"end_date,omitempty"`
+	ServiceName string   `json:"service_name" validate:"required_without=PlanID"`
+	PlanID      string   `json:"plan_id,omitempty" validate:"omitempty,uuid4"`
+	Price       int      `json:"price" validate:"omitempty,min=1"`
+	UserID      string   `json:"user_id" validate:"required,uuid4"`
+	StartDate   string   `json:"start_date" validate:"required"`
+	EndDate     *string  `json:"end_date,omitempty"`
+	TrialEnd    *string  `json:"trial_end_date,omitempty"`
+	IntroPrice  *int     `json:"intro_price,omitempty" validate:"omitempty,min=1"`
+	IntroMonths int      `json:"intro_months,omitempty" validate:"omitempty,min=1"`
+	Category    string   `json:"category,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
 }
 
 type CreateSubstractionResponse struct {
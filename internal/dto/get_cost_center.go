@@ -0,0 +1,11 @@
+package dto
+
+type GetCostCenterResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type GetCostCenterListResponse struct {
+	Total int                     `json:"total"`
+	List  []GetCostCenterResponse `json:"list"`
+}
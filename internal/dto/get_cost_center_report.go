@@ -0,0 +1,23 @@
+package dto
+
+// CostCenterAmount — расходы центра затрат. Без CostCenterID — расходы
+// подписок, не распределённых по центрам.
+type CostCenterAmount struct {
+	CostCenterID   string `json:"cost_center_id,omitempty"`
+	CostCenterName string `json:"cost_center_name,omitempty"`
+	Amount         int    `json:"amount"`
+}
+
+// CostCenterReportMonth — расходы одного месяца, посчитанные как сумма
+// подписок за этот месяц, и их распределение по центрам затрат.
+type CostCenterReportMonth struct {
+	Month       string             `json:"month"`
+	Total       int                `json:"total"`
+	CostCenters []CostCenterAmount `json:"cost_centers"`
+}
+
+type GetCostCenterReportResponse struct {
+	Total       int                     `json:"total"`
+	CostCenters []CostCenterAmount      `json:"cost_centers"`
+	Months      []CostCenterReportMonth `json:"months"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type Label struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type GetLabelListResponse struct {
+	Total int     `json:"total"`
+	List  []Label `json:"list"`
+}
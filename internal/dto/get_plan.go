@@ -0,0 +1,19 @@
+package dto
+
+type PlanPriceChange struct {
+	EffectiveDate string `json:"effective_date"`
+	Price         int    `json:"price"`
+}
+
+// GetPlanResponse: price — цена в текущем месяце, base_price действует до
+// первого изменения из price_changes.
+type GetPlanResponse struct {
+	ID            string            `json:"id"`
+	ServiceID     string            `json:"service_id"`
+	Name          string            `json:"name"`
+	Price         int               `json:"price"`
+	BasePrice     int               `json:"base_price"`
+	BillingPeriod string            `json:"billing_period"`
+	Default       bool              `json:"default"`
+	PriceChanges  []PlanPriceChange `json:"price_changes"`
+}
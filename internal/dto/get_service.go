@@ -0,0 +1,10 @@
+package dto
+
+type GetServiceResponse struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Aliases  []string      `json:"aliases"`
+	LogoURL  string        `json:"logo_url,omitempty"`
+	Category string        `json:"category,omitempty"`
+	Plans    []ServicePlan `json:"plans"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type GetServiceListResponse struct {
+	Total int                  `json:"total"`
+	List  []GetServiceResponse `json:"list"`
+}
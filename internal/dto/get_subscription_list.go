@@ -1,5 +1,15 @@
 package dto
 
+// GetSubsListFilter: пустые category, tag и status не фильтруют; статус
+// считается в месяце as_of (MM-YYYY), по умолчанию текущем.
+type GetSubsListFilter struct {
+	UserID   string
+	Category string
+	Tag      string
+	Status   string
+	AsOf     string
+}
+
 type GetSubsListResponse struct {
 	Total int                       `json:"total"`
 	List  []GetSubscriptionResponse `json:"list"`
@@ -1,5 +1,16 @@
 package dto
 
+// SumGroupByTag — группировка суммы по тегам в GetSubscriptionsSum.
+const SumGroupByTag = "tag"
+
+// SumGroup — сумма подписок с тегом Tag; пустой Tag — подписки без тегов.
+// Подписка с несколькими тегами входит в несколько групп.
+type SumGroup struct {
+	Tag   string `json:"tag"`
+	Total int    `json:"total"`
+}
+
 type GetSubSumResponse struct {
-	Total int `json:"total"`
+	Total  int        `json:"total"`
+	Groups []SumGroup `json:"groups,omitempty"`
 }
@@ -1,10 +1,23 @@
 package dto
 
+// GetSubscriptionResponse: status — состояние подписки в отчётном месяце:
+// scheduled, trial, active, ending_soon, paused или ended.
 type GetSubscriptionResponse struct {
-	ID          string  `json:"id"`
-	ServiceName string  `json:"service_name"`
-	Price       int     `json:"price"`
-	UserID      string  `json:"user_id"`
-	StartDate   string  `json:"start_date"`
-	EndDate     *string `json:"end_date,omitempty"`
+	ID            string              `json:"id"`
+	ServiceName   string              `json:"service_name"`
+	ServiceID     string              `json:"service_id,omitempty"`
+	PlanID        string              `json:"plan_id,omitempty"`
+	Price         int                 `json:"price"`
+	BillingPeriod string              `json:"billing_period"`
+	UserID        string              `json:"user_id"`
+	StartDate     string              `json:"start_date"`
+	EndDate       *string             `json:"end_date,omitempty"`
+	TrialEnd      *string             `json:"trial_end_date,omitempty"`
+	IntroPrice    *int                `json:"intro_price,omitempty"`
+	IntroMonths   int                 `json:"intro_months,omitempty"`
+	Category      string              `json:"category,omitempty"`
+	Tags          []string            `json:"tags"`
+	Status        string              `json:"status"`
+	Pauses        []SubscriptionPause `json:"pauses,omitempty"`
+	Cancellation  *CancellationInfo   `json:"cancellation,omitempty"`
 }
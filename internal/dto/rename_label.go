@@ -0,0 +1,10 @@
+package dto
+
+type RenameLabelRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// RenameLabelResponse — число подписок, у которых изменился тег или категория.
+type RenameLabelResponse struct {
+	Updated int `json:"updated"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+// CancelSubscriptionRequest: effective — immediately (текущий месяц уже не
+// оплачивается), end_of_period (по умолчанию; подписка оплачена до конца
+// текущего периода) или date (date — первый неоплачиваемый месяц).
+type CancelSubscriptionRequest struct {
+	Effective string `json:"effective,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Reason    string `json:"reason"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// CancellationInfo: effective_date — первый месяц, который не оплачивается.
+type CancellationInfo struct {
+	EffectiveDate string `json:"effective_date"`
+	Reason        string `json:"reason"`
+	Comment       string `json:"comment,omitempty"`
+	CancelledAt   string `json:"cancelled_at"`
+}
+
+type ChurnGroup struct {
+	Reason      string `json:"reason"`
+	ServiceName string `json:"service_name"`
+	Count       int    `json:"count"`
+}
+
+type ChurnReasonCount struct {
+	Reason string `json:"reason"`
+	Count  int    `json:"count"`
+}
+
+type ChurnServiceCount struct {
+	ServiceName string `json:"service_name"`
+	Count       int    `json:"count"`
+}
+
+// GetChurnReportResponse: groups — отмены по причине и сервису, reasons и
+// services — итоги по каждому из них.
+type GetChurnReportResponse struct {
+	Total    int                 `json:"total"`
+	Reasons  []ChurnReasonCount  `json:"reasons"`
+	Services []ChurnServiceCount `json:"services"`
+	Groups   []ChurnGroup        `json:"groups"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+// SubscriptionMember — участник разделённой подписки. Share в ответах —
+// доля участника в текущей цене подписки.
+type SubscriptionMember struct {
+	UserID string `json:"user_id" validate:"required,uuid4"`
+	Weight int    `json:"weight" validate:"required,min=1,max=1000"`
+	Share  int    `json:"share,omitempty"`
+}
+
+// SetMembersRequest заменяет участников подписки целиком; пустой список
+// возвращает подписку владельцу.
+type SetMembersRequest struct {
+	Members []SubscriptionMember `json:"members"`
+}
+
+type GetMembersResponse struct {
+	SubscriptionID string               `json:"subscription_id"`
+	OwnerID        string               `json:"owner_id"`
+	Price          int                  `json:"price"`
+	Members        []SubscriptionMember `json:"members"`
+}
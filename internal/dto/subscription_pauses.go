@@ -0,0 +1,21 @@
+package dto
+
+// SubscriptionPause — приостановленные месяцы подписки с start_date по
+// end_date включительно; без end_date пауза длится до возобновления.
+type SubscriptionPause struct {
+	StartDate string  `json:"start_date"`
+	EndDate   *string `json:"end_date,omitempty"`
+}
+
+// PauseSubscriptionRequest: без start_date пауза начинается с текущего
+// месяца, без end_date длится до возобновления.
+type PauseSubscriptionRequest struct {
+	StartDate string  `json:"start_date,omitempty"`
+	EndDate   *string `json:"end_date,omitempty"`
+}
+
+// ResumeSubscriptionRequest: date — первый оплачиваемый месяц после паузы,
+// не раньше текущего; по умолчанию текущий.
+type ResumeSubscriptionRequest struct {
+	Date string `json:"date,omitempty"`
+}
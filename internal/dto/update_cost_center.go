@@ -0,0 +1,5 @@
+package dto
+
+type UpdateCostCenterRequest struct {
+	Name string `json:"name" validate:"required"`
+}
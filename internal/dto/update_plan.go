@@ -0,0 +1,10 @@
+package dto
+
+// UpdatePlanRequest: новая price вступает в силу с месяца effective_date
+// (MM-YYYY) и с него же применяется к подпискам на тариф.
+type UpdatePlanRequest struct {
+	Name          string `json:"name" validate:"omitempty"`
+	Default       *bool  `json:"default,omitempty"`
+	Price         int    `json:"price" validate:"omitempty,min=1"`
+	EffectiveDate string `json:"effective_date" validate:"required_with=Price"`
+}
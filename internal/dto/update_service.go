@@ -0,0 +1,10 @@
+package dto
+
+// UpdateServiceRequest меняет только переданные поля. Aliases заменяются
+// целиком, пустой массив очищает их. Тарифы меняются через /plans.
+type UpdateServiceRequest struct {
+	Name     string    `json:"name" validate:"omitempty"`
+	Aliases  *[]string `json:"aliases,omitempty"`
+	LogoURL  *string   `json:"logo_url,omitempty"`
+	Category *string   `json:"category,omitempty"`
+}
@@ -1,17 +1,38 @@
 package dto
 
+// UpdateSubscriptionRequest: plan_id привязывает подписку к тарифу, пустая
+// строка отвязывает. Смена сервиса или цены без plan_id тоже отвязывает тариф.
+// Tags заменяются целиком, пустой массив очищает их. Пустой trial_end_date
+// убирает пробный период, intro_price 0 — вводную цену.
 type UpdateSubscriptionRequest struct {
-	ServiceName string  `json:"service_name" validate:"omitempty"`
-	Price       int     `json:"price" validate:"omitempty,min=1"`
-	StartDate   string  `json:"start_date" validate:"omitempty"`
-	EndDate     *string `json:"end_date,omitempty"`
+	ServiceName string    `json:"service_name" validate:"omitempty"`
+	PlanID      *string   `json:"plan_id,omitempty"`
+	Price       int       `json:"price" validate:"omitempty,min=1"`
+	StartDate   string    `json:"start_date" validate:"omitempty"`
+	EndDate     *string   `json:"end_date,omitempty"`
+	TrialEnd    *string   `json:"trial_end_date,omitempty"`
+	IntroPrice  *int      `json:"intro_price,omitempty" validate:"omitempty,min=0"`
+	IntroMonths *int      `json:"intro_months,omitempty" validate:"omitempty,min=1"`
+	Category    *string   `json:"category,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`
 }
 
 type UpdateSubscriptionResponse struct {
-	ID          string  `json:"id"`
-	ServiceName string  `json:"service_name"`
-	Price       int     `json:"price"`
-	UserID      string  `json:"user_id"`
-	StartDate   string  `json:"start_date"`
-	EndDate     *string `json:"end_date,omitempty"`
+	ID            string              `json:"id"`
+	ServiceName   string              `json:"service_name"`
+	ServiceID     string              `json:"service_id,omitempty"`
+	PlanID        string              `json:"plan_id,omitempty"`
+	Price         int                 `json:"price"`
+	BillingPeriod string              `json:"billing_period"`
+	UserID        string              `json:"user_id"`
+	StartDate     string              `json:"start_date"`
+	EndDate       *string             `json:"end_date,omitempty"`
+	TrialEnd      *string             `json:"trial_end_date,omitempty"`
+	IntroPrice    *int                `json:"intro_price,omitempty"`
+	IntroMonths   int                 `json:"intro_months,omitempty"`
+	Category      string              `json:"category,omitempty"`
+	Tags          []string            `json:"tags"`
+	Status        string              `json:"status"`
+	Pauses        []SubscriptionPause `json:"pauses,omitempty"`
+	Cancellation  *CancellationInfo   `json:"cancellation,omitempty"`
 }
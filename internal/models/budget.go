@@ -0,0 +1,112 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Области действия бюджета.
+const (
+	BudgetScopeUser         = "user"
+	BudgetScopeTag          = "tag"
+	BudgetScopeOrganization = "organization"
+)
+
+// Периоды бюджета.
+const (
+	BudgetMonthly = "month"
+	BudgetYearly  = "year"
+)
+
+// DefaultBudgetThresholds — пороги предупреждений в процентах от бюджета.
+var DefaultBudgetThresholds = []int{50, 80, 100}
+
+// Budget ограничивает расходы за календарный месяц или год. ScopeValue —
+// user_id для BudgetScopeUser и тег для BudgetScopeTag; бюджет организации
+// охватывает все подписки.
+type Budget struct {
+	ID         uuid.UUID
+	Name       string
+	Amount     int
+	Scope      string
+	ScopeValue string
+	Period     string
+	Thresholds []int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (b *Budget) Validate() error {
+	if strings.TrimSpace(b.Name) == "" {
+		return errors.New("budget name is required")
+	}
+	if b.Amount <= 0 {
+		return errors.New("budget amount must be greater than 0")
+	}
+
+	switch b.Scope {
+	case BudgetScopeUser:
+		if _, err := uuid.Parse(b.ScopeValue); err != nil {
+			return fmt.Errorf("user budget needs a user_id as scope_value: %w", err)
+		}
+	case BudgetScopeTag:
+		if b.ScopeValue == "" {
+			return errors.New("tag budget needs a tag as scope_value")
+		}
+	case BudgetScopeOrganization:
+		if b.ScopeValue != "" {
+			return errors.New("organization budget must not have a scope_value")
+		}
+	default:
+		return fmt.Errorf("scope must be %q, %q or %q", BudgetScopeUser, BudgetScopeTag, BudgetScopeOrganization)
+	}
+
+	if b.Period != BudgetMonthly && b.Period != BudgetYearly {
+		return fmt.Errorf("period must be %q or %q", BudgetMonthly, BudgetYearly)
+	}
+
+	if len(b.Thresholds) == 0 {
+		return errors.New("budget needs at least one threshold")
+	}
+	for _, t := range b.Thresholds {
+		if t <= 0 || t > 1000 {
+			return fmt.Errorf("threshold must be between 1 and 1000 percent, got %d", t)
+		}
+	}
+
+	return nil
+}
+
+// NormalizeThresholds сортирует пороги и убирает повторы.
+func NormalizeThresholds(thresholds []int) []int {
+	out := slices.Clone(thresholds)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// PeriodAt возвращает первый и последний месяц периода бюджета, в который
+// попадает now.
+func (b *Budget) PeriodAt(now time.Time) (start, end time.Time) {
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if b.Period == BudgetYearly {
+		start = time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 11, 0)
+	}
+	return month, month
+}
+
+// BudgetAlert — предупреждение о том, что обязательные расходы периода
+// достигли порога Threshold процентов бюджета.
+type BudgetAlert struct {
+	Budget      Budget
+	PeriodStart time.Time
+	Threshold   int
+	Actual      int
+	Committed   int
+	Percent     int
+}
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrCostCenterNameTaken — центр затрат с таким названием уже есть.
+var ErrCostCenterNameTaken = errors.New("cost center name is already taken")
+
+// ErrCostCenterInUse — на центр затрат распределены подписки.
+var ErrCostCenterInUse = errors.New("cost center has subscription allocations")
+
+type CostCenter struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c *CostCenter) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return errors.New("cost center name is required")
+	}
+	return nil
+}
+
+// Allocation — доля подписки в процентах, относимая на центр затрат.
+type Allocation struct {
+	CostCenterID uuid.UUID
+	Percent      int
+}
+
+// ValidateAllocations проверяет, что доли положительны, центры затрат не
+// повторяются и в сумме доли дают 100%. Пустой список означает, что
+// подписка не распределена.
+func ValidateAllocations(allocations []Allocation) error {
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	seen := map[uuid.UUID]bool{}
+	total := 0
+	for _, a := range allocations {
+		if a.Percent <= 0 || a.Percent > 100 {
+			return fmt.Errorf("allocation percent must be between 1 and 100, got %d", a.Percent)
+		}
+		if seen[a.CostCenterID] {
+			return fmt.Errorf("cost center %s is allocated more than once", a.CostCenterID)
+		}
+		seen[a.CostCenterID] = true
+		total += a.Percent
+	}
+	if total != 100 {
+		return fmt.Errorf("allocations must sum to 100%%, got %d%%", total)
+	}
+
+	return nil
+}
+
+// SplitAmount делит amount по долям allocations. Округление накопительное:
+// части всегда дают в сумме amount, а остаток от округления достаётся
+// долям ближе к концу списка.
+func SplitAmount(amount int, allocations []Allocation) []int {
+	parts := make([]int, len(allocations))
+	cumulative, assigned := 0, 0
+	for i, a := range allocations {
+		cumulative += a.Percent
+		upTo := amount * cumulative / 100
+		parts[i] = upTo - assigned
+		assigned = upTo
+	}
+	return parts
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// ErrServiceNameTaken — название или синоним уже принадлежит другому сервису.
+var ErrServiceNameTaken = errors.New("service name or alias is already used by another service")
+
+// ErrPlanNameTaken — у сервиса уже есть тариф с таким названием.
+var ErrPlanNameTaken = errors.New("service already has a plan with this name")
+
+// Периоды оплаты тарифа и подписки.
+const (
+	BillingMonthly = "month"
+	BillingYearly  = "year"
+)
+
+// MonthlyPrice возвращает месячную долю цены price за период оплаты
+// billingPeriod: годовая цена делится на 12 с округлением вниз.
+func MonthlyPrice(price int, billingPeriod string) int {
+	if billingPeriod == BillingYearly {
+		return price / 12
+	}
+	return price
+}
+
+// ServicePlan — тариф сервиса. Price — базовая цена, действующая до первого
+// изменения из PriceChanges. Цена тарифа с Default подставляется в подписку,
+// если ни цена, ни тариф не указаны.
+type ServicePlan struct {
+	ID            uuid.UUID
+	ServiceID     uuid.UUID
+	Name          string
+	Price         int
+	BillingPeriod string
+	Default       bool
+	PriceChanges  []PlanPriceChange
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// PlanPriceChange — новая цена тарифа с первого дня месяца EffectiveFrom.
+// Подписки на тариф получают её с того же месяца.
+type PlanPriceChange struct {
+	EffectiveFrom time.Time
+	Price         int
+}
+
+// PriceAt возвращает цену тарифа, действующую в месяце month.
+func (p *ServicePlan) PriceAt(month time.Time) int {
+	price := p.Price
+	for _, c := range p.PriceChanges {
+		if c.EffectiveFrom.After(month) {
+			break
+		}
+		price = c.Price
+	}
+	return price
+}
+
+func (p *ServicePlan) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("plan name is required")
+	}
+	if p.Price <= 0 {
+		return errors.New("plan price must be greater than 0")
+	}
+	if p.BillingPeriod != BillingMonthly && p.BillingPeriod != BillingYearly {
+		return fmt.Errorf("billing_period must be %q or %q", BillingMonthly, BillingYearly)
+	}
+	return nil
+}
+
+// Service — запись каталога. Подписки ссылаются на неё по ServiceID, а
+// service_name подписки хранит каноническое Name.
+type Service struct {
+	ID        uuid.UUID
+	Name      string
+	Aliases   []string
+	LogoURL   string
+	Category  string
+	Plans     []ServicePlan
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NormalizeServiceName приводит название к ключу сравнения: регистр, пробелы
+// по краям и повторные пробелы не учитываются.
+func NormalizeServiceName(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), unicode.IsSpace), " ")
+}
+
+// DefaultPlan возвращает тариф по умолчанию: отмеченный Default или первый.
+func (s *Service) DefaultPlan() (*ServicePlan, bool) {
+	for i := range s.Plans {
+		if s.Plans[i].Default {
+			return &s.Plans[i], true
+		}
+	}
+	if len(s.Plans) > 0 {
+		return &s.Plans[0], true
+	}
+	return nil, false
+}
+
+func (s *Service) Validate() error {
+	if NormalizeServiceName(s.Name) == "" {
+		return errors.New("name is required")
+	}
+
+	for _, alias := range s.Aliases {
+		if NormalizeServiceName(alias) == "" {
+			return errors.New("aliases must not be empty")
+		}
+	}
+
+	if s.LogoURL != "" {
+		u, err := url.Parse(s.LogoURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("logo_url must be an absolute http(s) URL")
+		}
+	}
+
+	defaults := 0
+	names := map[string]bool{}
+	for _, p := range s.Plans {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if names[p.Name] {
+			return fmt.Errorf("duplicate plan %q", p.Name)
+		}
+		names[p.Name] = true
+		if p.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return errors.New("only one plan can be default")
+	}
+
+	return nil
+}
+
+// ServiceNameCount — название сервиса, встречающееся в подписках, и число таких подписок.
+type ServiceNameCount struct {
+	Name  string
+	Count int
+}
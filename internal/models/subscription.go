@@ -2,20 +2,34 @@ package models
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type Subscription struct {
-	ID          uuid.UUID  `json:"id"`
-	ServiceName string     `json:"service_name"`
-	Price       int        `json:"price"`
-	UserID      uuid.UUID  `json:"user_id"`
-	StartDate   time.Time  `json:"start_date"`
-	EndDate     *time.Time `json:"end_date,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID            uuid.UUID  `json:"id"`
+	ServiceName   string     `json:"service_name"`
+	ServiceID     *uuid.UUID `json:"service_id,omitempty"`
+	PlanID        *uuid.UUID `json:"plan_id,omitempty"`
+	Price         int        `json:"price"`
+	BillingPeriod string     `json:"billing_period"`
+	Category      string     `json:"category,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+	UserID        uuid.UUID  `json:"user_id"`
+	StartDate     time.Time  `json:"start_date"`
+	EndDate       *time.Time `json:"end_date,omitempty"`
+	// TrialEndDate — последний месяц бесплатного пробного периода, который
+	// начинается со StartDate. После него IntroMonths месяцев действует
+	// вводная цена IntroPrice, затем обычная цена.
+	TrialEndDate *time.Time `json:"trial_end_date,omitempty"`
+	IntroPrice   *int       `json:"intro_price,omitempty"`
+	IntroMonths  int        `json:"intro_months,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
 }
 
 func (s *Subscription) Validate() error {
@@ -27,6 +41,10 @@ func (s *Subscription) Validate() error {
 		return errors.New("price must be greater than 0")
 	}
 
+	if s.BillingPeriod != "" && s.BillingPeriod != BillingMonthly && s.BillingPeriod != BillingYearly {
+		return errors.New("billing_period must be month or year")
+	}
+
 	if s.UserID == uuid.Nil {
 		return errors.New("user_id is required")
 	}
@@ -45,5 +63,157 @@ func (s *Subscription) Validate() error {
 		return errors.New("start_date cannot be in the future")
 	}
 
+	return s.ValidateIntro()
+}
+
+// maxIntroMonths ограничивает длительность вводной цены.
+const maxIntroMonths = 120
+
+// ValidateIntro проверяет пробный период и вводную цену.
+func (s *Subscription) ValidateIntro() error {
+	if s.TrialEndDate != nil && s.TrialEndDate.Before(s.StartDate) {
+		return errors.New("trial_end_date cannot be before start_date")
+	}
+
+	if s.IntroPrice == nil {
+		if s.IntroMonths != 0 {
+			return errors.New("intro_months requires intro_price")
+		}
+		return nil
+	}
+	if *s.IntroPrice <= 0 {
+		return errors.New("intro_price must be greater than 0")
+	}
+	if s.IntroMonths <= 0 || s.IntroMonths > maxIntroMonths {
+		return fmt.Errorf("intro_months must be between 1 and %d", maxIntroMonths)
+	}
+
 	return nil
 }
+
+// PaidStart возвращает первый платный месяц — следующий за пробным периодом.
+func (s *Subscription) PaidStart() time.Time {
+	if s.TrialEndDate == nil {
+		return s.StartDate
+	}
+	return s.TrialEndDate.AddDate(0, 1, 0)
+}
+
+// RegularStart возвращает первый месяц по обычной цене — после пробного
+// периода и вводной цены.
+func (s *Subscription) RegularStart() time.Time {
+	if s.IntroPrice == nil {
+		return s.PaidStart()
+	}
+	return s.PaidStart().AddDate(0, s.IntroMonths, 0)
+}
+
+// FirstChargePrice возвращает цену первого платного месяца. Для подписки на
+// тариф plan это цена тарифа в PaidStart, как в сводках; без тарифа —
+// собственная цена подписки.
+func (s *Subscription) FirstChargePrice(plan *ServicePlan) int {
+	if s.IntroPrice != nil {
+		return *s.IntroPrice
+	}
+	if plan != nil {
+		return plan.PriceAt(s.PaidStart())
+	}
+	return s.Price
+}
+
+// Статусы подписки в отчётном месяце.
+const (
+	StatusScheduled  = "scheduled"
+	StatusTrial      = "trial"
+	StatusActive     = "active"
+	StatusEndingSoon = "ending_soon"
+	StatusPaused     = "paused"
+	StatusEnded      = "ended"
+)
+
+// EndingSoonMonths — сколько месяцев после отчётного подписка с end_date
+// в этом окне считается заканчивающейся: при 1 — в отчётном или следующем.
+const EndingSoonMonths = 1
+
+// IsStatus сообщает, является ли status одним из статусов подписки.
+func IsStatus(status string) bool {
+	switch status {
+	case StatusScheduled, StatusTrial, StatusActive, StatusEndingSoon, StatusPaused, StatusEnded:
+		return true
+	}
+	return false
+}
+
+// Status возвращает статус подписки в месяце month. Подписка, которая
+// заканчивается в ближайшие EndingSoonMonths месяцев, отмечается как
+// заканчивающаяся, если не приостановлена.
+func (s *Subscription) Status(pauses []SubscriptionPause, month time.Time) string {
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	switch {
+	case month.Before(s.StartDate):
+		return StatusScheduled
+	case s.EndDate != nil && month.After(*s.EndDate):
+		return StatusEnded
+	case PausedAt(pauses, month):
+		return StatusPaused
+	case s.EndDate != nil && !s.EndDate.After(month.AddDate(0, EndingSoonMonths, 0)):
+		return StatusEndingSoon
+	case s.TrialEndDate != nil && !month.After(*s.TrialEndDate):
+		return StatusTrial
+	default:
+		return StatusActive
+	}
+}
+
+// TrialReminder — напоминание о первом платном месяце после пробного периода.
+type TrialReminder struct {
+	Subscription Subscription
+	ChargeDate   time.Time
+	Price        int
+}
+
+// NormalizeTag приводит тег к виду, в котором он хранится: без пробелов по
+// краям и в нижнем регистре.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// NormalizeTags нормализует теги, убирает пустые и повторы и сортирует их.
+func NormalizeTags(tags []string) []string {
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = NormalizeTag(tag); tag != "" {
+			out = append(out, tag)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// SubscriptionFilter — условия выборки подписок; пустые поля не фильтруют.
+// Status отбирает подписки со статусом в месяце AsOf.
+type SubscriptionFilter struct {
+	UserID   uuid.UUID
+	Category string
+	Tag      string
+	Status   string
+	AsOf     time.Time
+}
+
+// LabelCount — тег или категория и число подписок с ними.
+type LabelCount struct {
+	Name  string
+	Count int
+}
+
+// TagTotal — сумма подписок с тегом Tag; пустой Tag — подписки без тегов.
+type TagTotal struct {
+	Tag   string
+	Total int
+}
+
+// SubscriptionTotal — сумма одной подписки за период.
+type SubscriptionTotal struct {
+	SubscriptionID uuid.UUID
+	Total          int
+}
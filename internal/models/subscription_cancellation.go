@@ -0,0 +1,130 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Когда отмена вступает в силу.
+const (
+	CancelImmediately = "immediately"
+	CancelEndOfPeriod = "end_of_period"
+	CancelOnDate      = "date"
+)
+
+// Причины отмены подписки.
+const (
+	CancelReasonTooExpensive    = "too_expensive"
+	CancelReasonNotUsing        = "not_using"
+	CancelReasonSwitched        = "switched_service"
+	CancelReasonMissingFeatures = "missing_features"
+	CancelReasonTechnicalIssues = "technical_issues"
+	CancelReasonOther           = "other"
+)
+
+var (
+	ErrAlreadyCancelled   = errors.New("subscription is already cancelled")
+	ErrNotCancelled       = errors.New("subscription is not cancelled")
+	ErrCancellationActive = errors.New("cancellation is already in effect")
+	ErrSubscriptionEnded  = errors.New("subscription has already ended")
+	ErrCancelBeforeStart  = errors.New("subscription has no billed months before the cancellation: delete it or cancel on a later date")
+)
+
+// Cancellation — отмена подписки. С EffectiveDate подписка не оплачивается;
+// PreviousEndDate — end_date подписки до отмены.
+type Cancellation struct {
+	SubscriptionID  uuid.UUID
+	EffectiveDate   time.Time
+	PreviousEndDate *time.Time
+	Reason          string
+	Comment         string
+	CancelledAt     time.Time
+}
+
+// IsCancelReason сообщает, является ли reason одной из причин отмены.
+func IsCancelReason(reason string) bool {
+	switch reason {
+	case CancelReasonTooExpensive, CancelReasonNotUsing, CancelReasonSwitched,
+		CancelReasonMissingFeatures, CancelReasonTechnicalIssues, CancelReasonOther:
+		return true
+	}
+	return false
+}
+
+// PeriodEnd возвращает последний месяц оплаченного периода, в который
+// попадает month: для помесячной оплаты — сам month, для годовой — месяц
+// перед очередной годовщиной start_date.
+func (s *Subscription) PeriodEnd(month time.Time) time.Time {
+	if month.Before(s.StartDate) {
+		month = s.StartDate
+	}
+	if s.BillingPeriod != BillingYearly {
+		return month
+	}
+
+	elapsed := (month.Year()-s.StartDate.Year())*12 + int(month.Month()-s.StartDate.Month())
+	return s.StartDate.AddDate(0, elapsed/12*12+11, 0)
+}
+
+// CancelEffective возвращает первый неоплачиваемый месяц для отмены в режиме
+// mode, запрошенной в месяце month; date используется только с CancelOnDate.
+// Конец периода не наступает позже уже заданного end_date, а явная дата
+// после него отклоняется в Cancel.
+func (s *Subscription) CancelEffective(mode string, date, month time.Time) (time.Time, error) {
+	if s.EndDate != nil && s.EndDate.Before(month) {
+		return time.Time{}, ErrSubscriptionEnded
+	}
+
+	switch mode {
+	case CancelImmediately:
+		return month, nil
+	case CancelEndOfPeriod:
+		effective := s.PeriodEnd(month).AddDate(0, 1, 0)
+		if s.EndDate != nil && s.EndDate.AddDate(0, 1, 0).Before(effective) {
+			effective = s.EndDate.AddDate(0, 1, 0)
+		}
+		return effective, nil
+	case CancelOnDate:
+		return date, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unknown cancellation mode %q", mode)
+}
+
+// Cancel заканчивает подписку месяцем перед effective и возвращает запись
+// об отмене. Отмена не может продлить подписку или оставить её без
+// оплаченных месяцев — такую подписку нужно удалить.
+func (s *Subscription) Cancel(effective time.Time, reason, comment string, now time.Time) (Cancellation, error) {
+	if !IsCancelReason(reason) {
+		return Cancellation{}, errors.New("unknown cancellation reason")
+	}
+	if !effective.After(s.StartDate) {
+		return Cancellation{}, ErrCancelBeforeStart
+	}
+	end := effective.AddDate(0, -1, 0)
+	if s.EndDate != nil && end.After(*s.EndDate) {
+		return Cancellation{}, errors.New("subscription ends before the cancellation takes effect")
+	}
+
+	c := Cancellation{
+		SubscriptionID:  s.ID,
+		EffectiveDate:   effective,
+		PreviousEndDate: s.EndDate,
+		Reason:          reason,
+		Comment:         comment,
+		CancelledAt:     now,
+	}
+	s.EndDate = &end
+
+	return c, nil
+}
+
+// ChurnTotal — число отмен подписок сервиса ServiceName по причине Reason.
+type ChurnTotal struct {
+	Reason      string
+	ServiceName string
+	Count       int
+}
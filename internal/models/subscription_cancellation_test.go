@@ -0,0 +1,118 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSubscriptionPeriodEnd(t *testing.T) {
+	tests := []struct {
+		name    string
+		billing string
+		start   time.Time
+		month   time.Time
+		want    time.Time
+	}{
+		{name: "monthly", billing: BillingMonthly, start: month(2024, 3), month: month(2025, 7), want: month(2025, 7)},
+		{name: "monthly before start", billing: BillingMonthly, start: month(2025, 9), month: month(2025, 7), want: month(2025, 9)},
+		{name: "yearly first month", billing: BillingYearly, start: month(2024, 3), month: month(2024, 3), want: month(2025, 2)},
+		{name: "yearly last month of year", billing: BillingYearly, start: month(2024, 3), month: month(2025, 2), want: month(2025, 2)},
+		{name: "yearly anniversary", billing: BillingYearly, start: month(2024, 3), month: month(2025, 3), want: month(2026, 2)},
+		{name: "yearly across calendar year", billing: BillingYearly, start: month(2024, 11), month: month(2026, 1), want: month(2026, 10)},
+		{name: "yearly before start", billing: BillingYearly, start: month(2025, 9), month: month(2025, 7), want: month(2026, 8)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Subscription{StartDate: tt.start, BillingPeriod: tt.billing}
+			if got := s.PeriodEnd(tt.month); !got.Equal(tt.want) {
+				t.Fatalf("PeriodEnd(%s) = %s, want %s", tt.month.Format("01-2006"), got.Format("01-2006"), tt.want.Format("01-2006"))
+			}
+		})
+	}
+}
+
+func TestSubscriptionCancelEffective(t *testing.T) {
+	now := month(2025, 7)
+	end := func(t time.Time) *time.Time { return &t }
+	tests := []struct {
+		name    string
+		billing string
+		endDate *time.Time
+		mode    string
+		date    time.Time
+		want    time.Time
+		wantErr error
+	}{
+		{name: "immediately", billing: BillingMonthly, mode: CancelImmediately, want: now},
+		{name: "monthly end of period", billing: BillingMonthly, mode: CancelEndOfPeriod, want: month(2025, 8)},
+		{name: "yearly end of period", billing: BillingYearly, mode: CancelEndOfPeriod, want: month(2026, 3)},
+		{name: "yearly end of period capped at end_date", billing: BillingYearly, endDate: end(month(2025, 10)), mode: CancelEndOfPeriod, want: month(2025, 11)},
+		{name: "yearly end of period before end_date", billing: BillingYearly, endDate: end(month(2027, 1)), mode: CancelEndOfPeriod, want: month(2026, 3)},
+		{name: "date", billing: BillingMonthly, mode: CancelOnDate, date: month(2025, 12), want: month(2025, 12)},
+		{name: "ended", billing: BillingMonthly, endDate: end(month(2025, 6)), mode: CancelImmediately, wantErr: ErrSubscriptionEnded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Subscription{StartDate: month(2024, 3), EndDate: tt.endDate, BillingPeriod: tt.billing}
+			got, err := s.CancelEffective(tt.mode, tt.date, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CancelEffective error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !got.Equal(tt.want) {
+				t.Fatalf("CancelEffective = %s, want %s", got.Format("01-2006"), tt.want.Format("01-2006"))
+			}
+		})
+	}
+}
+
+func TestSubscriptionCancel(t *testing.T) {
+	now := time.Date(2025, 7, 10, 12, 0, 0, 0, time.UTC)
+	end := func(t time.Time) *time.Time { return &t }
+	tests := []struct {
+		name      string
+		endDate   *time.Time
+		effective time.Time
+		reason    string
+		wantEnd   time.Time
+		wantErr   error
+		anyErr    bool
+	}{
+		{name: "open-ended", effective: month(2025, 9), reason: CancelReasonNotUsing, wantEnd: month(2025, 8)},
+		{name: "before end_date", endDate: end(month(2025, 12)), effective: month(2025, 9), reason: CancelReasonNotUsing, wantEnd: month(2025, 8)},
+		{name: "right after end_date", endDate: end(month(2025, 12)), effective: month(2026, 1), reason: CancelReasonOther, wantEnd: month(2025, 12)},
+		{name: "past end_date", endDate: end(month(2025, 12)), effective: month(2026, 2), reason: CancelReasonOther, anyErr: true},
+		{name: "first month after start", effective: month(2024, 4), reason: CancelReasonOther, wantEnd: month(2024, 3)},
+		{name: "at start", effective: month(2024, 3), reason: CancelReasonOther, wantErr: ErrCancelBeforeStart},
+		{name: "before start", effective: month(2024, 1), reason: CancelReasonOther, wantErr: ErrCancelBeforeStart},
+		{name: "unknown reason", effective: month(2025, 9), reason: "bored", anyErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Subscription{StartDate: month(2024, 3), EndDate: tt.endDate}
+			c, err := s.Cancel(tt.effective, tt.reason, "", now)
+			switch {
+			case tt.wantErr != nil || tt.anyErr:
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Fatalf("Cancel error = %v, want %v", err, tt.wantErr)
+				}
+				if s.EndDate != tt.endDate {
+					t.Fatalf("end_date changed by a rejected cancellation")
+				}
+				return
+			case err != nil:
+				t.Fatalf("Cancel: %v", err)
+			}
+			if s.EndDate == nil || !s.EndDate.Equal(tt.wantEnd) {
+				t.Fatalf("end_date = %v, want %s", s.EndDate, tt.wantEnd.Format("01-2006"))
+			}
+			if c.PreviousEndDate != tt.endDate || !c.EffectiveDate.Equal(tt.effective) || !c.CancelledAt.Equal(now) {
+				t.Fatalf("cancellation = %+v", c)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// maxMemberWeight ограничивает вес участника, чтобы доли оставались
+// обозримыми, а произведения цены на веса не переполнялись.
+const maxMemberWeight = 1000
+
+// SubscriptionMember — пользователь, с которым разделена подписка, и его вес
+// при делении стоимости. У подписки с участниками стоимость в сводках по
+// пользователю делится между участниками пропорционально весам; владелец
+// платит долю, только если сам указан участником.
+type SubscriptionMember struct {
+	UserID uuid.UUID
+	Weight int
+}
+
+// ValidateMembers проверяет, что веса положительны и пользователи не
+// повторяются. Пустой список означает, что подписку оплачивает владелец.
+func ValidateMembers(members []SubscriptionMember) error {
+	seen := map[uuid.UUID]bool{}
+	for _, m := range members {
+		if m.UserID == uuid.Nil {
+			return fmt.Errorf("member user_id is required")
+		}
+		if m.Weight <= 0 || m.Weight > maxMemberWeight {
+			return fmt.Errorf("member weight must be between 1 and %d, got %d", maxMemberWeight, m.Weight)
+		}
+		if seen[m.UserID] {
+			return fmt.Errorf("user %s is a member more than once", m.UserID)
+		}
+		seen[m.UserID] = true
+	}
+	return nil
+}
+
+// SortMembers упорядочивает участников по user_id — в этом порядке
+// распределяется остаток от округления долей.
+func SortMembers(members []SubscriptionMember) {
+	slices.SortFunc(members, func(a, b SubscriptionMember) int {
+		return bytes.Compare(a.UserID[:], b.UserID[:])
+	})
+}
+
+// MemberShare возвращает долю userID в amount. Округление накопительное по
+// участникам в порядке SortMembers, так что доли всех участников дают в
+// сумме amount. Для пользователя, который не участник, доля равна нулю.
+func MemberShare(amount int, members []SubscriptionMember, userID uuid.UUID) int {
+	sorted := slices.Clone(members)
+	SortMembers(sorted)
+
+	total := 0
+	for _, m := range sorted {
+		total += m.Weight
+	}
+
+	cumulative := 0
+	for _, m := range sorted {
+		cumulative += m.Weight
+		if m.UserID == userID {
+			return amount*cumulative/total - amount*(cumulative-m.Weight)/total
+		}
+	}
+	return 0
+}
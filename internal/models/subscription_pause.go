@@ -0,0 +1,95 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// SubscriptionPause — приостановка подписки с StartDate по EndDate
+// включительно; EndDate nil — до возобновления.
+type SubscriptionPause struct {
+	StartDate time.Time  `json:"start_date"`
+	EndDate   *time.Time `json:"end_date,omitempty"`
+}
+
+var (
+	ErrAlreadyPaused = errors.New("subscription is already paused in this period")
+	ErrNotPaused     = errors.New("subscription is not paused")
+)
+
+// Covers сообщает, приостановлена ли подписка в месяце month.
+func (p SubscriptionPause) Covers(month time.Time) bool {
+	return !month.Before(p.StartDate) && (p.EndDate == nil || !month.After(*p.EndDate))
+}
+
+func (p SubscriptionPause) overlaps(other SubscriptionPause) bool {
+	return (p.EndDate == nil || !other.StartDate.After(*p.EndDate)) &&
+		(other.EndDate == nil || !p.StartDate.After(*other.EndDate))
+}
+
+// PausedAt сообщает, приостановлена ли подписка в месяце month.
+func PausedAt(pauses []SubscriptionPause, month time.Time) bool {
+	return slices.ContainsFunc(pauses, func(p SubscriptionPause) bool {
+		return p.Covers(month)
+	})
+}
+
+// AddPause добавляет паузу к паузам подписки, упорядоченным по StartDate.
+// Пауза, примыкающая к соседней, сливается с ней: так любой непрерывный
+// отрезок приостановленных месяцев покрывается одной паузой.
+func AddPause(pauses []SubscriptionPause, pause SubscriptionPause) ([]SubscriptionPause, error) {
+	if pause.EndDate != nil && pause.EndDate.Before(pause.StartDate) {
+		return nil, errors.New("pause end_date cannot be before start_date")
+	}
+	for _, p := range pauses {
+		if p.overlaps(pause) {
+			return nil, ErrAlreadyPaused
+		}
+	}
+
+	all := append(slices.Clone(pauses), pause)
+	slices.SortFunc(all, func(a, b SubscriptionPause) int {
+		return a.StartDate.Compare(b.StartDate)
+	})
+
+	merged := make([]SubscriptionPause, 0, len(all))
+	for _, p := range all {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if last.EndDate != nil && last.EndDate.AddDate(0, 1, 0).Equal(p.StartDate) {
+				last.EndDate = p.EndDate
+				continue
+			}
+		}
+		merged = append(merged, p)
+	}
+
+	return merged, nil
+}
+
+// ResumePauses возобновляет подписку с месяца month: ближайшая пауза,
+// которая действует в month или позже, заканчивается предыдущим месяцем,
+// а ещё не начавшаяся пауза отменяется. month не может быть раньше текущего
+// месяца current: прошедшие месяцы паузы уже учтены в сводках и бюджетах.
+func ResumePauses(pauses []SubscriptionPause, month, current time.Time) ([]SubscriptionPause, error) {
+	if month.Before(current) {
+		return nil, errors.New("resume date cannot be in the past")
+	}
+
+	i := slices.IndexFunc(pauses, func(p SubscriptionPause) bool {
+		return p.EndDate == nil || !p.EndDate.Before(month)
+	})
+	if i < 0 {
+		return nil, ErrNotPaused
+	}
+
+	resumed := slices.Clone(pauses)
+	if !resumed[i].StartDate.Before(month) {
+		return slices.Delete(resumed, i, i+1), nil
+	}
+	end := month.AddDate(0, -1, 0)
+	resumed[i].EndDate = &end
+
+	return resumed, nil
+}
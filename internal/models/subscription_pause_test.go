@@ -0,0 +1,72 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestResumePauses(t *testing.T) {
+	current := month(2026, 5)
+	end := func(t time.Time) *time.Time { return &t }
+	finished := SubscriptionPause{StartDate: month(2026, 1), EndDate: end(month(2026, 3))}
+	tests := []struct {
+		name    string
+		pauses  []SubscriptionPause
+		month   time.Time
+		want    []SubscriptionPause
+		wantErr bool
+	}{
+		{
+			name:    "finished pause in the past",
+			pauses:  []SubscriptionPause{finished},
+			month:   month(2026, 1),
+			wantErr: true,
+		},
+		{
+			name:    "only finished pauses",
+			pauses:  []SubscriptionPause{finished},
+			month:   current,
+			wantErr: true,
+		},
+		{
+			name:   "open pause ends before current month",
+			pauses: []SubscriptionPause{finished, {StartDate: month(2026, 4)}},
+			month:  current,
+			want:   []SubscriptionPause{finished, {StartDate: month(2026, 4), EndDate: end(month(2026, 4))}},
+		},
+		{
+			name:   "later resume",
+			pauses: []SubscriptionPause{{StartDate: month(2026, 4)}},
+			month:  month(2026, 8),
+			want:   []SubscriptionPause{{StartDate: month(2026, 4), EndDate: end(month(2026, 7))}},
+		},
+		{
+			name:   "scheduled pause is dropped",
+			pauses: []SubscriptionPause{finished, {StartDate: month(2026, 7), EndDate: end(month(2026, 9))}},
+			month:  current,
+			want:   []SubscriptionPause{finished},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResumePauses(tt.pauses, tt.month, current)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ResumePauses = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResumePauses: %v", err)
+			}
+			equal := slices.EqualFunc(got, tt.want, func(a, b SubscriptionPause) bool {
+				return a.StartDate.Equal(b.StartDate) && (a.EndDate == nil) == (b.EndDate == nil) &&
+					(a.EndDate == nil || a.EndDate.Equal(*b.EndDate))
+			})
+			if !equal {
+				t.Fatalf("ResumePauses = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package seed
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+type service struct {
+	name   string
+	prices []int
+	// weight — относительная популярность сервиса.
+	weight int
+}
+
+// Каталог примерно отражает популярные в России подписки и их тарифы в рублях.
+var services = []service{
+	{name: "Yandex Plus", prices: []int{299, 399, 649}, weight: 30},
+	{name: "Kinopoisk", prices: []int{269, 399}, weight: 12},
+	{name: "Okko", prices: []int{199, 399, 599}, weight: 10},
+	{name: "ivi", prices: []int{199, 399}, weight: 9},
+	{name: "Wink", prices: []int{249, 449}, weight: 6},
+	{name: "Start", prices: []int{299, 499}, weight: 5},
+	{name: "VK Music", prices: []int{149, 249, 299}, weight: 10},
+	{name: "Zvuk", prices: []int{169, 249}, weight: 4},
+	{name: "Telegram Premium", prices: []int{299}, weight: 8},
+	{name: "YouTube Premium", prices: []int{199, 299}, weight: 5},
+	{name: "Spotify", prices: []int{169, 269}, weight: 4},
+	{name: "Netflix", prices: []int{599, 799, 999}, weight: 3},
+	{name: "ChatGPT Plus", prices: []int{1990, 2290}, weight: 3},
+	{name: "Sber Prime", prices: []int{199, 399}, weight: 7},
+	{name: "MTS Premium", prices: []int{249}, weight: 5},
+	{name: "iCloud+", prices: []int{59, 149, 299, 599}, weight: 8},
+}
+
+// subscriptionsPerUser — распределение числа подписок у пользователя: индекс — количество, значение — вес.
+var subscriptionsPerUser = []int{0, 20, 26, 22, 14, 9, 5, 3, 1}
+
+type Options struct {
+	Users int
+	// Seed задаёт генератор случайных чисел: одинаковые Options дают одинаковые данные.
+	Seed uint64
+	// Until — последний месяц, в котором могут начинаться подписки.
+	Until time.Time
+	// Years — за сколько лет до Until распределяются даты начала.
+	Years int
+	// CancelRate — доля отменённых подписок, от 0 до 1.
+	CancelRate float64
+}
+
+// Generate создаёт подписки для opts.Users пользователей. Идентификаторы и
+// метки времени тоже выводятся из Seed, поэтому повторный запуск даёт те же строки.
+func Generate(opts Options) []*models.Subscription {
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))
+
+	until := time.Date(opts.Until.Year(), opts.Until.Month(), 1, 0, 0, 0, 0, time.UTC)
+	months := opts.Years * 12
+	if months <= 0 {
+		months = 1
+	}
+
+	totalWeight := 0
+	for _, s := range services {
+		totalWeight += s.weight
+	}
+
+	var subs []*models.Subscription
+	for range opts.Users {
+		userID := newUUID(rng)
+		count := pickWeighted(rng, subscriptionsPerUser)
+
+		// У одного пользователя сервисы не повторяются.
+		used := map[int]bool{}
+		for range count {
+			idx := pickService(rng, totalWeight)
+			for used[idx] {
+				idx = (idx + 1) % len(services)
+			}
+			used[idx] = true
+			svc := services[idx]
+
+			start := until.AddDate(0, -rng.IntN(months), 0)
+
+			var end *time.Time
+			if rng.Float64() < opts.CancelRate {
+				// Большинство отменяют подписку в первые месяцы, некоторые держат её годами.
+				duration := 1 + int(rng.ExpFloat64()*6)
+				e := start.AddDate(0, duration-1, 0)
+				end = &e
+			}
+
+			// Подписку заводят в течение первого месяца её действия.
+			createdAt := start.Add(time.Duration(rng.Int64N(int64(28 * 24 * time.Hour)))).Truncate(time.Microsecond)
+
+			subs = append(subs, &models.Subscription{
+				ID:          newUUID(rng),
+				ServiceName: svc.name,
+				Price:       svc.prices[rng.IntN(len(svc.prices))],
+				UserID:      userID,
+				StartDate:   start,
+				EndDate:     end,
+				CreatedAt:   createdAt,
+				UpdatedAt:   createdAt,
+			})
+		}
+	}
+
+	return subs
+}
+
+func pickWeighted(rng *rand.Rand, weights []int) int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	n := rng.IntN(total)
+	for i, w := range weights {
+		if n < w {
+			return i
+		}
+		n -= w
+	}
+	return len(weights) - 1
+}
+
+func pickService(rng *rand.Rand, totalWeight int) int {
+	n := rng.IntN(totalWeight)
+	for i, s := range services {
+		if n < s.weight {
+			return i
+		}
+		n -= s.weight
+	}
+	return len(services) - 1
+}
+
+func newUUID(rng *rand.Rand) uuid.UUID {
+	var id uuid.UUID
+	for i := 0; i < len(id); i += 8 {
+		v := rng.Uint64()
+		for j := range 8 {
+			id[i+j] = byte(v >> (8 * j))
+		}
+	}
+	// Версия 4 и вариант RFC 4122, как у uuid.New.
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return id
+}
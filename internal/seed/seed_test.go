@@ -0,0 +1,59 @@
+package seed
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	opts := Options{
+		Users:      50,
+		Seed:       42,
+		Until:      time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		Years:      3,
+		CancelRate: 0.35,
+	}
+
+	first := Generate(opts)
+	second := Generate(opts)
+	if !reflect.DeepEqual(first, second) {
+		t.Fatal("Generate returned different data for the same options")
+	}
+
+	opts.Seed = 43
+	if reflect.DeepEqual(first, Generate(opts)) {
+		t.Fatal("Generate returned the same data for different seeds")
+	}
+}
+
+func TestGenerateProducesValidSubscriptions(t *testing.T) {
+	until := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	subs := Generate(Options{Users: 200, Seed: 1, Until: until, Years: 2, CancelRate: 0.5})
+	if len(subs) == 0 {
+		t.Fatal("Generate returned no subscriptions")
+	}
+
+	ids := map[string]bool{}
+	cancelled := 0
+	for _, sub := range subs {
+		if err := sub.Validate(); err != nil {
+			t.Fatalf("invalid subscription %+v: %v", sub, err)
+		}
+		if ids[sub.ID.String()] {
+			t.Fatalf("duplicate id %s", sub.ID)
+		}
+		ids[sub.ID.String()] = true
+
+		if sub.StartDate.After(until) || sub.StartDate.Before(until.AddDate(-2, 0, 0)) {
+			t.Fatalf("start_date %s is outside the requested range", sub.StartDate)
+		}
+		if sub.EndDate != nil {
+			cancelled++
+		}
+	}
+
+	if cancelled == 0 || cancelled == len(subs) {
+		t.Fatalf("expected a mix of active and cancelled subscriptions, got %d of %d cancelled", cancelled, len(subs))
+	}
+}
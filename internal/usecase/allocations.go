@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (u *CostCenterUsecase) GetAllocations(ctx context.Context, subIDString string) (dto.GetAllocationsResponse, error) {
+	ctx, span := tracer.Start(ctx, "CostCenterUsecase.GetAllocations")
+	defer span.End()
+
+	subID, err := uuid.Parse(subIDString)
+	if err != nil {
+		return dto.GetAllocationsResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	sub, err := u.Subscriptions.GetById(ctx, subID)
+	if err != nil {
+		return dto.GetAllocationsResponse{}, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if sub == nil {
+		return dto.GetAllocationsResponse{}, fmt.Errorf("subscription not found")
+	}
+
+	return u.allocationsResponse(ctx, subID)
+}
+
+// SetAllocations заменяет распределение подписки по центрам затрат.
+func (u *CostCenterUsecase) SetAllocations(ctx context.Context, subIDString string, input dto.SetAllocationsRequest) (dto.GetAllocationsResponse, error) {
+	ctx, span := tracer.Start(ctx, "CostCenterUsecase.SetAllocations")
+	defer span.End()
+
+	subID, err := uuid.Parse(subIDString)
+	if err != nil {
+		return dto.GetAllocationsResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	allocations := make([]models.Allocation, 0, len(input.Allocations))
+	for _, a := range input.Allocations {
+		ccID, err := uuid.Parse(a.CostCenterID)
+		if err != nil {
+			return dto.GetAllocationsResponse{}, fmt.Errorf("invalid cost_center_id: %w", err)
+		}
+		allocations = append(allocations, models.Allocation{CostCenterID: ccID, Percent: a.Percent})
+	}
+
+	if err := models.ValidateAllocations(allocations); err != nil {
+		return dto.GetAllocationsResponse{}, fmt.Errorf("validation model failed: %w", err)
+	}
+
+	err = u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		sub, err := u.Subscriptions.GetById(ctx, subID)
+		if err != nil {
+			return fmt.Errorf("failed to get subscription: %w", err)
+		}
+		if sub == nil {
+			return fmt.Errorf("subscription not found")
+		}
+
+		for _, a := range allocations {
+			cc, err := u.Repository.GetById(ctx, a.CostCenterID)
+			if err != nil {
+				return fmt.Errorf("failed to get cost center: %w", err)
+			}
+			if cc == nil {
+				return fmt.Errorf("cost center %s not found", a.CostCenterID)
+			}
+		}
+
+		if err := u.Repository.SetAllocations(ctx, subID, allocations); err != nil {
+			return fmt.Errorf("failed to set allocations: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.GetAllocationsResponse{}, err
+	}
+
+	return u.allocationsResponse(ctx, subID)
+}
+
+func (u *CostCenterUsecase) allocationsResponse(ctx context.Context, subID uuid.UUID) (dto.GetAllocationsResponse, error) {
+	allocations, err := u.Repository.ListAllocations(ctx, []uuid.UUID{subID})
+	if err != nil {
+		return dto.GetAllocationsResponse{}, fmt.Errorf("failed to get allocations: %w", err)
+	}
+	names, err := u.costCenterNames(ctx)
+	if err != nil {
+		return dto.GetAllocationsResponse{}, err
+	}
+
+	output := dto.GetAllocationsResponse{
+		SubscriptionID: subID.String(),
+		Allocations:    make([]dto.Allocation, 0, len(allocations[subID])),
+	}
+	for _, a := range allocations[subID] {
+		output.Allocations = append(output.Allocations, dto.Allocation{
+			CostCenterID:   a.CostCenterID.String(),
+			CostCenterName: names[a.CostCenterID],
+			Percent:        a.Percent,
+		})
+	}
+
+	return output, nil
+}
+
+func (u *CostCenterUsecase) costCenterNames(ctx context.Context) (map[uuid.UUID]string, error) {
+	centers, err := u.Repository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost centers: %w", err)
+	}
+
+	names := make(map[uuid.UUID]string, len(centers))
+	for _, cc := range centers {
+		names[cc.ID] = cc.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// BackfillServices переносит в каталог подписки, созданные до его появления.
+// Каждое название ищется по каталогу (включая синонимы); если сервиса нет, он
+// создаётся из самого частого написания, а остальные написания с тем же
+// ключом нормализации привязываются к нему. Синонимы вроде «Яндекс Плюс»
+// для «Yandex Plus» нужно завести в каталоге до запуска. При dryRun ничего не
+// записывается, но отчёт тот же.
+func (u *ServiceUsecase) BackfillServices(ctx context.Context, dryRun bool) (dto.BackfillServicesResponse, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.BackfillServices")
+	defer span.End()
+
+	output := dto.BackfillServicesResponse{
+		Links: make([]dto.BackfillServiceLink, 0),
+	}
+
+	err := u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		names, err := u.Subscriptions.UnlinkedServiceNames(ctx)
+		if err != nil {
+			return err
+		}
+
+		// planned — сервисы, которые были бы созданы в режиме dryRun.
+		planned := map[string]*models.Service{}
+		for _, n := range names {
+			key := models.NormalizeServiceName(n.Name)
+			if key == "" {
+				continue
+			}
+
+			link := dto.BackfillServiceLink{Name: n.Name, Count: n.Count}
+
+			svc, err := u.Repository.FindByName(ctx, n.Name)
+			if err != nil {
+				return fmt.Errorf("failed to resolve service %q: %w", n.Name, err)
+			}
+			if svc == nil {
+				svc = planned[key]
+			}
+			if svc == nil {
+				svc = &models.Service{
+					ID:        uuid.New(),
+					Name:      strings.TrimSpace(n.Name),
+					CreatedAt: time.Now(),
+					UpdatedAt: time.Now(),
+				}
+				if !dryRun {
+					if err := u.Repository.Create(ctx, svc); err != nil {
+						return fmt.Errorf("failed to create service %q: %w", svc.Name, err)
+					}
+				}
+				planned[key] = svc
+				link.Created = true
+			}
+			link.Service = svc.Name
+
+			if !dryRun {
+				link.Count, err = u.Subscriptions.LinkService(ctx, n.Name, svc)
+				if err != nil {
+					return err
+				}
+			}
+
+			output.Links = append(output.Links, link)
+			output.Total += link.Count
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.BackfillServicesResponse{}, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// CancelSubscription отменяет подписку: end_date становится месяцем перед
+// вступлением отмены в силу, причина и комментарий сохраняются для отчёта
+// об оттоке.
+func (u *SubscriptionUsecase) CancelSubscription(ctx context.Context, idString string, input dto.CancelSubscriptionRequest) (dto.GetSubscriptionResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.CancelSubscription")
+	defer span.End()
+
+	idUUID, err := uuid.Parse(idString)
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	now := time.Now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	effective := strings.TrimSpace(input.Effective)
+	if effective == "" {
+		effective = models.CancelEndOfPeriod
+	}
+
+	var date time.Time
+	switch effective {
+	case models.CancelImmediately, models.CancelEndOfPeriod:
+		if input.Date != "" {
+			return dto.GetSubscriptionResponse{}, fmt.Errorf("date is only allowed with effective %s", models.CancelOnDate)
+		}
+	case models.CancelOnDate:
+		if date, err = time.Parse("01-2006", input.Date); err != nil {
+			return dto.GetSubscriptionResponse{}, fmt.Errorf("invalid date format (expected MM-YYYY): %w", err)
+		}
+		if date.Before(month) {
+			return dto.GetSubscriptionResponse{}, fmt.Errorf("cancellation date cannot be in the past")
+		}
+	default:
+		return dto.GetSubscriptionResponse{}, fmt.Errorf("effective must be %s, %s or %s",
+			models.CancelImmediately, models.CancelEndOfPeriod, models.CancelOnDate)
+	}
+
+	var sub *models.Subscription
+	err = u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		sub, err = u.Repository.GetByIdForUpdate(ctx, idUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get subscription: %w", err)
+		}
+		if sub == nil {
+			return fmt.Errorf("subscription not found")
+		}
+
+		effectiveDate, err := sub.CancelEffective(effective, date, month)
+		if err != nil {
+			return err
+		}
+
+		cancellation, err := sub.Cancel(effectiveDate, strings.TrimSpace(input.Reason), strings.TrimSpace(input.Comment), now)
+		if err != nil {
+			return err
+		}
+		sub.UpdatedAt = now
+
+		if err := u.Repository.CreateCancellation(ctx, cancellation); err != nil {
+			return err
+		}
+		if err := u.Repository.Update(ctx, sub); err != nil {
+			return fmt.Errorf("failed update subscription: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, err
+	}
+
+	return u.describeSubscription(ctx, sub, now)
+}
+
+// checkEndDateChange запрещает менять end_date отменённой подписки: его
+// задаёт отмена, и снять её можно только через UncancelSubscription.
+func (u *SubscriptionUsecase) checkEndDateChange(ctx context.Context, sub *models.Subscription, end *time.Time) error {
+	if (end == nil) == (sub.EndDate == nil) && (end == nil || end.Equal(*sub.EndDate)) {
+		return nil
+	}
+
+	cancellations, err := u.Repository.ListCancellations(ctx, []uuid.UUID{sub.ID})
+	if err != nil {
+		return fmt.Errorf("failed to get cancellations: %w", err)
+	}
+	if _, ok := cancellations[sub.ID]; ok {
+		return fmt.Errorf("%w: withdraw the cancellation before changing end_date", models.ErrAlreadyCancelled)
+	}
+
+	return nil
+}
+
+// UncancelSubscription снимает отмену, пока она не вступила в силу, и
+// возвращает подписке прежний end_date.
+func (u *SubscriptionUsecase) UncancelSubscription(ctx context.Context, idString string) (dto.GetSubscriptionResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.UncancelSubscription")
+	defer span.End()
+
+	idUUID, err := uuid.Parse(idString)
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	now := time.Now()
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var sub *models.Subscription
+	err = u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		sub, err = u.Repository.GetByIdForUpdate(ctx, idUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get subscription: %w", err)
+		}
+		if sub == nil {
+			return fmt.Errorf("subscription not found")
+		}
+
+		cancellations, err := u.Repository.ListCancellations(ctx, []uuid.UUID{sub.ID})
+		if err != nil {
+			return fmt.Errorf("failed to get cancellations: %w", err)
+		}
+		cancellation, ok := cancellations[sub.ID]
+		if !ok {
+			return models.ErrNotCancelled
+		}
+		if !month.Before(cancellation.EffectiveDate) {
+			return models.ErrCancellationActive
+		}
+
+		sub.EndDate = cancellation.PreviousEndDate
+		sub.UpdatedAt = now
+
+		if err := u.Repository.DeleteCancellation(ctx, sub.ID); err != nil {
+			return err
+		}
+		if err := u.Repository.Update(ctx, sub); err != nil {
+			return fmt.Errorf("failed update subscription: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, err
+	}
+
+	return u.describeSubscription(ctx, sub, now)
+}
@@ -0,0 +1,265 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestCancelledSubscriptionEndDateIsLocked(t *testing.T) {
+	env := newTestEnv()
+	ctx := context.Background()
+	start := currentMonth().AddDate(0, -2, 0).Format("01-2006")
+	previousEnd := currentMonth().AddDate(1, 0, 0).Format("01-2006")
+	id := env.createSubscription(t, dto.CreateSubstractionRequest{
+		ServiceName: "Netflix",
+		Price:       500,
+		StartDate:   start,
+		EndDate:     &previousEnd,
+	})
+
+	cancelDate := currentMonth().AddDate(0, 3, 0).Format("01-2006")
+	if _, err := env.usecase.CancelSubscription(ctx, id, dto.CancelSubscriptionRequest{
+		Effective: models.CancelOnDate,
+		Date:      cancelDate,
+		Reason:    models.CancelReasonNotUsing,
+	}); err != nil {
+		t.Fatalf("CancelSubscription: %v", err)
+	}
+	cancelledEnd := currentMonth().AddDate(0, 2, 0).Format("01-2006")
+
+	newEnd := currentMonth().AddDate(0, 6, 0).Format("01-2006")
+	_, err := env.usecase.UpdateSubscription(ctx, id, dto.UpdateSubscriptionRequest{EndDate: &newEnd})
+	if !errors.Is(err, models.ErrAlreadyCancelled) {
+		t.Fatalf("UpdateSubscription end_date error = %v, want %v", err, models.ErrAlreadyCancelled)
+	}
+	if got := env.getSubscription(t, id).EndDate; got == nil || *got != cancelledEnd {
+		t.Fatalf("end_date after rejected PUT = %v, want %s", got, cancelledEnd)
+	}
+
+	// PUT без изменения end_date отменённой подписке не мешает.
+	if _, err := env.usecase.UpdateSubscription(ctx, id, dto.UpdateSubscriptionRequest{Price: 600, EndDate: &cancelledEnd}); err != nil {
+		t.Fatalf("UpdateSubscription with same end_date: %v", err)
+	}
+
+	got, err := env.usecase.UncancelSubscription(ctx, id)
+	if err != nil {
+		t.Fatalf("UncancelSubscription: %v", err)
+	}
+	if got.EndDate == nil || *got.EndDate != previousEnd {
+		t.Fatalf("end_date after uncancel = %v, want %s", got.EndDate, previousEnd)
+	}
+	if got.Cancellation != nil {
+		t.Fatalf("cancellation after uncancel = %+v, want none", got.Cancellation)
+	}
+
+	if _, err := env.usecase.UpdateSubscription(ctx, id, dto.UpdateSubscriptionRequest{EndDate: &newEnd}); err != nil {
+		t.Fatalf("UpdateSubscription after uncancel: %v", err)
+	}
+}
+
+func TestCancelSubscriptionWithoutBilledMonths(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		effective string
+		wantErr   error
+		wantEnd   string
+	}{
+		{name: "starts this month, immediately", start: currentMonth().Format("01-2006"), effective: models.CancelImmediately, wantErr: models.ErrCancelBeforeStart},
+		{name: "starts this month, end of period", start: currentMonth().Format("01-2006"), effective: models.CancelEndOfPeriod, wantEnd: currentMonth().Format("01-2006")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			id := env.createSubscription(t, dto.CreateSubstractionRequest{ServiceName: "Netflix", Price: 500, StartDate: tt.start})
+
+			got, err := env.usecase.CancelSubscription(context.Background(), id, dto.CancelSubscriptionRequest{
+				Effective: tt.effective,
+				Reason:    models.CancelReasonOther,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("CancelSubscription error = %v, want %v", err, tt.wantErr)
+				}
+				if env.getSubscription(t, id).EndDate != nil {
+					t.Fatalf("end_date set by a rejected cancellation")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CancelSubscription: %v", err)
+			}
+			if got.EndDate == nil || *got.EndDate != tt.wantEnd {
+				t.Fatalf("end_date = %v, want %s", got.EndDate, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestCancelSubscriptionRespectsEndDate(t *testing.T) {
+	start := currentMonth().AddDate(0, -2, 0).Format("01-2006")
+	tests := []struct {
+		name      string
+		billing   string
+		endDate   string
+		effective string
+		date      string
+		wantErr   bool
+		wantEnd   string
+	}{
+		{
+			name:      "end of yearly period capped at end_date",
+			billing:   models.BillingYearly,
+			endDate:   currentMonth().AddDate(0, 3, 0).Format("01-2006"),
+			effective: models.CancelEndOfPeriod,
+			wantEnd:   currentMonth().AddDate(0, 3, 0).Format("01-2006"),
+		},
+		{
+			name:      "end of yearly period before end_date",
+			billing:   models.BillingYearly,
+			endDate:   currentMonth().AddDate(2, 0, 0).Format("01-2006"),
+			effective: models.CancelEndOfPeriod,
+			wantEnd:   currentMonth().AddDate(0, 9, 0).Format("01-2006"),
+		},
+		{
+			name:      "already ended",
+			billing:   models.BillingMonthly,
+			endDate:   currentMonth().AddDate(0, -1, 0).Format("01-2006"),
+			effective: models.CancelEndOfPeriod,
+			wantErr:   true,
+		},
+		{
+			name:      "explicit date after end_date",
+			billing:   models.BillingMonthly,
+			endDate:   currentMonth().AddDate(0, 3, 0).Format("01-2006"),
+			effective: models.CancelOnDate,
+			date:      currentMonth().AddDate(0, 6, 0).Format("01-2006"),
+			wantErr:   true,
+		},
+		{
+			name:      "explicit date right after end_date",
+			billing:   models.BillingMonthly,
+			endDate:   currentMonth().AddDate(0, 3, 0).Format("01-2006"),
+			effective: models.CancelOnDate,
+			date:      currentMonth().AddDate(0, 4, 0).Format("01-2006"),
+			wantEnd:   currentMonth().AddDate(0, 3, 0).Format("01-2006"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			svc, _ := env.createService(t, "Netflix", 500)
+			plan := &models.ServicePlan{ID: uuid.New(), ServiceID: svc.ID, Name: "Annual", Price: 5000, BillingPeriod: tt.billing}
+			if err := env.plans.Create(context.Background(), plan); err != nil {
+				t.Fatalf("create plan: %v", err)
+			}
+			id := env.createSubscription(t, dto.CreateSubstractionRequest{
+				PlanID:    plan.ID.String(),
+				StartDate: start,
+				EndDate:   &tt.endDate,
+			})
+
+			got, err := env.usecase.CancelSubscription(context.Background(), id, dto.CancelSubscriptionRequest{
+				Effective: tt.effective,
+				Date:      tt.date,
+				Reason:    models.CancelReasonTooExpensive,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CancelSubscription succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CancelSubscription: %v", err)
+			}
+			if got.EndDate == nil || *got.EndDate != tt.wantEnd {
+				t.Fatalf("end_date = %v, want %s", got.EndDate, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestCancelAndUncancelSubscription(t *testing.T) {
+	start := currentMonth().AddDate(-1, 0, 0).Format("01-2006")
+	tests := []struct {
+		name          string
+		input         dto.CancelSubscriptionRequest
+		wantErr       bool
+		wantEnd       string
+		wantUncancel  error
+		wantEffective string
+	}{
+		{
+			name:          "immediately",
+			input:         dto.CancelSubscriptionRequest{Effective: models.CancelImmediately},
+			wantEnd:       currentMonth().AddDate(0, -1, 0).Format("01-2006"),
+			wantEffective: currentMonth().Format("01-2006"),
+			wantUncancel:  models.ErrCancellationActive,
+		},
+		{
+			name:          "end of period by default",
+			input:         dto.CancelSubscriptionRequest{},
+			wantEnd:       currentMonth().Format("01-2006"),
+			wantEffective: currentMonth().AddDate(0, 1, 0).Format("01-2006"),
+		},
+		{
+			name:          "date",
+			input:         dto.CancelSubscriptionRequest{Effective: models.CancelOnDate, Date: currentMonth().AddDate(0, 4, 0).Format("01-2006")},
+			wantEnd:       currentMonth().AddDate(0, 3, 0).Format("01-2006"),
+			wantEffective: currentMonth().AddDate(0, 4, 0).Format("01-2006"),
+		},
+		{
+			name:    "date in the past",
+			input:   dto.CancelSubscriptionRequest{Effective: models.CancelOnDate, Date: currentMonth().AddDate(0, -1, 0).Format("01-2006")},
+			wantErr: true,
+		},
+		{
+			name:    "date without effective date",
+			input:   dto.CancelSubscriptionRequest{Date: currentMonth().AddDate(0, 4, 0).Format("01-2006")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			ctx := context.Background()
+			id := env.createSubscription(t, dto.CreateSubstractionRequest{ServiceName: "Netflix", Price: 500, StartDate: start})
+
+			if _, err := env.usecase.UncancelSubscription(ctx, id); !errors.Is(err, models.ErrNotCancelled) {
+				t.Fatalf("UncancelSubscription before cancel error = %v, want %v", err, models.ErrNotCancelled)
+			}
+
+			tt.input.Reason = models.CancelReasonSwitched
+			got, err := env.usecase.CancelSubscription(ctx, id, tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CancelSubscription succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CancelSubscription: %v", err)
+			}
+			if got.EndDate == nil || *got.EndDate != tt.wantEnd {
+				t.Fatalf("end_date = %v, want %s", got.EndDate, tt.wantEnd)
+			}
+			if got.Cancellation == nil || got.Cancellation.EffectiveDate != tt.wantEffective {
+				t.Fatalf("cancellation = %+v, want effective_date %s", got.Cancellation, tt.wantEffective)
+			}
+
+			uncancelled, err := env.usecase.UncancelSubscription(ctx, id)
+			if !errors.Is(err, tt.wantUncancel) {
+				t.Fatalf("UncancelSubscription error = %v, want %v", err, tt.wantUncancel)
+			}
+			if err == nil && uncancelled.EndDate != nil {
+				t.Fatalf("end_date after uncancel = %s, want none", *uncancelled.EndDate)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (u *BudgetUsecase) CreateBudget(ctx context.Context, input dto.CreateBudgetRequest) (dto.CreateBudgetResponse, error) {
+	ctx, span := tracer.Start(ctx, "BudgetUsecase.CreateBudget")
+	defer span.End()
+
+	thresholds := input.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = models.DefaultBudgetThresholds
+	}
+
+	b := &models.Budget{
+		ID:         uuid.New(),
+		Name:       strings.TrimSpace(input.Name),
+		Amount:     input.Amount,
+		Scope:      input.Scope,
+		ScopeValue: budgetScopeValue(input.Scope, input.ScopeValue),
+		Period:     input.Period,
+		Thresholds: models.NormalizeThresholds(thresholds),
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	if err := b.Validate(); err != nil {
+		return dto.CreateBudgetResponse{}, fmt.Errorf("validation model failed: %w", err)
+	}
+
+	if err := u.Repository.Create(ctx, b); err != nil {
+		return dto.CreateBudgetResponse{}, fmt.Errorf("db failed to create budget: %w", err)
+	}
+
+	return dto.CreateBudgetResponse{ID: b.ID.String()}, nil
+}
+
+// budgetScopeValue приводит значение области к виду, в котором оно хранится
+// у подписок: user_id — в каноничной записи uuid, тег — нормализованным.
+func budgetScopeValue(scope, value string) string {
+	value = strings.TrimSpace(value)
+	switch scope {
+	case models.BudgetScopeUser:
+		if id, err := uuid.Parse(value); err == nil {
+			return id.String()
+		}
+	case models.BudgetScopeTag:
+		return models.NormalizeTag(value)
+	}
+	return value
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (u *CostCenterUsecase) CreateCostCenter(ctx context.Context, input dto.CreateCostCenterRequest) (dto.CreateCostCenterResponse, error) {
+	ctx, span := tracer.Start(ctx, "CostCenterUsecase.CreateCostCenter")
+	defer span.End()
+
+	cc := &models.CostCenter{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(input.Name),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := cc.Validate(); err != nil {
+		return dto.CreateCostCenterResponse{}, fmt.Errorf("validation model failed: %w", err)
+	}
+
+	if err := u.Repository.Create(ctx, cc); err != nil {
+		return dto.CreateCostCenterResponse{}, fmt.Errorf("db failed to create cost center: %w", err)
+	}
+
+	return dto.CreateCostCenterResponse{ID: cc.ID.String()}, nil
+}
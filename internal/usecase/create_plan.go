@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (u *ServiceUsecase) CreatePlan(ctx context.Context, serviceIDString string, input dto.CreatePlanRequest) (dto.CreatePlanResponse, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.CreatePlan")
+	defer span.End()
+
+	serviceID, err := uuid.Parse(serviceIDString)
+	if err != nil {
+		return dto.CreatePlanResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	plan := &models.ServicePlan{
+		ID:            uuid.New(),
+		ServiceID:     serviceID,
+		Name:          strings.TrimSpace(input.Name),
+		Price:         input.Price,
+		BillingPeriod: billingPeriodOrMonthly(input.BillingPeriod),
+		Default:       input.Default,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+
+	if err := plan.Validate(); err != nil {
+		return dto.CreatePlanResponse{}, fmt.Errorf("validation model failed: %w", err)
+	}
+
+	err = u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		svc, err := u.Repository.GetById(ctx, serviceID)
+		if err != nil {
+			return fmt.Errorf("failed to get service: %w", err)
+		}
+		if svc == nil {
+			return fmt.Errorf("service not found")
+		}
+
+		if plan.Default {
+			if err := u.unsetDefaultPlans(ctx, svc, plan.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := u.Plans.Create(ctx, plan); err != nil {
+			return fmt.Errorf("db failed to create plan: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.CreatePlanResponse{}, err
+	}
+
+	return dto.CreatePlanResponse{ID: plan.ID.String()}, nil
+}
+
+// unsetDefaultPlans снимает отметку по умолчанию со всех тарифов сервиса,
+// кроме keep: тариф по умолчанию у сервиса один.
+func (u *ServiceUsecase) unsetDefaultPlans(ctx context.Context, svc *models.Service, keep uuid.UUID) error {
+	for i := range svc.Plans {
+		plan := &svc.Plans[i]
+		if plan.ID == keep || !plan.Default {
+			continue
+		}
+		plan.Default = false
+		plan.UpdatedAt = time.Now()
+		if err := u.Plans.Update(ctx, plan); err != nil {
+			return fmt.Errorf("failed update plan: %w", err)
+		}
+	}
+	return nil
+}
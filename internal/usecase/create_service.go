@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (u *ServiceUsecase) CreateService(ctx context.Context, input dto.CreateServiceRequest) (dto.CreateServiceResponse, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.CreateService")
+	defer span.End()
+
+	now := time.Now()
+	id := uuid.New()
+	svc := &models.Service{
+		ID:        id,
+		Name:      strings.TrimSpace(input.Name),
+		Aliases:   input.Aliases,
+		LogoURL:   input.LogoURL,
+		Category:  input.Category,
+		Plans:     servicePlans(id, input.Plans, now),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := svc.Validate(); err != nil {
+		return dto.CreateServiceResponse{}, fmt.Errorf("validation model failed: %w", err)
+	}
+
+	if err := u.Repository.Create(ctx, svc); err != nil {
+		return dto.CreateServiceResponse{}, fmt.Errorf("db failed to create service: %w", err)
+	}
+
+	return dto.CreateServiceResponse{ID: svc.ID.String()}, nil
+}
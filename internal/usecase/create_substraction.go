@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/I-Van-Radkov/subscription-service/internal/dto"
@@ -11,6 +12,9 @@ import (
 )
 
 func (u *SubscriptionUsecase) CreateSubscription(ctx context.Context, input dto.CreateSubstractionRequest) (dto.CreateSubstractionResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.CreateSubscription")
+	defer span.End()
+
 	userID, err := uuid.Parse(input.UserID)
 	if err != nil {
 		return dto.CreateSubstractionResponse{}, fmt.Errorf("invalid user_id format: %w", err)
@@ -30,15 +34,50 @@ func (u *SubscriptionUsecase) CreateSubscription(ctx context.Context, input dto.
 		end = &t
 	}
 
+	var trialEnd *time.Time
+	if input.TrialEnd != nil {
+		t, err := time.Parse("01-2006", *input.TrialEnd)
+		if err != nil {
+			return dto.CreateSubstractionResponse{}, fmt.Errorf("invalid trial_end_date format (expected MM-YYYY): %w", err)
+		}
+		trialEnd = &t
+	}
+
 	sub := &models.Subscription{
-		ID:          uuid.New(),
-		ServiceName: input.ServiceName,
-		Price:       input.Price,
-		UserID:      userID,
-		StartDate:   start,
-		EndDate:     end,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		ID:           uuid.New(),
+		ServiceName:  input.ServiceName,
+		Price:        input.Price,
+		UserID:       userID,
+		StartDate:    start,
+		EndDate:      end,
+		TrialEndDate: trialEnd,
+		IntroPrice:   input.IntroPrice,
+		IntroMonths:  input.IntroMonths,
+		Category:     strings.TrimSpace(input.Category),
+		Tags:         models.NormalizeTags(input.Tags),
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	if input.PlanID != "" {
+		planID, err := uuid.Parse(input.PlanID)
+		if err != nil {
+			return dto.CreateSubstractionResponse{}, fmt.Errorf("invalid plan_id format: %w", err)
+		}
+		if err := u.resolvePlan(ctx, sub, planID); err != nil {
+			return dto.CreateSubstractionResponse{}, err
+		}
+		if input.ServiceName != "" {
+			name, err := u.canonicalServiceName(ctx, input.ServiceName)
+			if err != nil {
+				return dto.CreateSubstractionResponse{}, err
+			}
+			if name != sub.ServiceName {
+				return dto.CreateSubstractionResponse{}, fmt.Errorf("plan belongs to service %q, not %q", sub.ServiceName, input.ServiceName)
+			}
+		}
+	} else if err := u.resolveService(ctx, sub); err != nil {
+		return dto.CreateSubstractionResponse{}, err
 	}
 
 	if err := sub.Validate(); err != nil {
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// DeleteBudget удаляет бюджет вместе с отметками об отправленных предупреждениях.
+func (u *BudgetUsecase) DeleteBudget(ctx context.Context, idString string) error {
+	ctx, span := tracer.Start(ctx, "BudgetUsecase.DeleteBudget")
+	defer span.End()
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return fmt.Errorf("invalid id format: %w", err)
+	}
+
+	if err := u.Repository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// DeleteCostCenter удаляет центр затрат, на который не распределены подписки.
+func (u *CostCenterUsecase) DeleteCostCenter(ctx context.Context, idString string) error {
+	ctx, span := tracer.Start(ctx, "CostCenterUsecase.DeleteCostCenter")
+	defer span.End()
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return fmt.Errorf("invalid id format: %w", err)
+	}
+
+	if err := u.Repository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete cost center: %w", err)
+	}
+
+	return nil
+}
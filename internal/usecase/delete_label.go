@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+)
+
+// DeleteTag снимает тег со всех подписок.
+func (u *SubscriptionUsecase) DeleteTag(ctx context.Context, name string) (dto.RenameLabelResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.DeleteTag")
+	defer span.End()
+
+	updated, err := u.Repository.DeleteTag(ctx, models.NormalizeTag(name))
+	if err != nil {
+		return dto.RenameLabelResponse{}, fmt.Errorf("failed to delete tag: %w", err)
+	}
+
+	return dto.RenameLabelResponse{Updated: updated}, nil
+}
+
+// DeleteCategory снимает категорию со всех подписок.
+func (u *SubscriptionUsecase) DeleteCategory(ctx context.Context, name string) (dto.RenameLabelResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.DeleteCategory")
+	defer span.End()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return dto.RenameLabelResponse{}, fmt.Errorf("category name is required")
+	}
+
+	updated, err := u.Repository.RenameCategory(ctx, name, "")
+	if err != nil {
+		return dto.RenameLabelResponse{}, fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return dto.RenameLabelResponse{Updated: updated}, nil
+}
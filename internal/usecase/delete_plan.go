@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// DeletePlan удаляет тариф. Подписки на него отвязываются и дальше
+// считаются по своей цене.
+func (u *ServiceUsecase) DeletePlan(ctx context.Context, idString string) error {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.DeletePlan")
+	defer span.End()
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return fmt.Errorf("invalid id format: %w", err)
+	}
+
+	if err := u.Plans.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete plan: %w", err)
+	}
+
+	return nil
+}
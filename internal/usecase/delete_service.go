@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// DeleteService удаляет сервис из каталога. Подписки остаются с прежним
+// service_name, но без ссылки на каталог.
+func (u *ServiceUsecase) DeleteService(ctx context.Context, idString string) error {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.DeleteService")
+	defer span.End()
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return fmt.Errorf("invalid id format: %w", err)
+	}
+
+	if err := u.Repository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete service: %w", err)
+	}
+
+	return nil
+}
@@ -8,6 +8,9 @@ import (
 )
 
 func (u *SubscriptionUsecase) DeleteSubscription(ctx context.Context, idString string) error {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.DeleteSubscription")
+	defer span.End()
+
 	idUUID, err := uuid.Parse(idString)
 	if err != nil {
 		return fmt.Errorf("invalid id format: %w", err)
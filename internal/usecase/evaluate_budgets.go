@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+)
+
+// EvaluateBudgets пересчитывает расходы всех бюджетов на момент now и
+// отправляет предупреждения о порогах, достигнутых впервые в текущем
+// периоде. Возвращает число отправленных предупреждений; ошибка одного
+// бюджета не мешает проверить остальные.
+func (u *BudgetUsecase) EvaluateBudgets(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "BudgetUsecase.EvaluateBudgets")
+	defer span.End()
+
+	budgets, err := u.Repository.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	sent := 0
+	var errs []error
+	for _, b := range budgets {
+		n, err := u.evaluateBudget(ctx, b, now)
+		sent += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("budget %s: %w", b.ID, err))
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func (u *BudgetUsecase) evaluateBudget(ctx context.Context, b *models.Budget, now time.Time) (int, error) {
+	spend, err := u.spend(ctx, b, now)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, threshold := range spend.reached(b) {
+		claimed, err := u.Repository.ClaimAlert(ctx, b.ID, spend.start, threshold)
+		if err != nil {
+			return sent, fmt.Errorf("failed to claim alert: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+
+		alert := models.BudgetAlert{
+			Budget:      *b,
+			PeriodStart: spend.start,
+			Threshold:   threshold,
+			Actual:      spend.actual,
+			Committed:   spend.committed,
+			Percent:     spend.percent(b),
+		}
+		if err := u.Notifier.Notify(ctx, alert); err != nil {
+			// Снимаем отметку, чтобы предупреждение ушло при следующей проверке.
+			if releaseErr := u.Repository.ReleaseAlert(ctx, b.ID, spend.start, threshold); releaseErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to release alert: %w", releaseErr))
+			}
+			return sent, fmt.Errorf("failed to send alert: %w", err)
+		}
+		sent++
+	}
+
+	return sent, nil
+}
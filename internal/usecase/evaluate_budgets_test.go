@@ -0,0 +1,136 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/adapter"
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// fakeNotifier запоминает отправленные предупреждения; при fail отправка
+// не удаётся.
+type fakeNotifier struct {
+	fail   bool
+	alerts []models.BudgetAlert
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, alert models.BudgetAlert) error {
+	if n.fail {
+		return errors.New("webhook unavailable")
+	}
+	n.alerts = append(n.alerts, alert)
+	return nil
+}
+
+func newBudgetEnv(t *testing.T, period string, amount int) (*testEnv, *usecase.BudgetUsecase, *fakeNotifier) {
+	t.Helper()
+	env := newTestEnv()
+	repo := adapter.NewMemoryBudgetRepo(env.store)
+	notifier := &fakeNotifier{}
+	budget := &models.Budget{
+		ID:         uuid.New(),
+		Name:       "Streaming",
+		Amount:     amount,
+		Scope:      models.BudgetScopeOrganization,
+		Period:     period,
+		Thresholds: models.DefaultBudgetThresholds,
+	}
+	if err := repo.Create(context.Background(), budget); err != nil {
+		t.Fatalf("create budget: %v", err)
+	}
+	return env, usecase.NewBudgetUsecase(repo, env.subs, notifier), notifier
+}
+
+func evaluate(t *testing.T, u *usecase.BudgetUsecase, now time.Time, wantSent int) {
+	t.Helper()
+	sent, err := u.EvaluateBudgets(context.Background(), now)
+	if err != nil {
+		t.Fatalf("EvaluateBudgets: %v", err)
+	}
+	if sent != wantSent {
+		t.Fatalf("EvaluateBudgets sent %d alerts, want %d", sent, wantSent)
+	}
+}
+
+func TestEvaluateBudgetsAlertsOncePerPeriod(t *testing.T) {
+	env, budgets, notifier := newBudgetEnv(t, models.BudgetMonthly, 1000)
+	env.createSubscription(t, dto.CreateSubstractionRequest{ServiceName: "Netflix", Price: 600, StartDate: "01-2025"})
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	evaluate(t, budgets, now, 1)
+	evaluate(t, budgets, now.AddDate(0, 0, 1), 0)
+	if len(notifier.alerts) != 1 || notifier.alerts[0].Threshold != 50 || !notifier.alerts[0].PeriodStart.Equal(month(2025, 6)) {
+		t.Fatalf("alerts = %+v, want one 50%% alert for 06-2025", notifier.alerts)
+	}
+
+	env.createSubscription(t, dto.CreateSubstractionRequest{ServiceName: "Spotify", Price: 300, StartDate: "01-2025"})
+	evaluate(t, budgets, now, 1)
+	if got := notifier.alerts[1]; got.Threshold != 80 || got.Committed != 900 || got.Percent != 90 {
+		t.Fatalf("second alert = %+v, want 80%% threshold at 900", got)
+	}
+
+	// Новый период — пороги снова не отмечены.
+	evaluate(t, budgets, now.AddDate(0, 1, 0), 2)
+}
+
+func TestEvaluateBudgetsRetriesFailedNotification(t *testing.T) {
+	env, budgets, notifier := newBudgetEnv(t, models.BudgetMonthly, 1000)
+	env.createSubscription(t, dto.CreateSubstractionRequest{ServiceName: "Netflix", Price: 1200, StartDate: "01-2025"})
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	notifier.fail = true
+	sent, err := budgets.EvaluateBudgets(context.Background(), now)
+	if err == nil {
+		t.Fatalf("EvaluateBudgets succeeded with a failing notifier")
+	}
+	if sent != 0 {
+		t.Fatalf("EvaluateBudgets sent %d alerts, want 0", sent)
+	}
+
+	notifier.fail = false
+	evaluate(t, budgets, now, 3)
+	evaluate(t, budgets, now, 0)
+	for i, want := range []int{50, 80, 100} {
+		if notifier.alerts[i].Threshold != want {
+			t.Fatalf("alert %d threshold = %d, want %d", i, notifier.alerts[i].Threshold, want)
+		}
+	}
+}
+
+func TestEvaluateBudgetsSpreadsYearlyPlans(t *testing.T) {
+	tests := []struct {
+		name      string
+		period    string
+		amount    int
+		wantAlert []int
+		committed int
+	}{
+		// 6000 в год — 500 в месяц, а не 6000 в каждом месяце.
+		{name: "monthly budget", period: models.BudgetMonthly, amount: 1000, wantAlert: []int{50}, committed: 500},
+		{name: "yearly budget", period: models.BudgetYearly, amount: 10000, wantAlert: []int{50}, committed: 6000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, budgets, notifier := newBudgetEnv(t, tt.period, tt.amount)
+			svc, _ := env.createService(t, "Netflix", 500)
+			plan := &models.ServicePlan{ID: uuid.New(), ServiceID: svc.ID, Name: "Annual", Price: 6000, BillingPeriod: models.BillingYearly}
+			if err := env.plans.Create(context.Background(), plan); err != nil {
+				t.Fatalf("create plan: %v", err)
+			}
+			env.createSubscription(t, dto.CreateSubstractionRequest{PlanID: plan.ID.String(), StartDate: "01-2025"})
+
+			evaluate(t, budgets, time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC), len(tt.wantAlert))
+			for i, alert := range notifier.alerts {
+				if alert.Threshold != tt.wantAlert[i] || alert.Committed != tt.committed {
+					t.Fatalf("alert %d = %d%% at %d, want %d%% at %d", i, alert.Threshold, alert.Committed, tt.wantAlert[i], tt.committed)
+				}
+			}
+		})
+	}
+}
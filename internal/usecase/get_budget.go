@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (u *BudgetUsecase) GetBudget(ctx context.Context, idString string) (dto.GetBudgetResponse, error) {
+	ctx, span := tracer.Start(ctx, "BudgetUsecase.GetBudget")
+	defer span.End()
+
+	b, err := u.getBudget(ctx, idString)
+	if err != nil {
+		return dto.GetBudgetResponse{}, err
+	}
+
+	return budgetResponse(b), nil
+}
+
+func (u *BudgetUsecase) GetBudgetsList(ctx context.Context) (dto.GetBudgetListResponse, error) {
+	ctx, span := tracer.Start(ctx, "BudgetUsecase.GetBudgetsList")
+	defer span.End()
+
+	budgets, err := u.Repository.List(ctx)
+	if err != nil {
+		return dto.GetBudgetListResponse{}, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	output := dto.GetBudgetListResponse{
+		Total: len(budgets),
+		List:  make([]dto.GetBudgetResponse, 0, len(budgets)),
+	}
+	for _, b := range budgets {
+		output.List = append(output.List, budgetResponse(b))
+	}
+
+	return output, nil
+}
+
+func (u *BudgetUsecase) getBudget(ctx context.Context, idString string) (*models.Budget, error) {
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	b, err := u.Repository.GetById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget: %w", err)
+	}
+	if b == nil {
+		return nil, fmt.Errorf("budget not found")
+	}
+
+	return b, nil
+}
+
+func budgetResponse(b *models.Budget) dto.GetBudgetResponse {
+	return dto.GetBudgetResponse{
+		ID:         b.ID.String(),
+		Name:       b.Name,
+		Amount:     b.Amount,
+		Scope:      b.Scope,
+		ScopeValue: b.ScopeValue,
+		Period:     b.Period,
+		Thresholds: b.Thresholds,
+	}
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// budgetSpend — расходы бюджета за период, в который попадает момент оценки.
+type budgetSpend struct {
+	start     time.Time
+	end       time.Time
+	actual    int
+	committed int
+}
+
+func (s budgetSpend) percent(b *models.Budget) int {
+	return s.committed * 100 / b.Amount
+}
+
+// reached возвращает пороги бюджета, которых достигли расходы периода.
+func (s budgetSpend) reached(b *models.Budget) []int {
+	percent := s.percent(b)
+	out := []int{}
+	for _, t := range b.Thresholds {
+		if percent >= t {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func (u *BudgetUsecase) GetBudgetStatus(ctx context.Context, idString string) (dto.BudgetStatus, error) {
+	ctx, span := tracer.Start(ctx, "BudgetUsecase.GetBudgetStatus")
+	defer span.End()
+
+	b, err := u.getBudget(ctx, idString)
+	if err != nil {
+		return dto.BudgetStatus{}, err
+	}
+
+	return u.budgetStatus(ctx, b, time.Now())
+}
+
+func (u *BudgetUsecase) GetBudgetStatuses(ctx context.Context) (dto.GetBudgetStatusListResponse, error) {
+	ctx, span := tracer.Start(ctx, "BudgetUsecase.GetBudgetStatuses")
+	defer span.End()
+
+	budgets, err := u.Repository.List(ctx)
+	if err != nil {
+		return dto.GetBudgetStatusListResponse{}, fmt.Errorf("failed to get budgets: %w", err)
+	}
+
+	now := time.Now()
+	output := dto.GetBudgetStatusListResponse{
+		Total: len(budgets),
+		List:  make([]dto.BudgetStatus, 0, len(budgets)),
+	}
+	for _, b := range budgets {
+		status, err := u.budgetStatus(ctx, b, now)
+		if err != nil {
+			return dto.GetBudgetStatusListResponse{}, err
+		}
+		output.List = append(output.List, status)
+	}
+
+	return output, nil
+}
+
+func (u *BudgetUsecase) budgetStatus(ctx context.Context, b *models.Budget, now time.Time) (dto.BudgetStatus, error) {
+	spend, err := u.spend(ctx, b, now)
+	if err != nil {
+		return dto.BudgetStatus{}, err
+	}
+
+	alerted, err := u.Repository.AlertedThresholds(ctx, b.ID, spend.start)
+	if err != nil {
+		return dto.BudgetStatus{}, fmt.Errorf("failed to get budget alerts: %w", err)
+	}
+	if alerted == nil {
+		alerted = []int{}
+	}
+
+	return dto.BudgetStatus{
+		Budget:            budgetResponse(b),
+		PeriodStart:       spend.start.Format("01-2006"),
+		PeriodEnd:         spend.end.Format("01-2006"),
+		Actual:            spend.actual,
+		Committed:         spend.committed,
+		Remaining:         max(b.Amount-spend.committed, 0),
+		Percent:           spend.percent(b),
+		Exceeded:          spend.committed > b.Amount,
+		ReachedThresholds: spend.reached(b),
+		AlertedThresholds: alerted,
+	}, nil
+}
+
+// spend считает расходы каждого месяца периода так же, как сводка за этот
+// месяц. Actual включает месяцы до текущего, Committed — весь период: для
+// годового бюджета это расходы по подпискам, которые уже оформлены на
+// оставшиеся месяцы.
+func (u *BudgetUsecase) spend(ctx context.Context, b *models.Budget, now time.Time) (budgetSpend, error) {
+	start, end := b.PeriodAt(now)
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	spend := budgetSpend{start: start, end: end}
+	for m := start; !m.After(end); m = m.AddDate(0, 1, 0) {
+		total, err := u.monthSpend(ctx, b, m)
+		if err != nil {
+			return budgetSpend{}, err
+		}
+		spend.committed += total
+		if !m.After(current) {
+			spend.actual += total
+		}
+	}
+
+	return spend, nil
+}
+
+// monthSpend возвращает расходы бюджета за месяц month. Годовые тарифы
+// входят в SumForPeriod месячной долей, поэтому за год бюджета их цена
+// набирается один раз.
+func (u *BudgetUsecase) monthSpend(ctx context.Context, b *models.Budget, month time.Time) (int, error) {
+	switch b.Scope {
+	case models.BudgetScopeUser:
+		userID, err := uuid.Parse(b.ScopeValue)
+		if err != nil {
+			return 0, fmt.Errorf("invalid budget user_id: %w", err)
+		}
+		total, err := u.Subscriptions.SumForPeriod(ctx, userID, "", month, &month)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get summary from DB: %w", err)
+		}
+		return total, nil
+	case models.BudgetScopeTag:
+		totals, err := u.Subscriptions.SumForPeriodByTag(ctx, uuid.Nil, "", month, &month)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get summary by tag from DB: %w", err)
+		}
+		for _, t := range totals {
+			if t.Tag == b.ScopeValue {
+				return t.Total, nil
+			}
+		}
+		return 0, nil
+	default:
+		total, err := u.Subscriptions.SumForPeriod(ctx, uuid.Nil, "", month, &month)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get summary from DB: %w", err)
+		}
+		return total, nil
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/google/uuid"
+)
+
+// GetChurnReport считает отмены подписок, вступающие в силу с start по end
+// включительно, по причине и сервису.
+func (u *SubscriptionUsecase) GetChurnReport(ctx context.Context, userIdStr, start, end string) (dto.GetChurnReportResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.GetChurnReport")
+	defer span.End()
+
+	var userId uuid.UUID
+	if userIdStr != "" {
+		parsed, err := uuid.Parse(userIdStr)
+		if err != nil {
+			return dto.GetChurnReportResponse{}, fmt.Errorf("invalid user_id: %w", err)
+		}
+		userId = parsed
+	}
+
+	startDate, err := time.Parse("01-2006", start)
+	if err != nil {
+		return dto.GetChurnReportResponse{}, fmt.Errorf("invalid start_date format (expected MM-YYYY): %w", err)
+	}
+	endDate, err := time.Parse("01-2006", end)
+	if err != nil {
+		return dto.GetChurnReportResponse{}, fmt.Errorf("invalid end_date format (expected MM-YYYY): %w", err)
+	}
+	if endDate.Before(startDate) {
+		return dto.GetChurnReportResponse{}, fmt.Errorf("end_date must not be before start_date")
+	}
+
+	totals, err := u.Repository.ChurnReport(ctx, userId, startDate, endDate)
+	if err != nil {
+		return dto.GetChurnReportResponse{}, fmt.Errorf("failed to get churn from DB: %w", err)
+	}
+
+	output := dto.GetChurnReportResponse{
+		Reasons:  make([]dto.ChurnReasonCount, 0),
+		Services: make([]dto.ChurnServiceCount, 0),
+		Groups:   make([]dto.ChurnGroup, 0, len(totals)),
+	}
+	byReason := map[string]int{}
+	byService := map[string]int{}
+	for _, t := range totals {
+		output.Total += t.Count
+		byReason[t.Reason] += t.Count
+		byService[t.ServiceName] += t.Count
+		output.Groups = append(output.Groups, dto.ChurnGroup{Reason: t.Reason, ServiceName: t.ServiceName, Count: t.Count})
+	}
+	for reason, count := range byReason {
+		output.Reasons = append(output.Reasons, dto.ChurnReasonCount{Reason: reason, Count: count})
+	}
+	for service, count := range byService {
+		output.Services = append(output.Services, dto.ChurnServiceCount{ServiceName: service, Count: count})
+	}
+	sort.Slice(output.Reasons, func(i, j int) bool {
+		if output.Reasons[i].Count != output.Reasons[j].Count {
+			return output.Reasons[i].Count > output.Reasons[j].Count
+		}
+		return output.Reasons[i].Reason < output.Reasons[j].Reason
+	})
+	sort.Slice(output.Services, func(i, j int) bool {
+		if output.Services[i].Count != output.Services[j].Count {
+			return output.Services[i].Count > output.Services[j].Count
+		}
+		return output.Services[i].ServiceName < output.Services[j].ServiceName
+	})
+
+	return output, nil
+}
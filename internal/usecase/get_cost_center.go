@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (u *CostCenterUsecase) GetCostCenter(ctx context.Context, idString string) (dto.GetCostCenterResponse, error) {
+	ctx, span := tracer.Start(ctx, "CostCenterUsecase.GetCostCenter")
+	defer span.End()
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return dto.GetCostCenterResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	cc, err := u.Repository.GetById(ctx, id)
+	if err != nil {
+		return dto.GetCostCenterResponse{}, fmt.Errorf("failed to get cost center: %w", err)
+	}
+	if cc == nil {
+		return dto.GetCostCenterResponse{}, fmt.Errorf("cost center not found")
+	}
+
+	return costCenterResponse(cc), nil
+}
+
+func (u *CostCenterUsecase) GetCostCentersList(ctx context.Context) (dto.GetCostCenterListResponse, error) {
+	ctx, span := tracer.Start(ctx, "CostCenterUsecase.GetCostCentersList")
+	defer span.End()
+
+	centers, err := u.Repository.List(ctx)
+	if err != nil {
+		return dto.GetCostCenterListResponse{}, fmt.Errorf("failed to get cost centers: %w", err)
+	}
+
+	output := dto.GetCostCenterListResponse{
+		Total: len(centers),
+		List:  make([]dto.GetCostCenterResponse, 0, len(centers)),
+	}
+	for _, cc := range centers {
+		output.List = append(output.List, costCenterResponse(cc))
+	}
+
+	return output, nil
+}
+
+func costCenterResponse(cc *models.CostCenter) dto.GetCostCenterResponse {
+	return dto.GetCostCenterResponse{
+		ID:   cc.ID.String(),
+		Name: cc.Name,
+	}
+}
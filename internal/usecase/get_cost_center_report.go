@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// maxReportMonths ограничивает период отчёта: каждый месяц — отдельный
+// запрос суммы.
+const maxReportMonths = 120
+
+// GetCostCenterReport считает расходы каждого месяца периода так же, как
+// сводка за этот месяц, и делит расходы каждой подписки по её долям: годовой
+// тариф входит в каждый месяц двенадцатой частью цены. Расходы подписок без
+// распределения идут в строку без центра затрат.
+func (u *CostCenterUsecase) GetCostCenterReport(ctx context.Context, userIdStr, start, end string) (dto.GetCostCenterReportResponse, error) {
+	ctx, span := tracer.Start(ctx, "CostCenterUsecase.GetCostCenterReport")
+	defer span.End()
+
+	var userId uuid.UUID
+	if userIdStr != "" {
+		parsed, err := uuid.Parse(userIdStr)
+		if err != nil {
+			return dto.GetCostCenterReportResponse{}, fmt.Errorf("invalid user_id: %w", err)
+		}
+		userId = parsed
+	}
+
+	startDate, err := time.Parse("01-2006", start)
+	if err != nil {
+		return dto.GetCostCenterReportResponse{}, fmt.Errorf("invalid start_date format (expected MM-YYYY): %w", err)
+	}
+	endDate, err := time.Parse("01-2006", end)
+	if err != nil {
+		return dto.GetCostCenterReportResponse{}, fmt.Errorf("invalid end_date format (expected MM-YYYY): %w", err)
+	}
+	if endDate.Before(startDate) {
+		return dto.GetCostCenterReportResponse{}, fmt.Errorf("end_date must not be before start_date")
+	}
+
+	var months []time.Time
+	for m := startDate; !m.After(endDate); m = m.AddDate(0, 1, 0) {
+		if len(months) == maxReportMonths {
+			return dto.GetCostCenterReportResponse{}, fmt.Errorf("report period must not exceed %d months", maxReportMonths)
+		}
+		months = append(months, m)
+	}
+
+	monthTotals := make([][]models.SubscriptionTotal, 0, len(months))
+	var subIDs []uuid.UUID
+	seen := map[uuid.UUID]bool{}
+	for _, m := range months {
+		totals, err := u.Subscriptions.SumForPeriodBySubscription(ctx, userId, "", m, &m)
+		if err != nil {
+			return dto.GetCostCenterReportResponse{}, fmt.Errorf("failed to get summary of month from DB: %w", err)
+		}
+		for _, t := range totals {
+			if !seen[t.SubscriptionID] {
+				seen[t.SubscriptionID] = true
+				subIDs = append(subIDs, t.SubscriptionID)
+			}
+		}
+		monthTotals = append(monthTotals, totals)
+	}
+
+	allocations, err := u.Repository.ListAllocations(ctx, subIDs)
+	if err != nil {
+		return dto.GetCostCenterReportResponse{}, fmt.Errorf("failed to get allocations: %w", err)
+	}
+	names, err := u.costCenterNames(ctx)
+	if err != nil {
+		return dto.GetCostCenterReportResponse{}, err
+	}
+
+	output := dto.GetCostCenterReportResponse{
+		Months: make([]dto.CostCenterReportMonth, 0, len(months)),
+	}
+	overall := map[uuid.UUID]int{}
+	for i, m := range months {
+		amounts := map[uuid.UUID]int{}
+		month := dto.CostCenterReportMonth{Month: m.Format("01-2006")}
+		for _, t := range monthTotals[i] {
+			month.Total += t.Total
+			subAllocations := allocations[t.SubscriptionID]
+			if len(subAllocations) == 0 {
+				amounts[uuid.Nil] += t.Total
+				continue
+			}
+			for j, part := range models.SplitAmount(t.Total, subAllocations) {
+				amounts[subAllocations[j].CostCenterID] += part
+			}
+		}
+		for id, amount := range amounts {
+			overall[id] += amount
+		}
+		month.CostCenters = costCenterAmounts(amounts, names)
+		output.Total += month.Total
+		output.Months = append(output.Months, month)
+	}
+	output.CostCenters = costCenterAmounts(overall, names)
+
+	return output, nil
+}
+
+// costCenterAmounts сортирует расходы по названию центра затрат; расходы
+// без центра (uuid.Nil) идут последними.
+func costCenterAmounts(amounts map[uuid.UUID]int, names map[uuid.UUID]string) []dto.CostCenterAmount {
+	ids := make([]uuid.UUID, 0, len(amounts))
+	for id := range amounts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if (ids[i] == uuid.Nil) != (ids[j] == uuid.Nil) {
+			return ids[j] == uuid.Nil
+		}
+		return names[ids[i]] < names[ids[j]]
+	})
+
+	out := make([]dto.CostCenterAmount, 0, len(ids))
+	for _, id := range ids {
+		amount := dto.CostCenterAmount{Amount: amounts[id]}
+		if id != uuid.Nil {
+			amount.CostCenterID = id.String()
+			amount.CostCenterName = names[id]
+		}
+		out = append(out, amount)
+	}
+	return out
+}
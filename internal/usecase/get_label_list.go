@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+)
+
+func (u *SubscriptionUsecase) GetTags(ctx context.Context) (dto.GetLabelListResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.GetTags")
+	defer span.End()
+
+	tags, err := u.Repository.ListTags(ctx)
+	if err != nil {
+		return dto.GetLabelListResponse{}, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return labelListResponse(tags), nil
+}
+
+func (u *SubscriptionUsecase) GetCategories(ctx context.Context) (dto.GetLabelListResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.GetCategories")
+	defer span.End()
+
+	categories, err := u.Repository.ListCategories(ctx)
+	if err != nil {
+		return dto.GetLabelListResponse{}, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	return labelListResponse(categories), nil
+}
+
+func labelListResponse(labels []models.LabelCount) dto.GetLabelListResponse {
+	output := dto.GetLabelListResponse{
+		Total: len(labels),
+		List:  make([]dto.Label, 0, len(labels)),
+	}
+	for _, l := range labels {
+		output.List = append(output.List, dto.Label{Name: l.Name, Count: l.Count})
+	}
+	return output
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/google/uuid"
+)
+
+func (u *ServiceUsecase) GetPlan(ctx context.Context, idString string) (dto.GetPlanResponse, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.GetPlan")
+	defer span.End()
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return dto.GetPlanResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	plan, err := u.Plans.GetById(ctx, id)
+	if err != nil {
+		return dto.GetPlanResponse{}, fmt.Errorf("failed to get plan: %w", err)
+	}
+	if plan == nil {
+		return dto.GetPlanResponse{}, fmt.Errorf("plan not found")
+	}
+
+	return planResponse(plan), nil
+}
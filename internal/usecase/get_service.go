@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/google/uuid"
+)
+
+func (u *ServiceUsecase) GetService(ctx context.Context, idString string) (dto.GetServiceResponse, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.GetService")
+	defer span.End()
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return dto.GetServiceResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	svc, err := u.Repository.GetById(ctx, id)
+	if err != nil {
+		return dto.GetServiceResponse{}, fmt.Errorf("failed to get service: %w", err)
+	}
+	if svc == nil {
+		return dto.GetServiceResponse{}, fmt.Errorf("service not found")
+	}
+
+	return serviceResponse(svc), nil
+}
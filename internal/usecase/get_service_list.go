@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+)
+
+func (u *ServiceUsecase) GetServicesList(ctx context.Context) (dto.GetServiceListResponse, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.GetServicesList")
+	defer span.End()
+
+	services, err := u.Repository.List(ctx)
+	if err != nil {
+		return dto.GetServiceListResponse{}, err
+	}
+
+	output := dto.GetServiceListResponse{
+		Total: len(services),
+		List:  make([]dto.GetServiceResponse, 0, len(services)),
+	}
+	for _, svc := range services {
+		output.List = append(output.List, serviceResponse(svc))
+	}
+
+	return output, nil
+}
@@ -9,7 +9,12 @@ import (
 	"github.com/google/uuid"
 )
 
-func (u *SubscriptionUsecase) GetSubscriptionsSum(ctx context.Context, userIdStr, serviceName, start string, end *string) (dto.GetSubSumResponse, error) {
+// GetSubscriptionsSum с groupBy = dto.SumGroupByTag дополнительно возвращает
+// суммы по тегам.
+func (u *SubscriptionUsecase) GetSubscriptionsSum(ctx context.Context, userIdStr, serviceName, start string, end *string, groupBy string) (dto.GetSubSumResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.GetSubscriptionsSum")
+	defer span.End()
+
 	var userId uuid.UUID
 	if userIdStr != "" {
 		parsed, err := uuid.Parse(userIdStr)
@@ -33,6 +38,11 @@ func (u *SubscriptionUsecase) GetSubscriptionsSum(ctx context.Context, userIdStr
 		endDate = &t
 	}
 
+	serviceName, err = u.canonicalServiceName(ctx, serviceName)
+	if err != nil {
+		return dto.GetSubSumResponse{}, err
+	}
+
 	total, err := u.Repository.SumForPeriod(ctx, userId, serviceName, startDate, endDate)
 	if err != nil {
 		return dto.GetSubSumResponse{}, fmt.Errorf("failed to get summary of period from DB: %w", err)
@@ -42,5 +52,20 @@ func (u *SubscriptionUsecase) GetSubscriptionsSum(ctx context.Context, userIdStr
 		Total: total,
 	}
 
+	switch groupBy {
+	case "":
+	case dto.SumGroupByTag:
+		totals, err := u.Repository.SumForPeriodByTag(ctx, userId, serviceName, startDate, endDate)
+		if err != nil {
+			return dto.GetSubSumResponse{}, fmt.Errorf("failed to get summary by tag from DB: %w", err)
+		}
+		output.Groups = make([]dto.SumGroup, 0, len(totals))
+		for _, t := range totals {
+			output.Groups = append(output.Groups, dto.SumGroup{Tag: t.Tag, Total: t.Total})
+		}
+	default:
+		return dto.GetSubSumResponse{}, fmt.Errorf("unsupported group_by %q", groupBy)
+	}
+
 	return output, nil
 }
@@ -3,16 +3,27 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
 	"github.com/google/uuid"
 )
 
-func (u *SubscriptionUsecase) GetSubscription(ctx context.Context, idString string) (dto.GetSubscriptionResponse, error) {
+// GetSubscription возвращает подписку со статусом в месяце asOf (MM-YYYY,
+// по умолчанию текущем).
+func (u *SubscriptionUsecase) GetSubscription(ctx context.Context, idString, asOf string) (dto.GetSubscriptionResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.GetSubscription")
+	defer span.End()
+
 	idUUID, err := uuid.Parse(idString)
 	if err != nil {
 		return dto.GetSubscriptionResponse{}, fmt.Errorf("invalid id format: %w", err)
 	}
+	month, err := referenceMonth(asOf)
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, err
+	}
 
 	sub, err := u.Repository.GetById(ctx, idUUID)
 	if err != nil {
@@ -22,20 +33,80 @@ func (u *SubscriptionUsecase) GetSubscription(ctx context.Context, idString stri
 		return dto.GetSubscriptionResponse{}, fmt.Errorf("subscription not found")
 	}
 
+	return u.describeSubscription(ctx, sub, month)
+}
+
+// describeSubscription загружает паузы и отмену подписки и собирает ответ
+// о ней со статусом в месяце month.
+func (u *SubscriptionUsecase) describeSubscription(ctx context.Context, sub *models.Subscription, month time.Time) (dto.GetSubscriptionResponse, error) {
+	pauses, err := u.Repository.ListPauses(ctx, []uuid.UUID{sub.ID})
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, fmt.Errorf("failed to get pauses: %w", err)
+	}
+	cancellations, err := u.Repository.ListCancellations(ctx, []uuid.UUID{sub.ID})
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, fmt.Errorf("failed to get cancellations: %w", err)
+	}
+
+	return subscriptionResponse(sub, pauses[sub.ID], cancellations[sub.ID], month), nil
+}
+
+// subscriptionResponse собирает ответ о подписке со статусом в месяце month;
+// отмена с нулевым SubscriptionID не попадает в ответ.
+func subscriptionResponse(sub *models.Subscription, pauses []models.SubscriptionPause, cancellation models.Cancellation, month time.Time) dto.GetSubscriptionResponse {
 	var endDate *string
 	if sub.EndDate != nil {
 		t := sub.EndDate.Format("01-2006")
 		endDate = &t
 	}
 
-	output := dto.GetSubscriptionResponse{
-		ID:          sub.ID.String(),
-		ServiceName: sub.ServiceName,
-		Price:       sub.Price,
-		UserID:      sub.UserID.String(),
-		StartDate:   sub.StartDate.Format("01-2006"),
-		EndDate:     endDate,
+	return dto.GetSubscriptionResponse{
+		ID:            sub.ID.String(),
+		ServiceName:   sub.ServiceName,
+		ServiceID:     serviceIDString(sub),
+		PlanID:        planIDString(sub),
+		Price:         sub.Price,
+		BillingPeriod: sub.BillingPeriod,
+		UserID:        sub.UserID.String(),
+		StartDate:     sub.StartDate.Format("01-2006"),
+		EndDate:       endDate,
+		TrialEnd:      trialEndString(sub),
+		IntroPrice:    sub.IntroPrice,
+		IntroMonths:   sub.IntroMonths,
+		Category:      sub.Category,
+		Tags:          tagsOrEmpty(sub.Tags),
+		Status:        sub.Status(pauses, month),
+		Pauses:        pausesResponse(pauses),
+		Cancellation:  cancellationResponse(cancellation),
+	}
+}
+
+func pausesResponse(pauses []models.SubscriptionPause) []dto.SubscriptionPause {
+	if len(pauses) == 0 {
+		return nil
+	}
+
+	output := make([]dto.SubscriptionPause, 0, len(pauses))
+	for _, p := range pauses {
+		pause := dto.SubscriptionPause{StartDate: p.StartDate.Format("01-2006")}
+		if p.EndDate != nil {
+			t := p.EndDate.Format("01-2006")
+			pause.EndDate = &t
+		}
+		output = append(output, pause)
 	}
+	return output
+}
 
-	return output, nil
+func cancellationResponse(c models.Cancellation) *dto.CancellationInfo {
+	if c.SubscriptionID == uuid.Nil {
+		return nil
+	}
+
+	return &dto.CancellationInfo{
+		EffectiveDate: c.EffectiveDate.Format("01-2006"),
+		Reason:        c.Reason,
+		Comment:       c.Comment,
+		CancelledAt:   c.CancelledAt.Format(time.RFC3339),
+	}
 }
@@ -3,41 +3,60 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
 	"github.com/google/uuid"
 )
 
-func (u *SubscriptionUsecase) GetSubscriptionsList(ctx context.Context, userIDStr string) (dto.GetSubsListResponse, error) {
-	userID, err := uuid.Parse(userIDStr)
+func (u *SubscriptionUsecase) GetSubscriptionsList(ctx context.Context, input dto.GetSubsListFilter) (dto.GetSubsListResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.GetSubscriptionsList")
+	defer span.End()
+
+	userID, err := uuid.Parse(input.UserID)
 	if err != nil {
 		return dto.GetSubsListResponse{}, fmt.Errorf("invalid user_id: %w", err)
 	}
+	month, err := referenceMonth(input.AsOf)
+	if err != nil {
+		return dto.GetSubsListResponse{}, err
+	}
+	status := strings.TrimSpace(input.Status)
+	if status != "" && !models.IsStatus(status) {
+		return dto.GetSubsListResponse{}, fmt.Errorf("unknown status %q", status)
+	}
 
-	subs, err := u.Repository.List(ctx, userID)
+	subs, err := u.Repository.List(ctx, models.SubscriptionFilter{
+		UserID:   userID,
+		Category: strings.TrimSpace(input.Category),
+		Tag:      models.NormalizeTag(input.Tag),
+		Status:   status,
+		AsOf:     month,
+	})
 	if err != nil {
 		return dto.GetSubsListResponse{}, err
 	}
 
+	ids := make([]uuid.UUID, 0, len(subs))
+	for _, sub := range subs {
+		ids = append(ids, sub.ID)
+	}
+	pauses, err := u.Repository.ListPauses(ctx, ids)
+	if err != nil {
+		return dto.GetSubsListResponse{}, fmt.Errorf("failed to get pauses: %w", err)
+	}
+	cancellations, err := u.Repository.ListCancellations(ctx, ids)
+	if err != nil {
+		return dto.GetSubsListResponse{}, fmt.Errorf("failed to get cancellations: %w", err)
+	}
+
 	output := dto.GetSubsListResponse{
 		Total: 0,
 		List:  make([]dto.GetSubscriptionResponse, 0, len(subs)),
 	}
 	for _, sub := range subs {
-		var end *string
-		if sub.EndDate != nil {
-			t := sub.EndDate.Format("01-2006")
-			end = &t
-		}
-
-		output.List = append(output.List, dto.GetSubscriptionResponse{
-			ID:          sub.ID.String(),
-			ServiceName: sub.ServiceName,
-			Price:       sub.Price,
-			UserID:      sub.UserID.String(),
-			StartDate:   sub.StartDate.Format("01-2006"),
-			EndDate:     end,
-		})
+		output.List = append(output.List, subscriptionResponse(sub, pauses[sub.ID], cancellations[sub.ID], month))
 		output.Total++
 	}
 
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// PauseSubscription приостанавливает подписку. Приостановленные месяцы не
+// входят в сводки, отчёты и расходы бюджетов.
+func (u *SubscriptionUsecase) PauseSubscription(ctx context.Context, idString string, input dto.PauseSubscriptionRequest) (dto.GetSubscriptionResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.PauseSubscription")
+	defer span.End()
+
+	idUUID, err := uuid.Parse(idString)
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	now := time.Now()
+	pause := models.SubscriptionPause{StartDate: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)}
+	if input.StartDate != "" {
+		if pause.StartDate, err = time.Parse("01-2006", input.StartDate); err != nil {
+			return dto.GetSubscriptionResponse{}, fmt.Errorf("invalid start_date format (expected MM-YYYY): %w", err)
+		}
+	}
+	if input.EndDate != nil && *input.EndDate != "" {
+		t, err := time.Parse("01-2006", *input.EndDate)
+		if err != nil {
+			return dto.GetSubscriptionResponse{}, fmt.Errorf("invalid end_date format (expected MM-YYYY): %w", err)
+		}
+		pause.EndDate = &t
+	}
+
+	var (
+		sub    *models.Subscription
+		pauses []models.SubscriptionPause
+	)
+	err = u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		sub, err = u.Repository.GetByIdForUpdate(ctx, idUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get subscription: %w", err)
+		}
+		if sub == nil {
+			return fmt.Errorf("subscription not found")
+		}
+		if pause.StartDate.Before(sub.StartDate) {
+			return errors.New("pause cannot start before start_date")
+		}
+		if sub.EndDate != nil && pause.StartDate.After(*sub.EndDate) {
+			return errors.New("pause cannot start after end_date")
+		}
+
+		existing, err := u.Repository.ListPauses(ctx, []uuid.UUID{sub.ID})
+		if err != nil {
+			return fmt.Errorf("failed to get pauses: %w", err)
+		}
+		if pauses, err = models.AddPause(existing[sub.ID], pause); err != nil {
+			return err
+		}
+
+		if err := u.Repository.SetPauses(ctx, sub.ID, pauses); err != nil {
+			return fmt.Errorf("failed to set pauses: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, err
+	}
+
+	return u.describeSubscription(ctx, sub, now)
+}
+
+// ResumeSubscription возобновляет приостановленную подписку с месяца
+// input.Date; запланированная, но ещё не начавшаяся пауза отменяется.
+func (u *SubscriptionUsecase) ResumeSubscription(ctx context.Context, idString string, input dto.ResumeSubscriptionRequest) (dto.GetSubscriptionResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.ResumeSubscription")
+	defer span.End()
+
+	idUUID, err := uuid.Parse(idString)
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	now := time.Now()
+	current := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	month := current
+	if input.Date != "" {
+		if month, err = time.Parse("01-2006", input.Date); err != nil {
+			return dto.GetSubscriptionResponse{}, fmt.Errorf("invalid date format (expected MM-YYYY): %w", err)
+		}
+	}
+
+	var (
+		sub    *models.Subscription
+		pauses []models.SubscriptionPause
+	)
+	err = u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		sub, err = u.Repository.GetByIdForUpdate(ctx, idUUID)
+		if err != nil {
+			return fmt.Errorf("failed to get subscription: %w", err)
+		}
+		if sub == nil {
+			return fmt.Errorf("subscription not found")
+		}
+
+		existing, err := u.Repository.ListPauses(ctx, []uuid.UUID{sub.ID})
+		if err != nil {
+			return fmt.Errorf("failed to get pauses: %w", err)
+		}
+		if pauses, err = models.ResumePauses(existing[sub.ID], month, current); err != nil {
+			return err
+		}
+
+		if err := u.Repository.SetPauses(ctx, sub.ID, pauses); err != nil {
+			return fmt.Errorf("failed to set pauses: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.GetSubscriptionResponse{}, err
+	}
+
+	return u.describeSubscription(ctx, sub, now)
+}
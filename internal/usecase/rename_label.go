@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+)
+
+// RenameTag переименовывает тег у всех подписок. Если новый тег уже есть у
+// подписки, теги сливаются.
+func (u *SubscriptionUsecase) RenameTag(ctx context.Context, name string, input dto.RenameLabelRequest) (dto.RenameLabelResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.RenameTag")
+	defer span.End()
+
+	from, to := models.NormalizeTag(name), models.NormalizeTag(input.Name)
+	if from == "" || to == "" {
+		return dto.RenameLabelResponse{}, fmt.Errorf("tag name is required")
+	}
+	if from == to {
+		return dto.RenameLabelResponse{}, fmt.Errorf("new tag name is the same as the current one")
+	}
+
+	updated, err := u.Repository.RenameTag(ctx, from, to)
+	if err != nil {
+		return dto.RenameLabelResponse{}, fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	return dto.RenameLabelResponse{Updated: updated}, nil
+}
+
+func (u *SubscriptionUsecase) RenameCategory(ctx context.Context, name string, input dto.RenameLabelRequest) (dto.RenameLabelResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.RenameCategory")
+	defer span.End()
+
+	from, to := strings.TrimSpace(name), strings.TrimSpace(input.Name)
+	if from == "" || to == "" {
+		return dto.RenameLabelResponse{}, fmt.Errorf("category name is required")
+	}
+
+	updated, err := u.Repository.RenameCategory(ctx, from, to)
+	if err != nil {
+		return dto.RenameLabelResponse{}, fmt.Errorf("failed to rename category: %w", err)
+	}
+
+	return dto.RenameLabelResponse{Updated: updated}, nil
+}
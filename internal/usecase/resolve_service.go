@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// resolveService ищет service_name подписки в каталоге. Найденный сервис
+// подставляет каноническое название, ServiceID и категорию, если она не
+// задана, а при пустой цене — тариф по умолчанию с его ценой и периодом
+// оплаты. Название не из каталога сохраняется как есть. Прежний тариф
+// подписки отвязывается.
+func (u *SubscriptionUsecase) resolveService(ctx context.Context, sub *models.Subscription) error {
+	sub.ServiceID = nil
+	sub.PlanID = nil
+	if u.Services == nil {
+		return nil
+	}
+
+	svc, err := u.Services.FindByName(ctx, sub.ServiceName)
+	if err != nil {
+		return fmt.Errorf("failed to resolve service: %w", err)
+	}
+	if svc == nil {
+		return nil
+	}
+
+	sub.ServiceID = &svc.ID
+	sub.ServiceName = svc.Name
+	if sub.Category == "" {
+		sub.Category = svc.Category
+	}
+	if sub.Price == 0 {
+		if plan, ok := svc.DefaultPlan(); ok {
+			applyPlan(sub, plan)
+		}
+	}
+
+	return nil
+}
+
+// changeService меняет сервис подписки на name. Название того же сервиса,
+// в том числе его синоним, тариф не отвязывает.
+func (u *SubscriptionUsecase) changeService(ctx context.Context, sub *models.Subscription, name string) error {
+	if sub.ServiceID == nil {
+		if name == sub.ServiceName {
+			return nil
+		}
+	} else if u.Services != nil {
+		svc, err := u.Services.FindByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to resolve service: %w", err)
+		}
+		if svc != nil && svc.ID == *sub.ServiceID {
+			sub.ServiceName = svc.Name
+			return nil
+		}
+	}
+
+	sub.ServiceName = name
+	return u.resolveService(ctx, sub)
+}
+
+// resolvePlan привязывает подписку к тарифу planID: сервис, цена на месяц
+// начала подписки и период оплаты берутся из тарифа. Цена, отличная от
+// цены тарифа, считается ошибкой.
+func (u *SubscriptionUsecase) resolvePlan(ctx context.Context, sub *models.Subscription, planID uuid.UUID) error {
+	if u.Plans == nil {
+		return fmt.Errorf("plans are not supported")
+	}
+
+	plan, err := u.Plans.GetById(ctx, planID)
+	if err != nil {
+		return fmt.Errorf("failed to get plan: %w", err)
+	}
+	if plan == nil {
+		return fmt.Errorf("plan not found")
+	}
+
+	svc, err := u.Services.GetById(ctx, plan.ServiceID)
+	if err != nil {
+		return fmt.Errorf("failed to get service: %w", err)
+	}
+	if svc == nil {
+		return fmt.Errorf("service not found")
+	}
+
+	price := sub.Price
+	sub.ServiceID = &svc.ID
+	sub.ServiceName = svc.Name
+	if sub.Category == "" {
+		sub.Category = svc.Category
+	}
+	applyPlan(sub, plan)
+	if price != 0 && price != sub.Price {
+		return fmt.Errorf("price %d does not match plan price %d", price, sub.Price)
+	}
+
+	return nil
+}
+
+// repricePlan пересчитывает цену подписки на тарифе по месяцу её начала.
+func (u *SubscriptionUsecase) repricePlan(ctx context.Context, sub *models.Subscription) error {
+	if sub.PlanID == nil || u.Plans == nil {
+		return nil
+	}
+
+	plan, err := u.Plans.GetById(ctx, *sub.PlanID)
+	if err != nil {
+		return fmt.Errorf("failed to get plan: %w", err)
+	}
+	if plan == nil {
+		return fmt.Errorf("plan not found")
+	}
+	sub.Price = plan.PriceAt(sub.StartDate)
+
+	return nil
+}
+
+func applyPlan(sub *models.Subscription, plan *models.ServicePlan) {
+	id := plan.ID
+	sub.PlanID = &id
+	sub.Price = plan.PriceAt(sub.StartDate)
+	sub.BillingPeriod = plan.BillingPeriod
+}
+
+// canonicalServiceName возвращает название из каталога для фильтров по
+// сервису, а для неизвестного названия — его само.
+func (u *SubscriptionUsecase) canonicalServiceName(ctx context.Context, name string) (string, error) {
+	if u.Services == nil || name == "" {
+		return name, nil
+	}
+
+	svc, err := u.Services.FindByName(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve service: %w", err)
+	}
+	if svc == nil {
+		return name, nil
+	}
+
+	return svc.Name, nil
+}
+
+func serviceIDString(sub *models.Subscription) string {
+	if sub.ServiceID == nil {
+		return ""
+	}
+	return sub.ServiceID.String()
+}
+
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// trialEndString форматирует конец пробного периода как MM-YYYY.
+func trialEndString(sub *models.Subscription) *string {
+	if sub.TrialEndDate == nil {
+		return nil
+	}
+	t := sub.TrialEndDate.Format("01-2006")
+	return &t
+}
+
+func planIDString(sub *models.Subscription) string {
+	if sub.PlanID == nil {
+		return ""
+	}
+	return sub.PlanID.String()
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+)
+
+// SendTrialReminders отправляет напоминания о подписках, первый платный
+// месяц которых начинается в течение Lead после now. Каждое напоминание
+// уходит один раз; возвращает число отправленных.
+func (u *TrialReminderUsecase) SendTrialReminders(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "TrialReminderUsecase.SendTrialReminders")
+	defer span.End()
+
+	sent := 0
+	var errs []error
+	// Платные месяцы начинаются первого числа: перебираем те, что попадают
+	// в (now, now+Lead].
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for paidStart := month.AddDate(0, 1, 0); !paidStart.After(now.Add(u.Lead)); paidStart = paidStart.AddDate(0, 1, 0) {
+		subs, err := u.Subscriptions.ListTrialsEnding(ctx, paidStart.AddDate(0, -1, 0))
+		if err != nil {
+			return sent, errors.Join(append(errs, fmt.Errorf("failed to get ending trials: %w", err))...)
+		}
+
+		for _, sub := range subs {
+			ok, err := u.sendTrialReminder(ctx, sub, paidStart)
+			if ok {
+				sent++
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("subscription %s: %w", sub.ID, err))
+			}
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func (u *TrialReminderUsecase) sendTrialReminder(ctx context.Context, sub *models.Subscription, paidStart time.Time) (bool, error) {
+	var plan *models.ServicePlan
+	if sub.PlanID != nil && u.Plans != nil {
+		var err error
+		if plan, err = u.Plans.GetById(ctx, *sub.PlanID); err != nil {
+			return false, fmt.Errorf("failed to get plan: %w", err)
+		}
+	}
+
+	claimed, err := u.Subscriptions.ClaimTrialReminder(ctx, sub.ID, paidStart)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim reminder: %w", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	reminder := models.TrialReminder{
+		Subscription: *sub,
+		ChargeDate:   paidStart,
+		Price:        sub.FirstChargePrice(plan),
+	}
+	if err := u.Notifier.NotifyTrialEnding(ctx, reminder); err != nil {
+		// Снимаем отметку, чтобы напоминание ушло при следующей проверке.
+		if releaseErr := u.Subscriptions.ReleaseTrialReminder(ctx, sub.ID, paidStart); releaseErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to release reminder: %w", releaseErr))
+		}
+		return false, fmt.Errorf("failed to send reminder: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,107 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+)
+
+// fakeTrialNotifier запоминает отправленные напоминания; при fail отправка
+// не удаётся.
+type fakeTrialNotifier struct {
+	fail      bool
+	reminders []models.TrialReminder
+}
+
+func (n *fakeTrialNotifier) NotifyTrialEnding(ctx context.Context, reminder models.TrialReminder) error {
+	if n.fail {
+		return errors.New("webhook unavailable")
+	}
+	n.reminders = append(n.reminders, reminder)
+	return nil
+}
+
+// trialLead покрывает три следующих месяца, но не четвёртый.
+const trialLead = 93 * 24 * time.Hour
+
+func newTrialEnv() (*testEnv, *usecase.TrialReminderUsecase, *fakeTrialNotifier) {
+	env := newTestEnv()
+	notifier := &fakeTrialNotifier{}
+	return env, usecase.NewTrialReminderUsecase(env.subs, env.plans, notifier, trialLead), notifier
+}
+
+func (e *testEnv) createTrial(t *testing.T, input dto.CreateSubstractionRequest, trialEnd time.Time) string {
+	t.Helper()
+	if input.ServiceName == "" && input.PlanID == "" {
+		input.ServiceName, input.Price = "Netflix", 500
+	}
+	input.StartDate = currentMonth().AddDate(0, -1, 0).Format("01-2006")
+	input.TrialEnd = ptr(trialEnd.Format("01-2006"))
+	return e.createSubscription(t, input)
+}
+
+func sendReminders(t *testing.T, u *usecase.TrialReminderUsecase, wantSent int) {
+	t.Helper()
+	sent, err := u.SendTrialReminders(context.Background(), currentMonth())
+	if err != nil {
+		t.Fatalf("SendTrialReminders: %v", err)
+	}
+	if sent != wantSent {
+		t.Fatalf("SendTrialReminders sent %d reminders, want %d", sent, wantSent)
+	}
+}
+
+func TestSendTrialRemindersLeadWindow(t *testing.T) {
+	env, reminders, notifier := newTrialEnv()
+	want := map[string]time.Time{}
+	for _, months := range []int{0, 1, 2} {
+		id := env.createTrial(t, dto.CreateSubstractionRequest{}, currentMonth().AddDate(0, months, 0))
+		want[id] = currentMonth().AddDate(0, months+1, 0)
+	}
+	env.createTrial(t, dto.CreateSubstractionRequest{}, currentMonth().AddDate(0, 3, 0))
+
+	sendReminders(t, reminders, 3)
+	for _, r := range notifier.reminders {
+		charge, ok := want[r.Subscription.ID.String()]
+		if !ok || !r.ChargeDate.Equal(charge) {
+			t.Fatalf("reminder for %s charged %s, want %s", r.Subscription.ID, r.ChargeDate.Format("01-2006"), charge.Format("01-2006"))
+		}
+	}
+
+	sendReminders(t, reminders, 0)
+}
+
+func TestSendTrialRemindersRetriesFailedNotification(t *testing.T) {
+	env, reminders, notifier := newTrialEnv()
+	env.createTrial(t, dto.CreateSubstractionRequest{}, currentMonth())
+
+	notifier.fail = true
+	if _, err := reminders.SendTrialReminders(context.Background(), currentMonth()); err == nil {
+		t.Fatal("SendTrialReminders succeeded with a failing notifier")
+	}
+
+	notifier.fail = false
+	sendReminders(t, reminders, 1)
+	sendReminders(t, reminders, 0)
+}
+
+func TestSendTrialRemindersQuotesPlanPriceAtFirstCharge(t *testing.T) {
+	env, reminders, notifier := newTrialEnv()
+	_, plan := env.createService(t, "Netflix", 500, models.PlanPriceChange{EffectiveFrom: currentMonth().AddDate(0, 2, 0), Price: 700})
+	env.createTrial(t, dto.CreateSubstractionRequest{PlanID: plan.ID.String()}, currentMonth().AddDate(0, 1, 0))
+	env.createTrial(t, dto.CreateSubstractionRequest{PlanID: plan.ID.String(), IntroPrice: ptr(100), IntroMonths: 3}, currentMonth().AddDate(0, 1, 0))
+
+	sendReminders(t, reminders, 2)
+	got := map[bool]int{}
+	for _, r := range notifier.reminders {
+		got[r.Subscription.IntroPrice != nil] = r.Price
+	}
+	if got[false] != 700 || got[true] != 100 {
+		t.Fatalf("reminder prices = %d (plan), %d (intro), want 700, 100", got[false], got[true])
+	}
+}
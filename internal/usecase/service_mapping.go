@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// servicePlans сдвигает created_at тарифов на микросекунду, чтобы они
+// перечислялись в порядке запроса.
+func servicePlans(serviceID uuid.UUID, plans []dto.ServicePlan, now time.Time) []models.ServicePlan {
+	out := make([]models.ServicePlan, 0, len(plans))
+	for i, p := range plans {
+		created := now.Add(time.Duration(i) * time.Microsecond)
+		out = append(out, models.ServicePlan{
+			ID:            uuid.New(),
+			ServiceID:     serviceID,
+			Name:          p.Name,
+			Price:         p.Price,
+			BillingPeriod: billingPeriodOrMonthly(p.BillingPeriod),
+			Default:       p.Default,
+			CreatedAt:     created,
+			UpdatedAt:     created,
+		})
+	}
+	return out
+}
+
+func billingPeriodOrMonthly(period string) string {
+	if period == "" {
+		return models.BillingMonthly
+	}
+	return period
+}
+
+func serviceResponse(svc *models.Service) dto.GetServiceResponse {
+	now := time.Now()
+	plans := make([]dto.ServicePlan, 0, len(svc.Plans))
+	for _, p := range svc.Plans {
+		plans = append(plans, dto.ServicePlan{
+			ID:            p.ID.String(),
+			Name:          p.Name,
+			Price:         p.PriceAt(now),
+			BillingPeriod: p.BillingPeriod,
+			Default:       p.Default,
+		})
+	}
+
+	aliases := svc.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return dto.GetServiceResponse{
+		ID:       svc.ID.String(),
+		Name:     svc.Name,
+		Aliases:  aliases,
+		LogoURL:  svc.LogoURL,
+		Category: svc.Category,
+		Plans:    plans,
+	}
+}
+
+func planResponse(plan *models.ServicePlan) dto.GetPlanResponse {
+	changes := make([]dto.PlanPriceChange, 0, len(plan.PriceChanges))
+	for _, c := range plan.PriceChanges {
+		changes = append(changes, dto.PlanPriceChange{
+			EffectiveDate: c.EffectiveFrom.Format("01-2006"),
+			Price:         c.Price,
+		})
+	}
+
+	return dto.GetPlanResponse{
+		ID:            plan.ID.String(),
+		ServiceID:     plan.ServiceID.String(),
+		Name:          plan.Name,
+		Price:         plan.PriceAt(time.Now()),
+		BasePrice:     plan.Price,
+		BillingPeriod: plan.BillingPeriod,
+		Default:       plan.Default,
+		PriceChanges:  changes,
+	}
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (u *SubscriptionUsecase) GetMembers(ctx context.Context, subIDString string) (dto.GetMembersResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.GetMembers")
+	defer span.End()
+
+	subID, err := uuid.Parse(subIDString)
+	if err != nil {
+		return dto.GetMembersResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	sub, err := u.Repository.GetById(ctx, subID)
+	if err != nil {
+		return dto.GetMembersResponse{}, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	if sub == nil {
+		return dto.GetMembersResponse{}, fmt.Errorf("subscription not found")
+	}
+
+	return u.membersResponse(ctx, sub)
+}
+
+// SetMembers заменяет участников подписки. С участниками сводки по
+// пользователю учитывают долю каждого участника вместо полной цены у
+// владельца.
+func (u *SubscriptionUsecase) SetMembers(ctx context.Context, subIDString string, input dto.SetMembersRequest) (dto.GetMembersResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.SetMembers")
+	defer span.End()
+
+	subID, err := uuid.Parse(subIDString)
+	if err != nil {
+		return dto.GetMembersResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	members := make([]models.SubscriptionMember, 0, len(input.Members))
+	for _, m := range input.Members {
+		userID, err := uuid.Parse(m.UserID)
+		if err != nil {
+			return dto.GetMembersResponse{}, fmt.Errorf("invalid member user_id: %w", err)
+		}
+		members = append(members, models.SubscriptionMember{UserID: userID, Weight: m.Weight})
+	}
+
+	if err := models.ValidateMembers(members); err != nil {
+		return dto.GetMembersResponse{}, fmt.Errorf("validation model failed: %w", err)
+	}
+
+	var sub *models.Subscription
+	err = u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		sub, err = u.Repository.GetByIdForUpdate(ctx, subID)
+		if err != nil {
+			return fmt.Errorf("failed to get subscription: %w", err)
+		}
+		if sub == nil {
+			return fmt.Errorf("subscription not found")
+		}
+
+		if err := u.Repository.SetMembers(ctx, subID, members); err != nil {
+			return fmt.Errorf("failed to set members: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.GetMembersResponse{}, err
+	}
+
+	return u.membersResponse(ctx, sub)
+}
+
+func (u *SubscriptionUsecase) membersResponse(ctx context.Context, sub *models.Subscription) (dto.GetMembersResponse, error) {
+	members, err := u.Repository.ListMembers(ctx, sub.ID)
+	if err != nil {
+		return dto.GetMembersResponse{}, fmt.Errorf("failed to get members: %w", err)
+	}
+
+	output := dto.GetMembersResponse{
+		SubscriptionID: sub.ID.String(),
+		OwnerID:        sub.UserID.String(),
+		Price:          sub.Price,
+		Members:        make([]dto.SubscriptionMember, 0, len(members)),
+	}
+	for _, m := range members {
+		output.Members = append(output.Members, dto.SubscriptionMember{
+			UserID: m.UserID.String(),
+			Weight: m.Weight,
+			Share:  models.MemberShare(sub.Price, members, m.UserID),
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+)
+
+// referenceMonth разбирает отчётный месяц в формате MM-YYYY, относительно
+// которого считается статус подписки; пустая строка — текущий месяц.
+func referenceMonth(asOf string) (time.Time, error) {
+	if asOf == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+
+	month, err := time.Parse("01-2006", asOf)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid as_of format: %w", err)
+	}
+	return month, nil
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+)
+
+// UpdateBudget меняет название, сумму и пороги бюджета. Уже отправленные
+// предупреждения текущего периода повторно не отправляются.
+func (u *BudgetUsecase) UpdateBudget(ctx context.Context, idString string, input dto.UpdateBudgetRequest) (dto.GetBudgetResponse, error) {
+	ctx, span := tracer.Start(ctx, "BudgetUsecase.UpdateBudget")
+	defer span.End()
+
+	b, err := u.getBudget(ctx, idString)
+	if err != nil {
+		return dto.GetBudgetResponse{}, err
+	}
+
+	if input.Name != nil {
+		b.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Amount != nil {
+		b.Amount = *input.Amount
+	}
+	if input.Thresholds != nil {
+		b.Thresholds = models.NormalizeThresholds(*input.Thresholds)
+	}
+	b.UpdatedAt = time.Now()
+
+	if err := b.Validate(); err != nil {
+		return dto.GetBudgetResponse{}, fmt.Errorf("validation model failed: %w", err)
+	}
+
+	if err := u.Repository.Update(ctx, b); err != nil {
+		return dto.GetBudgetResponse{}, fmt.Errorf("failed update budget: %w", err)
+	}
+
+	return budgetResponse(b), nil
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (u *CostCenterUsecase) UpdateCostCenter(ctx context.Context, idString string, input dto.UpdateCostCenterRequest) (dto.GetCostCenterResponse, error) {
+	ctx, span := tracer.Start(ctx, "CostCenterUsecase.UpdateCostCenter")
+	defer span.End()
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return dto.GetCostCenterResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	cc := &models.CostCenter{
+		ID:        id,
+		Name:      strings.TrimSpace(input.Name),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := cc.Validate(); err != nil {
+		return dto.GetCostCenterResponse{}, fmt.Errorf("validation model failed: %w", err)
+	}
+
+	if err := u.Repository.Update(ctx, cc); err != nil {
+		return dto.GetCostCenterResponse{}, fmt.Errorf("failed update cost center: %w", err)
+	}
+
+	return costCenterResponse(cc), nil
+}
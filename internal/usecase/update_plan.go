@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+// UpdatePlan меняет название и отметку по умолчанию, а новая цена
+// записывается в историю цен тарифа с месяца effective_date. Подписки на
+// тариф хранят цену на момент начала, а суммы считаются по истории.
+func (u *ServiceUsecase) UpdatePlan(ctx context.Context, idString string, input dto.UpdatePlanRequest) (dto.GetPlanResponse, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.UpdatePlan")
+	defer span.End()
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return dto.GetPlanResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	var change *models.PlanPriceChange
+	if input.Price > 0 || input.EffectiveDate != "" {
+		if input.Price <= 0 {
+			return dto.GetPlanResponse{}, fmt.Errorf("price is required with effective_date")
+		}
+		if input.EffectiveDate == "" {
+			return dto.GetPlanResponse{}, fmt.Errorf("effective_date is required with price")
+		}
+		from, err := time.Parse("01-2006", input.EffectiveDate)
+		if err != nil {
+			return dto.GetPlanResponse{}, fmt.Errorf("invalid effective_date format (expected MM-YYYY): %w", err)
+		}
+		change = &models.PlanPriceChange{EffectiveFrom: from, Price: input.Price}
+	}
+
+	var plan *models.ServicePlan
+	err = u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		plan, err = u.Plans.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if plan == nil {
+			return fmt.Errorf("plan not found")
+		}
+
+		if name := strings.TrimSpace(input.Name); name != "" {
+			plan.Name = name
+		}
+		if input.Default != nil {
+			plan.Default = *input.Default
+		}
+
+		if err := plan.Validate(); err != nil {
+			return fmt.Errorf("validation model failed: %w", err)
+		}
+
+		if plan.Default {
+			svc, err := u.Repository.GetById(ctx, plan.ServiceID)
+			if err != nil {
+				return fmt.Errorf("failed to get service: %w", err)
+			}
+			if svc != nil {
+				if err := u.unsetDefaultPlans(ctx, svc, plan.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		plan.UpdatedAt = time.Now()
+
+		if err := u.Plans.Update(ctx, plan); err != nil {
+			return fmt.Errorf("failed update plan: %w", err)
+		}
+		if change != nil {
+			if err := u.Plans.SetPriceChange(ctx, plan.ID, *change); err != nil {
+				return fmt.Errorf("failed to set plan price: %w", err)
+			}
+		}
+
+		plan, err = u.Plans.GetById(ctx, id)
+		return err
+	})
+	if err != nil {
+		return dto.GetPlanResponse{}, err
+	}
+
+	return planResponse(plan), nil
+}
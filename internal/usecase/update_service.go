@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (u *ServiceUsecase) UpdateService(ctx context.Context, idString string, input dto.UpdateServiceRequest) (dto.GetServiceResponse, error) {
+	ctx, span := tracer.Start(ctx, "ServiceUsecase.UpdateService")
+	defer span.End()
+
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return dto.GetServiceResponse{}, fmt.Errorf("invalid id format: %w", err)
+	}
+
+	// Переименование сервиса переписывает service_name привязанных подписок
+	// в той же транзакции, чтобы фильтры по названию не разъехались с каталогом.
+	var svc *models.Service
+	err = u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		svc, err = u.Repository.GetById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if svc == nil {
+			return fmt.Errorf("service not found")
+		}
+
+		if name := strings.TrimSpace(input.Name); name != "" {
+			svc.Name = name
+		}
+		if input.Aliases != nil {
+			svc.Aliases = *input.Aliases
+		}
+		if input.LogoURL != nil {
+			svc.LogoURL = *input.LogoURL
+		}
+		if input.Category != nil {
+			svc.Category = *input.Category
+		}
+
+		if err := svc.Validate(); err != nil {
+			return fmt.Errorf("validation model failed: %w", err)
+		}
+
+		svc.UpdatedAt = time.Now()
+
+		if err := u.Repository.Update(ctx, svc); err != nil {
+			return fmt.Errorf("failed update service: %w", err)
+		}
+		if _, err := u.Subscriptions.RenameService(ctx, svc); err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return dto.GetServiceResponse{}, err
+	}
+
+	return serviceResponse(svc), nil
+}
@@ -3,72 +3,135 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
 	"github.com/google/uuid"
 )
 
 func (u *SubscriptionUsecase) UpdateSubscription(ctx context.Context, idString string, input dto.UpdateSubscriptionRequest) (dto.UpdateSubscriptionResponse, error) {
+	ctx, span := tracer.Start(ctx, "SubscriptionUsecase.UpdateSubscription")
+	defer span.End()
+
 	idUUID, err := uuid.Parse(idString)
 	if err != nil {
 		return dto.UpdateSubscriptionResponse{}, fmt.Errorf("invalid id format: %w", err)
 	}
 
-	// Проверка на существование подписки
-	sub, err := u.Repository.GetById(ctx, idUUID)
-	if err != nil {
-		return dto.UpdateSubscriptionResponse{}, err
-	}
-	if sub == nil {
-		return dto.UpdateSubscriptionResponse{}, fmt.Errorf("subscription not found")
-	}
-
-	if input.ServiceName != "" {
-		sub.ServiceName = input.ServiceName
-	}
-	if input.Price > 0 {
-		sub.Price = input.Price
-	}
-	if input.StartDate != "" {
-		start, err := time.Parse("01-2006", input.StartDate)
+	// Чтение с блокировкой и запись идут в одной транзакции, чтобы параллельные
+	// обновления не перетирали друг друга.
+	var sub *models.Subscription
+	err = u.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		// Проверка на существование подписки
+		sub, err = u.Repository.GetByIdForUpdate(ctx, idUUID)
 		if err != nil {
-			return dto.UpdateSubscriptionResponse{}, fmt.Errorf("invalid start_date: %w", err)
+			return err
 		}
-		sub.StartDate = start
-	}
-	if input.EndDate != nil {
-		if *input.EndDate == "" {
-			sub.EndDate = nil
-		} else {
-			t, err := time.Parse("01-2006", *input.EndDate)
+		if sub == nil {
+			return fmt.Errorf("subscription not found")
+		}
+
+		if input.ServiceName != "" {
+			if err := u.changeService(ctx, sub, input.ServiceName); err != nil {
+				return err
+			}
+		}
+		if input.StartDate != "" {
+			start, err := time.Parse("01-2006", input.StartDate)
 			if err != nil {
-				return dto.UpdateSubscriptionResponse{}, fmt.Errorf("invalid end_date: %w", err)
+				return fmt.Errorf("invalid start_date: %w", err)
 			}
-			sub.EndDate = &t
+			sub.StartDate = start
+		}
+		if input.PlanID != nil {
+			if *input.PlanID == "" {
+				sub.PlanID = nil
+			} else {
+				planID, err := uuid.Parse(*input.PlanID)
+				if err != nil {
+					return fmt.Errorf("invalid plan_id format: %w", err)
+				}
+				sub.Price = input.Price
+				if err := u.resolvePlan(ctx, sub, planID); err != nil {
+					return err
+				}
+			}
+		} else if input.Price > 0 && input.Price != sub.Price {
+			// Своя цена вместо цены тарифа.
+			sub.Price = input.Price
+			sub.PlanID = nil
+		} else if input.StartDate != "" {
+			// Цена тарифа берётся на месяц начала подписки.
+			if err := u.repricePlan(ctx, sub); err != nil {
+				return err
+			}
+		}
+		if input.EndDate != nil {
+			var end *time.Time
+			if *input.EndDate != "" {
+				t, err := time.Parse("01-2006", *input.EndDate)
+				if err != nil {
+					return fmt.Errorf("invalid end_date: %w", err)
+				}
+				end = &t
+			}
+			if err := u.checkEndDateChange(ctx, sub, end); err != nil {
+				return err
+			}
+			sub.EndDate = end
+		}
+		if input.TrialEnd != nil {
+			if *input.TrialEnd == "" {
+				sub.TrialEndDate = nil
+			} else {
+				t, err := time.Parse("01-2006", *input.TrialEnd)
+				if err != nil {
+					return fmt.Errorf("invalid trial_end_date: %w", err)
+				}
+				sub.TrialEndDate = &t
+			}
+		}
+		if input.IntroPrice != nil {
+			if *input.IntroPrice == 0 {
+				sub.IntroPrice = nil
+				sub.IntroMonths = 0
+			} else {
+				price := *input.IntroPrice
+				sub.IntroPrice = &price
+			}
+		}
+		if input.IntroMonths != nil {
+			sub.IntroMonths = *input.IntroMonths
+		}
+		if err := sub.ValidateIntro(); err != nil {
+			return fmt.Errorf("validation model failed: %w", err)
 		}
-	}
 
-	sub.UpdatedAt = time.Now()
+		if input.Category != nil {
+			sub.Category = strings.TrimSpace(*input.Category)
+		}
+		if input.Tags != nil {
+			sub.Tags = models.NormalizeTags(*input.Tags)
+		}
 
-	if err := u.Repository.Update(ctx, sub); err != nil {
-		return dto.UpdateSubscriptionResponse{}, fmt.Errorf("failed update subscription: %w", err)
-	}
+		sub.UpdatedAt = time.Now()
+
+		if err := u.Repository.Update(ctx, sub); err != nil {
+			return fmt.Errorf("failed update subscription: %w", err)
+		}
 
-	var endDate *string
-	if sub.EndDate != nil {
-		t := sub.EndDate.Format("01-2006")
-		endDate = &t
+		return nil
+	})
+	if err != nil {
+		return dto.UpdateSubscriptionResponse{}, err
 	}
 
-	output := dto.UpdateSubscriptionResponse{
-		ID:          sub.ID.String(),
-		ServiceName: sub.ServiceName,
-		Price:       sub.Price,
-		UserID:      sub.UserID.String(),
-		StartDate:   sub.StartDate.Format("01-2006"),
-		EndDate:     endDate,
+	output, err := u.describeSubscription(ctx, sub, time.Now())
+	if err != nil {
+		return dto.UpdateSubscriptionResponse{}, err
 	}
 
-	return output, nil
+	return dto.UpdateSubscriptionResponse(output), nil
 }
@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+)
+
+func TestUpdateSubscriptionKeepsPlanForSameService(t *testing.T) {
+	tests := []struct {
+		name        string
+		serviceName string
+		wantPlan    bool
+		wantService string
+	}{
+		{name: "same name", serviceName: "Netflix", wantPlan: true, wantService: "Netflix"},
+		{name: "alias", serviceName: "netflix premium", wantPlan: true, wantService: "Netflix"},
+		{name: "other catalog service", serviceName: "Spotify", wantService: "Spotify"},
+		{name: "unknown service", serviceName: "Okko", wantService: "Okko"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv()
+			_, plan := env.createService(t, "Netflix", 500)
+			env.createService(t, "Spotify", 300)
+			id := env.createSubscription(t, dto.CreateSubstractionRequest{PlanID: plan.ID.String(), StartDate: "01-2025"})
+
+			got, err := env.usecase.UpdateSubscription(context.Background(), id, dto.UpdateSubscriptionRequest{
+				ServiceName: tt.serviceName,
+				Price:       500,
+				StartDate:   "01-2025",
+			})
+			if err != nil {
+				t.Fatalf("UpdateSubscription: %v", err)
+			}
+			if (got.PlanID == plan.ID.String()) != tt.wantPlan {
+				t.Fatalf("plan_id = %q, want linked: %v", got.PlanID, tt.wantPlan)
+			}
+			if got.ServiceName != tt.wantService {
+				t.Fatalf("service_name = %q, want %q", got.ServiceName, tt.wantService)
+			}
+		})
+	}
+}
+
+func TestUpdateSubscriptionRepricesPlanOnStartChange(t *testing.T) {
+	env := newTestEnv()
+	_, plan := env.createService(t, "Netflix", 500, models.PlanPriceChange{EffectiveFrom: month(2025, 6), Price: 700})
+	id := env.createSubscription(t, dto.CreateSubstractionRequest{PlanID: plan.ID.String(), StartDate: "01-2025"})
+
+	tests := []struct {
+		name  string
+		input dto.UpdateSubscriptionRequest
+		want  int
+	}{
+		{name: "later start", input: dto.UpdateSubscriptionRequest{StartDate: "07-2025"}, want: 700},
+		{name: "full object with stored price", input: dto.UpdateSubscriptionRequest{ServiceName: "Netflix", Price: 700, StartDate: "02-2025"}, want: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := env.usecase.UpdateSubscription(context.Background(), id, tt.input)
+			if err != nil {
+				t.Fatalf("UpdateSubscription: %v", err)
+			}
+			if got.Price != tt.want || got.PlanID != plan.ID.String() {
+				t.Fatalf("price = %d, plan_id = %q; want %d on plan %s", got.Price, got.PlanID, tt.want, plan.ID)
+			}
+		})
+	}
+}
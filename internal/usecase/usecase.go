@@ -6,23 +6,231 @@ import (
 
 	"github.com/I-Van-Radkov/subscription-service/internal/models"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("github.com/I-Van-Radkov/subscription-service/internal/usecase")
+
 type SubscriptionRepo interface {
 	Create(ctx context.Context, sub *models.Subscription) (uuid.UUID, error)
+	// CreateBatch вставляет подписки атомарно: либо все, либо ни одной.
+	CreateBatch(ctx context.Context, subs []*models.Subscription) error
 	GetById(ctx context.Context, id uuid.UUID) (*models.Subscription, error)
+	GetByIdForUpdate(ctx context.Context, id uuid.UUID) (*models.Subscription, error)
 	Update(ctx context.Context, sub *models.Subscription) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, userID uuid.UUID) ([]*models.Subscription, error)
+	List(ctx context.Context, filter models.SubscriptionFilter) ([]*models.Subscription, error)
+	// SumForPeriod складывает цены отрезков цены подписок, пересекающихся с
+	// периодом, по одному разу на отрезок. Цена берётся месячная: годовая
+	// делится на 12 с округлением вниз (models.MonthlyPrice).
 	SumForPeriod(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) (int, error)
+	// SumForPeriodByTag считает суммы как SumForPeriod по тегам; подписка
+	// входит в сумму каждого своего тега, подписки без тегов — в сумму с
+	// пустым тегом.
+	SumForPeriodByTag(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) ([]models.TagTotal, error)
+	// SumForPeriodBySubscription считает суммы как SumForPeriod отдельно для
+	// каждой подписки, пересекающейся с периодом.
+	SumForPeriodBySubscription(ctx context.Context, userID uuid.UUID, serviceName string, start time.Time, end *time.Time) ([]models.SubscriptionTotal, error)
+	// SetMembers заменяет участников подписки. В сводках с userID
+	// разделённая подписка учитывается долей пользователя-участника, а не
+	// полной ценой у владельца.
+	SetMembers(ctx context.Context, subscriptionID uuid.UUID, members []models.SubscriptionMember) error
+	// ListMembers возвращает участников подписки в порядке user_id.
+	ListMembers(ctx context.Context, subscriptionID uuid.UUID) ([]models.SubscriptionMember, error)
+	// SetPauses заменяет паузы подписки. В сводках отрезок цены не
+	// учитывается, если все его месяцы в периоде приостановлены.
+	SetPauses(ctx context.Context, subscriptionID uuid.UUID, pauses []models.SubscriptionPause) error
+	// ListPauses возвращает паузы подписок в порядке start_date; подписок
+	// без пауз в результате нет.
+	ListPauses(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID][]models.SubscriptionPause, error)
+	// CreateCancellation сохраняет отмену подписки; для уже отменённой
+	// возвращает models.ErrAlreadyCancelled.
+	CreateCancellation(ctx context.Context, c models.Cancellation) error
+	// DeleteCancellation снимает отмену; для неотменённой подписки
+	// возвращает models.ErrNotCancelled.
+	DeleteCancellation(ctx context.Context, subscriptionID uuid.UUID) error
+	// ListCancellations возвращает отмены подписок; подписок без отмены в
+	// результате нет.
+	ListCancellations(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID]models.Cancellation, error)
+	// ChurnReport считает отмены, вступающие в силу с start по end, по
+	// причине и сервису; с userID — только подписки пользователя. Самые
+	// частые сочетания идут первыми.
+	ChurnReport(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]models.ChurnTotal, error)
+	// ListTrialsEnding возвращает подписки, пробный период которых
+	// заканчивается в месяце month, если они не заканчиваются вместе с ним.
+	ListTrialsEnding(ctx context.Context, month time.Time) ([]*models.Subscription, error)
+	// ClaimTrialReminder отмечает напоминание о первом платном месяце и
+	// возвращает false, если оно уже было отмечено.
+	ClaimTrialReminder(ctx context.Context, subscriptionID uuid.UUID, paidStart time.Time) (bool, error)
+	// ReleaseTrialReminder снимает отметку, если напоминание не удалось отправить.
+	ReleaseTrialReminder(ctx context.Context, subscriptionID uuid.UUID, paidStart time.Time) error
+	// UnlinkedServiceNames группирует подписки без ссылки на каталог по
+	// service_name, самые частые названия идут первыми.
+	UnlinkedServiceNames(ctx context.Context) ([]models.ServiceNameCount, error)
+	// LinkService привязывает к svc подписки без ссылки на каталог с
+	// service_name, равным name, и возвращает их число.
+	LinkService(ctx context.Context, name string, svc *models.Service) (int, error)
+	// RenameService переписывает service_name привязанных к svc подписок.
+	RenameService(ctx context.Context, svc *models.Service) (int, error)
+
+	ListTags(ctx context.Context) ([]models.LabelCount, error)
+	// RenameTag заменяет тег from на to у всех подписок (теги сливаются,
+	// если to уже есть) и возвращает число подписок с тегом from.
+	RenameTag(ctx context.Context, from, to string) (int, error)
+	DeleteTag(ctx context.Context, name string) (int, error)
+	ListCategories(ctx context.Context) ([]models.LabelCount, error)
+	// RenameCategory заменяет категорию from на to; пустой to снимает её.
+	RenameCategory(ctx context.Context, from, to string) (int, error)
+}
+
+// ServiceRepo — каталог сервисов. FindByName ищет по названию и синонимам
+// без учёта регистра и лишних пробелов; Create и Update возвращают
+// models.ErrServiceNameTaken, если название уже занято другим сервисом.
+type ServiceRepo interface {
+	Create(ctx context.Context, svc *models.Service) error
+	GetById(ctx context.Context, id uuid.UUID) (*models.Service, error)
+	FindByName(ctx context.Context, name string) (*models.Service, error)
+	List(ctx context.Context) ([]*models.Service, error)
+	Update(ctx context.Context, svc *models.Service) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// PlanRepo — тарифы сервисов. Create и Update возвращают
+// models.ErrPlanNameTaken, если у сервиса уже есть тариф с таким названием.
+// Удаление тарифа отвязывает от него подписки.
+type PlanRepo interface {
+	Create(ctx context.Context, plan *models.ServicePlan) error
+	GetById(ctx context.Context, id uuid.UUID) (*models.ServicePlan, error)
+	Update(ctx context.Context, plan *models.ServicePlan) error
+	// SetPriceChange добавляет изменение цены или заменяет изменение с тем
+	// же EffectiveFrom.
+	SetPriceChange(ctx context.Context, planID uuid.UUID, change models.PlanPriceChange) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// CostCenterRepo — центры затрат и распределение по ним подписок. Create и
+// Update возвращают models.ErrCostCenterNameTaken, если название занято,
+// Delete — models.ErrCostCenterInUse, если на центр распределены подписки.
+// Распределение удаляется вместе с подпиской.
+type CostCenterRepo interface {
+	Create(ctx context.Context, cc *models.CostCenter) error
+	GetById(ctx context.Context, id uuid.UUID) (*models.CostCenter, error)
+	List(ctx context.Context) ([]*models.CostCenter, error)
+	Update(ctx context.Context, cc *models.CostCenter) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// SetAllocations заменяет распределение подписки; пустой список его снимает.
+	SetAllocations(ctx context.Context, subscriptionID uuid.UUID, allocations []models.Allocation) error
+	// ListAllocations возвращает распределение подписок; доли каждой
+	// подписки идут от большей к меньшей.
+	ListAllocations(ctx context.Context, subscriptionIDs []uuid.UUID) (map[uuid.UUID][]models.Allocation, error)
+}
+
+// BudgetRepo — бюджеты и отметки об отправленных предупреждениях. Область
+// и период бюджета после создания не меняются.
+type BudgetRepo interface {
+	Create(ctx context.Context, b *models.Budget) error
+	GetById(ctx context.Context, id uuid.UUID) (*models.Budget, error)
+	List(ctx context.Context) ([]*models.Budget, error)
+	Update(ctx context.Context, b *models.Budget) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// ClaimAlert отмечает предупреждение о пороге в периоде и возвращает
+	// false, если оно уже было отмечено: так предупреждение уходит один раз
+	// даже при нескольких экземплярах сервиса.
+	ClaimAlert(ctx context.Context, budgetID uuid.UUID, periodStart time.Time, threshold int) (bool, error)
+	// ReleaseAlert снимает отметку, если предупреждение не удалось отправить.
+	ReleaseAlert(ctx context.Context, budgetID uuid.UUID, periodStart time.Time, threshold int) error
+	AlertedThresholds(ctx context.Context, budgetID uuid.UUID, periodStart time.Time) ([]int, error)
+}
+
+// Notifier доставляет предупреждения о бюджетах.
+type Notifier interface {
+	Notify(ctx context.Context, alert models.BudgetAlert) error
+}
+
+// TrialNotifier доставляет напоминания об окончании пробного периода.
+type TrialNotifier interface {
+	NotifyTrialEnding(ctx context.Context, reminder models.TrialReminder) error
+}
+
+// Transactor выполняет fn атомарно: все вызовы репозиториев с переданным
+// в fn контекстом попадают в одну транзакцию.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type SubscriptionUsecase struct {
 	Repository SubscriptionRepo
+	Services   ServiceRepo
+	Plans      PlanRepo
+	Transactor Transactor
 }
 
-func NewSubscriptionUsecase(repo SubscriptionRepo) *SubscriptionUsecase {
+func NewSubscriptionUsecase(repo SubscriptionRepo, services ServiceRepo, plans PlanRepo, tx Transactor) *SubscriptionUsecase {
 	return &SubscriptionUsecase{
 		Repository: repo,
+		Services:   services,
+		Plans:      plans,
+		Transactor: tx,
+	}
+}
+
+type ServiceUsecase struct {
+	Repository    ServiceRepo
+	Plans         PlanRepo
+	Subscriptions SubscriptionRepo
+	Transactor    Transactor
+}
+
+func NewServiceUsecase(repo ServiceRepo, plans PlanRepo, subs SubscriptionRepo, tx Transactor) *ServiceUsecase {
+	return &ServiceUsecase{
+		Repository:    repo,
+		Plans:         plans,
+		Subscriptions: subs,
+		Transactor:    tx,
+	}
+}
+
+type CostCenterUsecase struct {
+	Repository    CostCenterRepo
+	Subscriptions SubscriptionRepo
+	Transactor    Transactor
+}
+
+func NewCostCenterUsecase(repo CostCenterRepo, subs SubscriptionRepo, tx Transactor) *CostCenterUsecase {
+	return &CostCenterUsecase{
+		Repository:    repo,
+		Subscriptions: subs,
+		Transactor:    tx,
+	}
+}
+
+type BudgetUsecase struct {
+	Repository    BudgetRepo
+	Subscriptions SubscriptionRepo
+	Notifier      Notifier
+}
+
+func NewBudgetUsecase(repo BudgetRepo, subs SubscriptionRepo, notifier Notifier) *BudgetUsecase {
+	return &BudgetUsecase{
+		Repository:    repo,
+		Subscriptions: subs,
+		Notifier:      notifier,
+	}
+}
+
+// TrialReminderUsecase напоминает о первом платном списании за Lead до него.
+type TrialReminderUsecase struct {
+	Subscriptions SubscriptionRepo
+	Plans         PlanRepo
+	Notifier      TrialNotifier
+	Lead          time.Duration
+}
+
+func NewTrialReminderUsecase(subs SubscriptionRepo, plans PlanRepo, notifier TrialNotifier, lead time.Duration) *TrialReminderUsecase {
+	return &TrialReminderUsecase{
+		Subscriptions: subs,
+		Plans:         plans,
+		Notifier:      notifier,
+		Lead:          lead,
 	}
 }
@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/internal/adapter"
+	"github.com/I-Van-Radkov/subscription-service/internal/dto"
+	"github.com/I-Van-Radkov/subscription-service/internal/models"
+	"github.com/I-Van-Radkov/subscription-service/internal/usecase"
+	"github.com/google/uuid"
+)
+
+// testEnv — usecase подписок поверх хранилища в памяти.
+type testEnv struct {
+	store    *adapter.MemoryStore
+	subs     *adapter.MemorySubscriptionRepo
+	services *adapter.MemoryServiceRepo
+	plans    *adapter.MemoryPlanRepo
+	usecase  *usecase.SubscriptionUsecase
+}
+
+func newTestEnv() *testEnv {
+	store := adapter.NewMemoryStore()
+	env := &testEnv{
+		store:    store,
+		subs:     adapter.NewMemorySubscriptionRepo(store),
+		services: adapter.NewMemoryServiceRepo(store),
+		plans:    adapter.NewMemoryPlanRepo(store),
+	}
+	env.usecase = usecase.NewSubscriptionUsecase(env.subs, env.services, env.plans, store)
+	return env
+}
+
+// createService добавляет в каталог сервис name с помесячным тарифом.
+func (e *testEnv) createService(t *testing.T, name string, price int, changes ...models.PlanPriceChange) (*models.Service, *models.ServicePlan) {
+	t.Helper()
+	svc := &models.Service{ID: uuid.New(), Name: name, Aliases: []string{name + " Premium"}}
+	if err := e.services.Create(context.Background(), svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	plan := &models.ServicePlan{
+		ID:            uuid.New(),
+		ServiceID:     svc.ID,
+		Name:          "Standard",
+		Price:         price,
+		BillingPeriod: models.BillingMonthly,
+		Default:       true,
+		PriceChanges:  changes,
+	}
+	if err := e.plans.Create(context.Background(), plan); err != nil {
+		t.Fatalf("create plan: %v", err)
+	}
+	return svc, plan
+}
+
+func (e *testEnv) createSubscription(t *testing.T, input dto.CreateSubstractionRequest) string {
+	t.Helper()
+	if input.UserID == "" {
+		input.UserID = uuid.NewString()
+	}
+	out, err := e.usecase.CreateSubscription(context.Background(), input)
+	if err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+	return out.ID
+}
+
+func (e *testEnv) getSubscription(t *testing.T, id string) dto.GetSubscriptionResponse {
+	t.Helper()
+	out, err := e.usecase.GetSubscription(context.Background(), id, "")
+	if err != nil {
+		t.Fatalf("GetSubscription: %v", err)
+	}
+	return out
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func currentMonth() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_subscriptions_service_id;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS service_id;
+
+DROP INDEX IF EXISTS idx_service_aliases_service_id;
+
+DROP TABLE IF EXISTS service_aliases;
+DROP TABLE IF EXISTS services;
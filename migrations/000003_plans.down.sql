@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_subscriptions_plan_id;
+
+ALTER TABLE subscriptions
+    DROP COLUMN IF EXISTS billing_period,
+    DROP COLUMN IF EXISTS plan_id;
+
+-- Тарифы возвращаются в services.plans с базовой ценой; история цен теряется.
+ALTER TABLE services ADD COLUMN IF NOT EXISTS plans JSONB NOT NULL DEFAULT '[]';
+
+UPDATE services s SET plans = (
+    SELECT COALESCE(jsonb_agg(jsonb_build_object('name', p.name, 'price', p.price, 'default', p.is_default)
+                              ORDER BY p.created_at, p.name), '[]')
+    FROM plans p WHERE p.service_id = s.id
+);
+
+DROP TABLE IF EXISTS plan_price_changes;
+DROP TABLE IF EXISTS plans;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS plans (
+    id UUID PRIMARY KEY,
+    service_id UUID NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    -- Базовая цена: действует до первого изменения из plan_price_changes.
+    price INTEGER NOT NULL CHECK (price > 0),
+    billing_period TEXT NOT NULL DEFAULT 'month' CHECK (billing_period IN ('month', 'year')),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT plans_service_name_unique UNIQUE (service_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS plan_price_changes (
+    plan_id UUID NOT NULL REFERENCES plans (id) ON DELETE CASCADE,
+    effective_from DATE NOT NULL,
+    price INTEGER NOT NULL CHECK (price > 0),
+
+    PRIMARY KEY (plan_id, effective_from)
+);
+
+INSERT INTO plans (id, service_id, name, price, is_default, created_at, updated_at)
+    SELECT gen_random_uuid(), s.id, p->>'name', (p->>'price')::INTEGER,
+           COALESCE((p->>'default')::BOOLEAN, FALSE), s.created_at, s.updated_at
+    FROM services s, jsonb_array_elements(s.plans) p
+    ON CONFLICT (service_id, name) DO NOTHING;
+
+ALTER TABLE services DROP COLUMN IF EXISTS plans;
+
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS plan_id UUID REFERENCES plans (id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS billing_period TEXT NOT NULL DEFAULT 'month'
+        CHECK (billing_period IN ('month', 'year'));
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_plan_id ON subscriptions (plan_id);
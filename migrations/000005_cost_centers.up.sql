@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS cost_centers (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Доли подписки по центрам затрат; сумма долей подписки равна 100, это
+-- проверяет приложение. Центр затрат с долями удалить нельзя.
+CREATE TABLE IF NOT EXISTS subscription_allocations (
+    subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    cost_center_id UUID NOT NULL REFERENCES cost_centers (id),
+    percent INTEGER NOT NULL CHECK (percent > 0 AND percent <= 100),
+
+    PRIMARY KEY (subscription_id, cost_center_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_allocations_cost_center_id ON subscription_allocations (cost_center_id);
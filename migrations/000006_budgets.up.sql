@@ -0,0 +1,23 @@
+-- Бюджет на месяц или год. scope_value — user_id для scope = 'user', тег для
+-- scope = 'tag'; бюджет организации охватывает все подписки.
+CREATE TABLE IF NOT EXISTS budgets (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    scope TEXT NOT NULL CHECK (scope IN ('user', 'tag', 'organization')),
+    scope_value TEXT NOT NULL DEFAULT '',
+    period TEXT NOT NULL CHECK (period IN ('month', 'year')),
+    thresholds INTEGER[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Отправленные предупреждения: по одному на порог в каждом периоде бюджета.
+CREATE TABLE IF NOT EXISTS budget_alerts (
+    budget_id UUID NOT NULL REFERENCES budgets (id) ON DELETE CASCADE,
+    period_start DATE NOT NULL,
+    threshold INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (budget_id, period_start, threshold)
+);
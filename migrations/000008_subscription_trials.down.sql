@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS trial_reminders;
+
+ALTER TABLE subscriptions
+    DROP CONSTRAINT IF EXISTS trial_after_start,
+    DROP CONSTRAINT IF EXISTS intro_months_with_price,
+    DROP COLUMN IF EXISTS intro_months,
+    DROP COLUMN IF EXISTS intro_price,
+    DROP COLUMN IF EXISTS trial_end_date;
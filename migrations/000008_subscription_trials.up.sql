@@ -0,0 +1,22 @@
+-- Пробный период длится со start_date по trial_end_date включительно, затем
+-- intro_months месяцев действует вводная цена intro_price.
+ALTER TABLE subscriptions
+    ADD COLUMN IF NOT EXISTS trial_end_date DATE,
+    ADD COLUMN IF NOT EXISTS intro_price INTEGER CHECK (intro_price > 0),
+    ADD COLUMN IF NOT EXISTS intro_months INTEGER NOT NULL DEFAULT 0 CHECK (intro_months >= 0);
+
+ALTER TABLE subscriptions
+    ADD CONSTRAINT trial_after_start CHECK (trial_end_date IS NULL OR trial_end_date >= start_date),
+    ADD CONSTRAINT intro_months_with_price CHECK (intro_price IS NOT NULL OR intro_months = 0);
+
+-- Отправленные напоминания об окончании пробного периода: по одному на
+-- первый платный месяц подписки.
+CREATE TABLE IF NOT EXISTS trial_reminders (
+    subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    paid_start DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (subscription_id, paid_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_trial_end_date ON subscriptions (trial_end_date);
@@ -0,0 +1,13 @@
+-- Отмена подписки: с effective_date подписка не оплачивается, end_date
+-- подписки — предыдущий месяц. previous_end_date возвращается подписке,
+-- если отмену снимают до effective_date.
+CREATE TABLE IF NOT EXISTS subscription_cancellations (
+    subscription_id UUID PRIMARY KEY REFERENCES subscriptions (id) ON DELETE CASCADE,
+    effective_date DATE NOT NULL,
+    previous_end_date DATE,
+    reason VARCHAR(50) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    cancelled_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_cancellations_effective_date ON subscription_cancellations (effective_date);
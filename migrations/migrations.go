@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// SQLiteFS содержит отдельный набор миграций для SQLite в каталоге sqlite.
+//
+//go:embed sqlite/*.sql
+var SQLiteFS embed.FS
+
+// Latest возвращает номер последней миграции, встроенной в бинарник.
+func Latest() (uint, error) {
+	latest, err := LatestIn(FS)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no embedded migrations found")
+	}
+
+	return latest, nil
+}
+
+// LatestIn возвращает номер последней миграции в корне fsys; 0 — если
+// миграций нет.
+func LatestIn(fsys fs.FS) (uint, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		if uint(v) > latest {
+			latest = uint(v)
+		}
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_subscriptions_start_end_date;
+DROP INDEX IF EXISTS idx_subscriptions_service_name;
+DROP INDEX IF EXISTS idx_subscriptions_user_id;
+
+DROP TABLE IF EXISTS subscriptions;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- Даты хранятся как TEXT в формате YYYY-MM-DD, метки времени — как
+-- YYYY-MM-DD HH:MM:SS.ffffff в UTC. Строки фиксированной длины сравниваются
+-- и сортируются так же, как DATE и TIMESTAMP в Postgres.
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id TEXT PRIMARY KEY,
+    service_name TEXT NOT NULL,
+    price INTEGER NOT NULL CHECK (price > 0),
+    user_id TEXT NOT NULL,
+    start_date TEXT NOT NULL CHECK (start_date = date(start_date)),
+    end_date TEXT CHECK (end_date IS NULL OR end_date = date(end_date)),
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+
+    CONSTRAINT end_after_start CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions (user_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_service_name ON subscriptions (service_name);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_start_end_date
+    ON subscriptions (start_date, end_date);
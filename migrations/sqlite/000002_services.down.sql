@@ -0,0 +1,29 @@
+-- SQLite не удаляет колонку с внешним ключом, поэтому таблица пересоздаётся.
+CREATE TABLE subscriptions_old (
+    id TEXT PRIMARY KEY,
+    service_name TEXT NOT NULL,
+    price INTEGER NOT NULL CHECK (price > 0),
+    user_id TEXT NOT NULL,
+    start_date TEXT NOT NULL CHECK (start_date = date(start_date)),
+    end_date TEXT CHECK (end_date IS NULL OR end_date = date(end_date)),
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+
+    CONSTRAINT end_after_start CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+INSERT INTO subscriptions_old (id, service_name, price, user_id, start_date, end_date, created_at, updated_at)
+    SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at FROM subscriptions;
+
+DROP TABLE subscriptions;
+ALTER TABLE subscriptions_old RENAME TO subscriptions;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions (user_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_service_name ON subscriptions (service_name);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_start_end_date
+    ON subscriptions (start_date, end_date);
+
+DROP INDEX IF EXISTS idx_service_aliases_service_id;
+
+DROP TABLE IF EXISTS service_aliases;
+DROP TABLE IF EXISTS services;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS services (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    logo_url TEXT NOT NULL DEFAULT '',
+    category TEXT NOT NULL DEFAULT '',
+    plans TEXT NOT NULL DEFAULT '[]' CHECK (json_valid(plans)),
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+-- Нормализованные название и синонимы всех сервисов в одном пространстве
+-- ключей: одно написание не может принадлежать двум сервисам.
+CREATE TABLE IF NOT EXISTS service_aliases (
+    alias_key TEXT PRIMARY KEY,
+    alias TEXT NOT NULL,
+    service_id TEXT NOT NULL REFERENCES services (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_service_aliases_service_id ON service_aliases (service_id);
+
+ALTER TABLE subscriptions ADD COLUMN service_id TEXT REFERENCES services (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_service_id ON subscriptions (service_id);
@@ -0,0 +1,38 @@
+-- SQLite не удаляет колонку с внешним ключом, поэтому таблица пересоздаётся.
+CREATE TABLE subscriptions_old (
+    id TEXT PRIMARY KEY,
+    service_name TEXT NOT NULL,
+    price INTEGER NOT NULL CHECK (price > 0),
+    user_id TEXT NOT NULL,
+    start_date TEXT NOT NULL CHECK (start_date = date(start_date)),
+    end_date TEXT CHECK (end_date IS NULL OR end_date = date(end_date)),
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    service_id TEXT REFERENCES services (id) ON DELETE SET NULL,
+
+    CONSTRAINT end_after_start CHECK (end_date IS NULL OR end_date >= start_date)
+);
+
+INSERT INTO subscriptions_old (id, service_name, price, user_id, start_date, end_date, created_at, updated_at, service_id)
+    SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at, service_id FROM subscriptions;
+
+DROP TABLE subscriptions;
+ALTER TABLE subscriptions_old RENAME TO subscriptions;
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_user_id ON subscriptions (user_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_service_name ON subscriptions (service_name);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_start_end_date
+    ON subscriptions (start_date, end_date);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_service_id ON subscriptions (service_id);
+
+-- Тарифы возвращаются в services.plans с базовой ценой; история цен теряется.
+ALTER TABLE services ADD COLUMN plans TEXT NOT NULL DEFAULT '[]' CHECK (json_valid(plans));
+
+UPDATE services SET plans = (
+    SELECT coalesce(json_group_array(json_object('name', p.name, 'price', p.price,
+                                                 'default', json(CASE WHEN p.is_default THEN 'true' ELSE 'false' END))), '[]')
+    FROM (SELECT * FROM plans WHERE service_id = services.id ORDER BY created_at, name) p
+);
+
+DROP TABLE IF EXISTS plan_price_changes;
+DROP TABLE IF EXISTS plans;
@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS plans (
+    id TEXT PRIMARY KEY,
+    service_id TEXT NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    -- Базовая цена: действует до первого изменения из plan_price_changes.
+    price INTEGER NOT NULL CHECK (price > 0),
+    billing_period TEXT NOT NULL DEFAULT 'month' CHECK (billing_period IN ('month', 'year')),
+    is_default INTEGER NOT NULL DEFAULT 0 CHECK (is_default IN (0, 1)),
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+
+    CONSTRAINT plans_service_name_unique UNIQUE (service_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS plan_price_changes (
+    plan_id TEXT NOT NULL REFERENCES plans (id) ON DELETE CASCADE,
+    effective_from TEXT NOT NULL CHECK (effective_from = date(effective_from)),
+    price INTEGER NOT NULL CHECK (price > 0),
+
+    PRIMARY KEY (plan_id, effective_from)
+);
+
+-- UUID v4 из randomblob: в SQLite нет встроенного генератора.
+INSERT OR IGNORE INTO plans (id, service_id, name, price, is_default, created_at, updated_at)
+    SELECT lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+               substr(lower(hex(randomblob(2))), 2) || '-' ||
+               substr('89ab', 1 + abs(random()) % 4, 1) || substr(lower(hex(randomblob(2))), 2) || '-' ||
+               lower(hex(randomblob(6))),
+           s.id, json_extract(p.value, '$.name'), json_extract(p.value, '$.price'),
+           coalesce(json_extract(p.value, '$.default'), 0), s.created_at, s.updated_at
+    FROM services s, json_each(s.plans) p;
+
+ALTER TABLE services DROP COLUMN plans;
+
+ALTER TABLE subscriptions ADD COLUMN plan_id TEXT REFERENCES plans (id) ON DELETE SET NULL;
+ALTER TABLE subscriptions ADD COLUMN billing_period TEXT NOT NULL DEFAULT 'month'
+    CHECK (billing_period IN ('month', 'year'));
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_plan_id ON subscriptions (plan_id);
@@ -0,0 +1,24 @@
+-- Бюджет на месяц или год. scope_value — user_id для scope = 'user', тег для
+-- scope = 'tag'; бюджет организации охватывает все подписки.
+CREATE TABLE IF NOT EXISTS budgets (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    scope TEXT NOT NULL CHECK (scope IN ('user', 'tag', 'organization')),
+    scope_value TEXT NOT NULL DEFAULT '',
+    period TEXT NOT NULL CHECK (period IN ('month', 'year')),
+    -- JSON-массив порогов в процентах.
+    thresholds TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+-- Отправленные предупреждения: по одному на порог в каждом периоде бюджета.
+CREATE TABLE IF NOT EXISTS budget_alerts (
+    budget_id TEXT NOT NULL REFERENCES budgets (id) ON DELETE CASCADE,
+    period_start TEXT NOT NULL CHECK (period_start = date(period_start)),
+    threshold INTEGER NOT NULL,
+    created_at TEXT NOT NULL,
+
+    PRIMARY KEY (budget_id, period_start, threshold)
+);
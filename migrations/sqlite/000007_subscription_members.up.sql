@@ -0,0 +1,11 @@
+-- Участники разделённой подписки. Если участники есть, в сводках по
+-- пользователю стоимость подписки делится между ними пропорционально весам.
+CREATE TABLE IF NOT EXISTS subscription_members (
+    subscription_id TEXT NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    weight INTEGER NOT NULL CHECK (weight > 0 AND weight <= 1000),
+
+    PRIMARY KEY (subscription_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_members_user_id ON subscription_members (user_id);
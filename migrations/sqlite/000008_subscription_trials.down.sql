@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS trial_reminders;
+DROP INDEX IF EXISTS idx_subscriptions_trial_end_date;
+
+ALTER TABLE subscriptions DROP COLUMN intro_months;
+ALTER TABLE subscriptions DROP COLUMN intro_price;
+ALTER TABLE subscriptions DROP COLUMN trial_end_date;
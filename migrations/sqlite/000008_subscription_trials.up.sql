@@ -0,0 +1,19 @@
+-- Пробный период длится со start_date по trial_end_date включительно, затем
+-- intro_months месяцев действует вводная цена intro_price.
+ALTER TABLE subscriptions ADD COLUMN trial_end_date TEXT
+    CHECK (trial_end_date IS NULL OR (trial_end_date = date(trial_end_date) AND trial_end_date >= start_date));
+ALTER TABLE subscriptions ADD COLUMN intro_price INTEGER CHECK (intro_price > 0);
+ALTER TABLE subscriptions ADD COLUMN intro_months INTEGER NOT NULL DEFAULT 0
+    CHECK (intro_months >= 0 AND (intro_price IS NOT NULL OR intro_months = 0));
+
+-- Отправленные напоминания об окончании пробного периода: по одному на
+-- первый платный месяц подписки.
+CREATE TABLE IF NOT EXISTS trial_reminders (
+    subscription_id TEXT NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    paid_start TEXT NOT NULL CHECK (paid_start = date(paid_start)),
+    created_at TEXT NOT NULL,
+
+    PRIMARY KEY (subscription_id, paid_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_trial_end_date ON subscriptions (trial_end_date);
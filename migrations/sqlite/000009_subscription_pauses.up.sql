@@ -0,0 +1,10 @@
+-- Паузы подписки: месяцы с start_date по end_date включительно не
+-- оплачиваются, end_date NULL — пауза до возобновления. Паузы одной
+-- подписки не пересекаются и не примыкают друг к другу.
+CREATE TABLE IF NOT EXISTS subscription_pauses (
+    subscription_id TEXT NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    start_date TEXT NOT NULL CHECK (start_date = date(start_date)),
+    end_date TEXT CHECK (end_date IS NULL OR (end_date = date(end_date) AND end_date >= start_date)),
+
+    PRIMARY KEY (subscription_id, start_date)
+);
@@ -0,0 +1,13 @@
+-- Отмена подписки: с effective_date подписка не оплачивается, end_date
+-- подписки — предыдущий месяц. previous_end_date возвращается подписке,
+-- если отмену снимают до effective_date.
+CREATE TABLE IF NOT EXISTS subscription_cancellations (
+    subscription_id TEXT PRIMARY KEY REFERENCES subscriptions (id) ON DELETE CASCADE,
+    effective_date TEXT NOT NULL CHECK (effective_date = date(effective_date)),
+    previous_end_date TEXT CHECK (previous_end_date IS NULL OR previous_end_date = date(previous_end_date)),
+    reason TEXT NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    cancelled_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscription_cancellations_effective_date ON subscription_cancellations (effective_date);
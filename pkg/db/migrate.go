@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// migrationLockID — ключ advisory lock, под которым реплики приложения по
+// очереди применяют миграции при старте.
+const migrationLockID int64 = 0x5375627363726962 // "Subscrib"
+
+var ErrDirtyMigration = errors.New("database is in dirty migration state")
+
+// NewMigrate создаёт экземпляр golang-migrate для миграций из source.
+// Закрыть его нужно вызовом Close.
+func NewMigrate(config PostgresConfig, source fs.FS) (*migrate.Migrate, error) {
+	src, err := iofs.New(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", src, config.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+
+	return m, nil
+}
+
+// MigrateUp применяет недостающие миграции. Advisory lock держится на
+// отдельном соединении пула, так что несколько реплик, стартующих
+// одновременно, применяют миграции по очереди. Если база в состоянии dirty,
+// миграции не запускаются и возвращается ErrDirtyMigration.
+//
+// ctx ограничивает ожидание блокировки и применение: когда он истекает,
+// начатая миграция доводится до конца, а следующие не запускаются.
+func (d *Database) MigrateUp(ctx context.Context, config PostgresConfig, source fs.FS) (from, to uint, err error) {
+	conn, err := d.Pool.Acquire(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to acquire connection for migration lock: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return 0, 0, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Контекст мог истечь, но блокировку нужно снять в любом случае.
+		if _, unlockErr := conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockID); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to release migration lock: %w", unlockErr))
+		}
+	}()
+
+	m, err := NewMigrate(config, source)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer m.Close()
+
+	from, dirty, err := migrationVersion(m)
+	if err != nil {
+		return 0, 0, err
+	}
+	if dirty {
+		return from, from, fmt.Errorf("%w at version %d: fix the schema manually and run migrate force", ErrDirtyMigration, from)
+	}
+
+	// m.Up не принимает контекст: блокировку golang-migrate ограничивает
+	// LockTimeout, а между миграциями его останавливает GracefulStop.
+	if deadline, ok := ctx.Deadline(); ok {
+		m.LockTimeout = time.Until(deadline)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			m.GracefulStop <- true
+		case <-done:
+		}
+	}()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return from, from, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	to, _, err = migrationVersion(m)
+	if err != nil {
+		return from, from, err
+	}
+	if err := ctx.Err(); err != nil {
+		return from, to, fmt.Errorf("migrations stopped at version %d: %w", to, err)
+	}
+
+	return from, to, nil
+}
+
+// CheckMigrations сообщает текущую версию схемы и возвращает
+// ErrDirtyMigration, если прошлая миграция не завершилась.
+func CheckMigrations(config PostgresConfig, source fs.FS) (uint, error) {
+	m, err := NewMigrate(config, source)
+	if err != nil {
+		return 0, err
+	}
+	defer m.Close()
+
+	version, dirty, err := migrationVersion(m)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return version, fmt.Errorf("%w at version %d: fix the schema manually and run migrate force", ErrDirtyMigration, version)
+	}
+
+	return version, nil
+}
+
+// migrationVersion возвращает 0 для базы без применённых миграций.
+func migrationVersion(m *migrate.Migrate) (uint, bool, error) {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get migration version: %w", err)
+	}
+	return version, dirty, nil
+}
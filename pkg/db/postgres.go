@@ -2,44 +2,216 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const (
+	initialRetryBackoff = 500 * time.Millisecond
+	maxRetryBackoff     = 5 * time.Second
+)
+
+var sslModes = map[string]struct{}{
+	"disable":     {},
+	"allow":       {},
+	"prefer":      {},
+	"require":     {},
+	"verify-ca":   {},
+	"verify-full": {},
+}
+
 type PostgresConfig struct {
-	Username string `env:"POSTGRES_USER" env-default:"postgres"`
-	Password string `env:"POSTGRES_PASSWORD" env-default:"postgres"`
-	Host     string `env:"POSTGRES_HOST" env-default:"db"`
-	Port     string `env:"POSTGRES_PORT" env-default:"5432"`
-	DbName   string `env:"POSTGRES_DB" env-default:"postgres"`
+	Username string `yaml:"user" toml:"user" env:"POSTGRES_USER" env-default:"postgres"`
+	Password string `yaml:"password" toml:"password" env:"POSTGRES_PASSWORD" env-default:"postgres"`
+	Host     string `yaml:"host" toml:"host" env:"POSTGRES_HOST" env-default:"db"`
+	Port     string `yaml:"port" toml:"port" env:"POSTGRES_PORT" env-default:"5432"`
+	DbName   string `yaml:"db" toml:"db" env:"POSTGRES_DB" env-default:"postgres"`
+
+	SSLMode     string `yaml:"sslmode" toml:"sslmode" env:"POSTGRES_SSLMODE" env-default:"disable"`
+	SSLRootCert string `yaml:"sslrootcert" toml:"sslrootcert" env:"POSTGRES_SSLROOTCERT"`
+	SSLCert     string `yaml:"sslcert" toml:"sslcert" env:"POSTGRES_SSLCERT"`
+	SSLKey      string `yaml:"sslkey" toml:"sslkey" env:"POSTGRES_SSLKEY"`
+
+	ApplicationName string `yaml:"application_name" toml:"application_name" env:"POSTGRES_APPLICATION_NAME" env-default:"subscription-service"`
+
+	MaxConns        int32         `yaml:"max_conns" toml:"max_conns" env:"POSTGRES_MAX_CONNS" env-default:"10"`
+	MinConns        int32         `yaml:"min_conns" toml:"min_conns" env:"POSTGRES_MIN_CONNS" env-default:"0"`
+	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime" toml:"max_conn_lifetime" env:"POSTGRES_MAX_CONN_LIFETIME" env-default:"1h"`
+	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time" toml:"max_conn_idle_time" env:"POSTGRES_MAX_CONN_IDLE_TIME" env-default:"30m"`
+	// 0 — без ограничения времени выполнения запроса.
+	StatementTimeout time.Duration `yaml:"statement_timeout" toml:"statement_timeout" env:"POSTGRES_STATEMENT_TIMEOUT" env-default:"0s"`
+
+	// Сколько ждать доступности базы при старте, повторяя попытки с нарастающей паузой.
+	ConnectTimeout time.Duration `yaml:"connect_timeout" toml:"connect_timeout" env:"POSTGRES_CONNECT_TIMEOUT" env-default:"30s"`
+
+	// Реплики в формате host[:port]; учётные данные и база те же, что у primary.
+	ReplicaHosts          []string      `yaml:"replica_hosts" toml:"replica_hosts" env:"POSTGRES_REPLICA_HOSTS" env-separator:","`
+	ReplicaHealthInterval time.Duration `yaml:"replica_health_interval" toml:"replica_health_interval" env:"POSTGRES_REPLICA_HEALTH_INTERVAL" env-default:"5s"`
+	ReadYourWrites        bool          `yaml:"read_your_writes" toml:"read_your_writes" env:"POSTGRES_READ_YOUR_WRITES" env-default:"true"`
+}
+
+func (c PostgresConfig) Validate() error {
+	var errs []error
+	if c.Host == "" {
+		errs = append(errs, errors.New("POSTGRES_HOST: required"))
+	}
+	if port, err := strconv.Atoi(c.Port); err != nil || port <= 0 || port > 65535 {
+		errs = append(errs, fmt.Errorf("POSTGRES_PORT: must be a valid port number, got %q", c.Port))
+	}
+	if c.DbName == "" {
+		errs = append(errs, errors.New("POSTGRES_DB: required"))
+	}
+	if c.Username == "" {
+		errs = append(errs, errors.New("POSTGRES_USER: required"))
+	}
+	if _, ok := sslModes[c.SSLMode]; !ok {
+		errs = append(errs, fmt.Errorf("POSTGRES_SSLMODE: unsupported value %q", c.SSLMode))
+	}
+	if (c.SSLCert == "") != (c.SSLKey == "") {
+		errs = append(errs, errors.New("POSTGRES_SSLCERT and POSTGRES_SSLKEY must be set together"))
+	}
+	if c.MaxConns <= 0 {
+		errs = append(errs, fmt.Errorf("POSTGRES_MAX_CONNS: must be positive, got %d", c.MaxConns))
+	}
+	if c.MinConns < 0 || c.MinConns > c.MaxConns {
+		errs = append(errs, fmt.Errorf("POSTGRES_MIN_CONNS: must be between 0 and POSTGRES_MAX_CONNS, got %d", c.MinConns))
+	}
+	if c.MaxConnLifetime <= 0 {
+		errs = append(errs, fmt.Errorf("POSTGRES_MAX_CONN_LIFETIME: must be positive, got %s", c.MaxConnLifetime))
+	}
+	if c.MaxConnIdleTime <= 0 {
+		errs = append(errs, fmt.Errorf("POSTGRES_MAX_CONN_IDLE_TIME: must be positive, got %s", c.MaxConnIdleTime))
+	}
+	if c.StatementTimeout < 0 {
+		errs = append(errs, fmt.Errorf("POSTGRES_STATEMENT_TIMEOUT: must not be negative, got %s", c.StatementTimeout))
+	}
+	if c.ConnectTimeout < 0 {
+		errs = append(errs, fmt.Errorf("POSTGRES_CONNECT_TIMEOUT: must not be negative, got %s", c.ConnectTimeout))
+	}
+	if len(c.ReplicaHosts) > 0 && c.ReplicaHealthInterval <= 0 {
+		errs = append(errs, fmt.Errorf("POSTGRES_REPLICA_HEALTH_INTERVAL: must be positive, got %s", c.ReplicaHealthInterval))
+	}
+	return errors.Join(errs...)
+}
+
+// DSN собирает строку подключения в формате URL. Её используют и пул
+// приложения, и cmd/migrate.
+func (c PostgresConfig) DSN() string {
+	query := url.Values{}
+	query.Set("sslmode", c.SSLMode)
+	if c.SSLRootCert != "" {
+		query.Set("sslrootcert", c.SSLRootCert)
+	}
+	if c.SSLCert != "" {
+		query.Set("sslcert", c.SSLCert)
+	}
+	if c.SSLKey != "" {
+		query.Set("sslkey", c.SSLKey)
+	}
+	if c.ApplicationName != "" {
+		query.Set("application_name", c.ApplicationName)
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.Username, c.Password),
+		Host:     net.JoinHostPort(c.Host, c.Port),
+		Path:     "/" + c.DbName,
+		RawQuery: query.Encode(),
+	}
+
+	return dsn.String()
 }
 
 type Database struct {
 	Pool *pgxpool.Pool
+
+	replicas       []*replica
+	next           atomic.Uint64
+	readYourWrites bool
+	stopHealth     context.CancelFunc
 }
 
 func New(config PostgresConfig) (*Database, error) {
-	dataSource := fmt.Sprintf("postgres://%v:%v@%v:%v/%v?sslmode=disable",
-		config.Username, config.Password, config.Host, config.Port, config.DbName)
+	pool, err := newPool(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pingWithRetry(pool, config.ConnectTimeout); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	db := &Database{
+		Pool:           pool,
+		readYourWrites: config.ReadYourWrites,
+	}
 
-	pool, err := pgxpool.New(context.Background(), dataSource)
+	// Недоступная при старте реплика не мешает запуску: чтения уйдут в primary,
+	// пока проверка здоровья не вернёт её в ротацию.
+	if err := db.openReplicas(config); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func newPool(config PostgresConfig) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(config.DSN())
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to db after retries: %w", err)
+		return nil, fmt.Errorf("failed to parse db config: %w", err)
+	}
+	poolConfig.MaxConns = config.MaxConns
+	poolConfig.MinConns = config.MinConns
+	poolConfig.MaxConnLifetime = config.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = config.MaxConnIdleTime
+	if config.StatementTimeout > 0 {
+		poolConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(config.StatementTimeout.Milliseconds(), 10)
 	}
+	poolConfig.ConnConfig.Tracer = newQueryTracer()
 
-	err = pool.Ping(context.Background())
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
-		pool.Close()
-		return nil, fmt.Errorf("failed to ping db: %w", err)
+		return nil, fmt.Errorf("failed to create db pool for %s: %w", config.Host, err)
 	}
 
-	return &Database{
-		Pool: pool,
-	}, nil
+	return pool, nil
+}
+
+// pingWithRetry ждёт доступности базы до истечения timeout, увеличивая паузу
+// между попытками вдвое.
+func pingWithRetry(pool *pgxpool.Pool, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	backoff := initialRetryBackoff
+
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), maxRetryBackoff)
+		err := pool.Ping(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+
+		if time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("failed to connect to db after %d attempts: %w", attempt, err)
+		}
+
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
 }
 
 func (d *Database) Close() {
+	d.closeReplicas()
 	if d.Pool != nil {
 		d.Pool.Close()
 	}
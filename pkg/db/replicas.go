@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Querier — общее подмножество методов пула и транзакции, которым пользуются репозитории.
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type replica struct {
+	addr    string
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+}
+
+type ReplicaStatus struct {
+	Addr    string
+	Healthy bool
+}
+
+type sessionKey struct{}
+
+// session отмечает, что в рамках запроса уже была запись, и дальнейшие чтения
+// должны идти в primary (read-your-writes).
+type session struct {
+	wrote atomic.Bool
+}
+
+// WithSession начинает сессию чтения-после-записи для одного запроса.
+func WithSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionKey{}, &session{})
+}
+
+func sessionFrom(ctx context.Context) *session {
+	s, _ := ctx.Value(sessionKey{}).(*session)
+	return s
+}
+
+func (d *Database) openReplicas(config PostgresConfig) error {
+	for _, addr := range config.ReplicaHosts {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			host, port = addr, config.Port
+		}
+
+		replicaConfig := config
+		replicaConfig.Host = host
+		replicaConfig.Port = port
+
+		pool, err := newPool(replicaConfig)
+		if err != nil {
+			d.closeReplicas()
+			return err
+		}
+
+		r := &replica{addr: net.JoinHostPort(host, port), pool: pool}
+		d.replicas = append(d.replicas, r)
+	}
+
+	if len(d.replicas) == 0 {
+		return nil
+	}
+
+	d.checkReplicas()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.stopHealth = cancel
+	go d.watchReplicas(ctx, config.ReplicaHealthInterval)
+
+	return nil
+}
+
+func (d *Database) watchReplicas(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.checkReplicas()
+		}
+	}
+}
+
+func (d *Database) checkReplicas() {
+	for _, r := range d.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), maxRetryBackoff)
+		err := r.pool.Ping(ctx)
+		cancel()
+		r.healthy.Store(err == nil)
+	}
+}
+
+func (d *Database) closeReplicas() {
+	if d.stopHealth != nil {
+		d.stopHealth()
+	}
+	for _, r := range d.replicas {
+		r.pool.Close()
+	}
+	d.replicas = nil
+}
+
+// Writer возвращает соединение для записи (primary) и, если включён
+// read-your-writes, закрепляет оставшиеся чтения запроса за primary.
+func (d *Database) Writer(ctx context.Context) Querier {
+	if tx := txFrom(ctx); tx != nil {
+		return tx
+	}
+	if s := sessionFrom(ctx); s != nil {
+		s.wrote.Store(true)
+	}
+	return d.Pool
+}
+
+// Primary возвращает primary для чтений, которым нужна строгая согласованность.
+func (d *Database) Primary(ctx context.Context) Querier {
+	if tx := txFrom(ctx); tx != nil {
+		return tx
+	}
+	return d.Pool
+}
+
+// Reader выбирает здоровую реплику по кругу. Если реплик нет, все они
+// недоступны или запрос уже писал в базу, чтение идёт в primary.
+// Внутри транзакции чтение выполняется в ней же.
+func (d *Database) Reader(ctx context.Context) Querier {
+	if tx := txFrom(ctx); tx != nil {
+		return tx
+	}
+	if len(d.replicas) == 0 {
+		return d.Pool
+	}
+	if s := sessionFrom(ctx); d.readYourWrites && s != nil && s.wrote.Load() {
+		return d.Pool
+	}
+
+	start := d.next.Add(1)
+	for i := range uint64(len(d.replicas)) {
+		r := d.replicas[(start+i)%uint64(len(d.replicas))]
+		if r.healthy.Load() {
+			return r.pool
+		}
+	}
+
+	return d.Pool
+}
+
+func (d *Database) Replicas() []ReplicaStatus {
+	statuses := make([]ReplicaStatus, 0, len(d.replicas))
+	for _, r := range d.replicas {
+		statuses = append(statuses, ReplicaStatus{Addr: r.addr, Healthy: r.healthy.Load()})
+	}
+	return statuses
+}
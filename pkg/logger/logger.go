@@ -2,58 +2,154 @@ package logger
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 const (
 	loggerRequestIDKey = "x-request-id"
+	loggerTraceIDKey   = "trace_id"
+	loggerSpanIDKey    = "span_id"
+
+	EncodingJSON    = "json"
+	EncodingConsole = "console"
 )
 
+type ctxFieldsKey struct{}
+
 type Logger interface {
 	Info(ctx context.Context, msg string, fields ...zap.Field)
 	Error(ctx context.Context, msg string, fields ...zap.Field)
 	Debug(ctx context.Context, msg string, fields ...zap.Field)
+
+	Level() string
+	SetLevel(level string) error
+}
+
+type LoggerConfig struct {
+	// Пустое значение означает debug для ENV=dev и info для остальных окружений.
+	Level    string `yaml:"level" toml:"level" env:"LOG_LEVEL"`
+	Encoding string `yaml:"encoding" toml:"encoding" env:"LOG_ENCODING" env-default:"json"`
 }
 
 type L struct {
-	z *zap.Logger
+	z     *zap.Logger
+	level zap.AtomicLevel
 }
 
-func NewLogger(env string) Logger {
-	loggerCfg := zap.NewProductionConfig()
-	loggerCfg.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+// EffectiveLevel возвращает уровень логирования с учётом значения по умолчанию для окружения.
+func (c LoggerConfig) EffectiveLevel(env string) string {
+	if c.Level != "" {
+		return c.Level
+	}
 	if env == "dev" {
-		loggerCfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+		return zapcore.DebugLevel.String()
+	}
+	return zapcore.InfoLevel.String()
+}
+
+func (c LoggerConfig) Validate() error {
+	var errs []error
+	if c.Level != "" {
+		if _, err := zapcore.ParseLevel(c.Level); err != nil {
+			errs = append(errs, fmt.Errorf("LOG_LEVEL: %w", err))
+		}
+	}
+	if c.Encoding != "" && c.Encoding != EncodingJSON && c.Encoding != EncodingConsole {
+		errs = append(errs, fmt.Errorf("LOG_ENCODING: must be %q or %q, got %q", EncodingJSON, EncodingConsole, c.Encoding))
 	}
-	logger, err := loggerCfg.Build()
+	return errors.Join(errs...)
+}
+
+func NewLogger(env string, cfg LoggerConfig) (*L, error) {
+	levelName := cfg.EffectiveLevel(env)
+
+	level, err := zap.ParseAtomicLevel(levelName)
 	if err != nil {
-		return nil
+		return nil, fmt.Errorf("invalid log level %q: %w", levelName, err)
+	}
+
+	var loggerCfg zap.Config
+	switch cfg.Encoding {
+	case "", EncodingJSON:
+		loggerCfg = zap.NewProductionConfig()
+	case EncodingConsole:
+		loggerCfg = zap.NewDevelopmentConfig()
+	default:
+		return nil, fmt.Errorf("invalid log encoding %q (expected json or console)", cfg.Encoding)
 	}
-	defer logger.Sync()
+	loggerCfg.Level = level
 
-	lo := L{logger}
-	return &lo
+	logger, err := loggerCfg.Build(zap.AddCallerSkip(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build logger: %w", err)
+	}
+
+	return &L{z: logger, level: level}, nil
+}
+
+// WithFields добавляет в контекст поля, которые будут записаны в каждую
+// строку лога с этим контекстом (пользователь, тенант и т.п.).
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	existing, _ := ctx.Value(ctxFieldsKey{}).([]zap.Field)
+
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+
+	return context.WithValue(ctx, ctxFieldsKey{}, merged)
 }
 
 func WithRequestID(ctx context.Context, requestID string) context.Context {
-	return context.WithValue(ctx, loggerRequestIDKey, requestID)
+	return WithFields(ctx, zap.String(loggerRequestIDKey, requestID))
 }
 
-func (l *L) Info(ctx context.Context, msg string, fields ...zap.Field) {
-	id := ctx.Value(loggerRequestIDKey).(string)
+func contextFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	if ctx == nil {
+		return fields
+	}
 
-	fields = append(fields, zap.String(loggerRequestIDKey, id))
+	if ctxFields, ok := ctx.Value(ctxFieldsKey{}).([]zap.Field); ok {
+		fields = append(fields, ctxFields...)
+	}
+
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return fields
+	}
+	return append(fields,
+		zap.String(loggerTraceIDKey, spanCtx.TraceID().String()),
+		zap.String(loggerSpanIDKey, spanCtx.SpanID().String()),
+	)
+}
 
-	l.z.Info(msg, fields...)
+func (l *L) Info(ctx context.Context, msg string, fields ...zap.Field) {
+	l.z.Info(msg, contextFields(ctx, fields)...)
 }
 func (l *L) Error(ctx context.Context, msg string, fields ...zap.Field) {
-	id := ctx.Value(loggerRequestIDKey).(string)
-	fields = append(fields, zap.String(loggerRequestIDKey, id))
-	l.z.Error(msg, fields...)
+	l.z.Error(msg, contextFields(ctx, fields)...)
 }
 func (l *L) Debug(ctx context.Context, msg string, fields ...zap.Field) {
-	id := ctx.Value(loggerRequestIDKey).(string)
-	fields = append(fields, zap.String(loggerRequestIDKey, id))
-	l.z.Debug(msg, fields...)
+	l.z.Debug(msg, contextFields(ctx, fields)...)
+}
+
+func (l *L) Level() string {
+	return l.level.String()
+}
+
+func (l *L) SetLevel(level string) error {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	l.level.SetLevel(parsed)
+	return nil
+}
+
+func (l *L) Sync() error {
+	return l.z.Sync()
 }
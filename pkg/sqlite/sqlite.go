@@ -0,0 +1,141 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/I-Van-Radkov/subscription-service/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	sqlitedrv "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type SQLiteConfig struct {
+	Path        string        `yaml:"path" toml:"path" env:"SQLITE_PATH" env-default:"./data/subscriptions.db"`
+	BusyTimeout time.Duration `yaml:"busy_timeout" toml:"busy_timeout" env:"SQLITE_BUSY_TIMEOUT" env-default:"5s"`
+}
+
+func (c SQLiteConfig) Validate() error {
+	var errs []error
+	if c.Path == "" {
+		errs = append(errs, errors.New("SQLITE_PATH: required"))
+	}
+	if c.BusyTimeout < 0 {
+		errs = append(errs, fmt.Errorf("SQLITE_BUSY_TIMEOUT: must not be negative, got %s", c.BusyTimeout))
+	}
+	return errors.Join(errs...)
+}
+
+// DSN собирает строку подключения для драйвера modernc.org/sqlite.
+func (c SQLiteConfig) DSN() string {
+	query := url.Values{}
+	query.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", c.BusyTimeout.Milliseconds()))
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Set("_txlock", "immediate")
+
+	return "file:" + c.Path + "?" + query.Encode()
+}
+
+// Querier — общее подмножество методов *sql.DB и *sql.Tx, которым пользуются репозитории.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type Database struct {
+	DB *sql.DB
+}
+
+// New открывает файл базы, создавая его при необходимости, и применяет
+// встроенные миграции: отдельного шага cmd/migrate для SQLite нет.
+func New(config SQLiteConfig) (*Database, error) {
+	if dir := filepath.Dir(config.Path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for sqlite database: %w", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", config.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	// SQLite допускает только одного писателя. Одно соединение избавляет от
+	// SQLITE_BUSY при параллельных запросах ценой их последовательного выполнения.
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	if err := migrateUp(config); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Database{DB: db}, nil
+}
+
+func migrateUp(config SQLiteConfig) error {
+	src, err := iofs.New(migrations.SQLiteFS, "sqlite")
+	if err != nil {
+		return fmt.Errorf("failed to open embedded sqlite migrations: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", config.DSN())
+	if err != nil {
+		return fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	driver, err := migratesqlite.WithInstance(db, &migratesqlite.Config{})
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("failed to create migrate driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "sqlite", driver)
+	if err != nil {
+		db.Close()
+		return fmt.Errorf("failed to create migrate instance: %w", err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to apply sqlite migrations: %w", err)
+	}
+
+	return nil
+}
+
+func (d *Database) Ping(ctx context.Context) error {
+	return d.DB.PingContext(ctx)
+}
+
+func (d *Database) Close() error {
+	return d.DB.Close()
+}
+
+// IsUniqueViolation сообщает, что запись нарушила PRIMARY KEY или UNIQUE.
+func IsUniqueViolation(err error) bool {
+	var sqliteErr *sqlitedrv.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY || sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+// IsForeignKeyViolation сообщает, что запись ссылается на несуществующую строку.
+func IsForeignKeyViolation(err error) bool {
+	var sqliteErr *sqlitedrv.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+}